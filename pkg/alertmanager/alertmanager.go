@@ -0,0 +1,170 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"k8s.io/klog/v2"
+)
+
+// maxResponseBytes bounds the size of the Alertmanager responses
+const maxResponseBytes = 10 * 1024 * 1024
+
+type Alertmanager struct {
+	client               api.KubernetesClient
+	alertmanagerURL      string
+	alertmanagerInsecure bool
+	certificateAuthority string
+	namespace            string
+	service              string
+	port                 string
+	scheme               string
+}
+
+// NewAlertmanager creates a new Alertmanager instance.
+// When a Service is configured, requests go through the API server service proxy with the Kubernetes client credentials.
+// Otherwise, requests go directly to the configured URL with the bearer token of the Kubernetes REST config.
+func NewAlertmanager(configProvider api.ExtendedConfigProvider, client api.KubernetesClient) *Alertmanager {
+	alertmanager := &Alertmanager{client: client}
+	if cfg, ok := configProvider.GetToolsetConfig("alertmanager"); ok {
+		if ac, ok := cfg.(*Config); ok && ac != nil {
+			alertmanager.alertmanagerURL = ac.Url
+			alertmanager.alertmanagerInsecure = ac.Insecure
+			alertmanager.certificateAuthority = ac.CertificateAuthority
+			alertmanager.namespace = ac.Namespace
+			alertmanager.service = ac.Service
+			alertmanager.port = ac.Port
+			alertmanager.scheme = ac.Scheme
+		}
+	}
+	return alertmanager
+}
+
+// serviceProxyName returns the [scheme:]name[:port] service reference used by the API server service proxy
+func (a *Alertmanager) serviceProxyName() string {
+	name := a.service
+	if a.scheme != "" {
+		name = a.scheme + ":" + name
+	}
+	if a.port != "" {
+		name = name + ":" + a.port
+	}
+	return name
+}
+
+// executeRequest executes an Alertmanager API request (optionally with a JSON body) and returns the response body
+func (a *Alertmanager) executeRequest(ctx context.Context, method, endpoint string, params url.Values, body []byte) ([]byte, error) {
+	if a == nil || (a.service == "" && a.alertmanagerURL == "") {
+		return nil, errors.New("alertmanager not configured, set [toolset_configs.alertmanager].url or service")
+	}
+	if a.service != "" {
+		return a.executeServiceProxyRequest(ctx, method, endpoint, params, body)
+	}
+	return a.executeHTTPRequest(ctx, method, endpoint, params, body)
+}
+
+func (a *Alertmanager) executeServiceProxyRequest(ctx context.Context, method, endpoint string, params url.Values, body []byte) ([]byte, error) {
+	req := a.client.CoreV1().RESTClient().Verb(method).
+		Namespace(a.namespace).
+		Resource("services").
+		Name(a.serviceProxyName()).
+		SubResource("proxy").
+		Suffix(endpoint)
+	for key, values := range params {
+		for _, value := range values {
+			req.Param(key, value)
+		}
+	}
+	if body != nil {
+		req.SetHeader("Content-Type", "application/json").Body(body)
+	}
+	klog.V(5).Infof("alertmanager API call (service proxy %s/%s): %s %s", a.namespace, a.serviceProxyName(), method, endpoint)
+	ret, err := req.DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("alertmanager API error: %w", err)
+	}
+	return ret, nil
+}
+
+func (a *Alertmanager) executeHTTPRequest(ctx context.Context, method, endpoint string, params url.Values, body []byte) ([]byte, error) {
+	joined, err := url.JoinPath(strings.TrimSpace(a.alertmanagerURL), endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alertmanager base URL: %w", err)
+	}
+	apiCallURL, err := url.Parse(joined)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse joined URL: %w", err)
+	}
+	apiCallURL.RawQuery = params.Encode()
+	klog.V(5).Infof("alertmanager API call: %s %s", method, apiCallURL)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiCallURL.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	if token := strings.TrimSpace(a.client.RESTConfig().BearerToken); token != "" {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimPrefix(token, "Bearer "))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := a.createHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(respBody) > maxResponseBytes {
+		return nil, fmt.Errorf("alertmanager response exceeds %d bytes, use filters to narrow the results", maxResponseBytes)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(respBody) > 0 {
+			return nil, fmt.Errorf("alertmanager API error: %s", strings.TrimSpace(string(respBody)))
+		}
+		return nil, fmt.Errorf("alertmanager API error: status %d", resp.StatusCode)
+	}
+	return respBody, nil
+}
+
+func (a *Alertmanager) createHTTPClient() *http.Client {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: a.alertmanagerInsecure,
+	}
+	if caValue := strings.TrimSpace(a.certificateAuthority); caValue != "" {
+		caPEM, err := os.ReadFile(caValue)
+		if err != nil {
+			klog.Errorf("failed to read CA certificate from file %s: %v; proceeding without custom CA", caValue, err)
+		} else {
+			certPool, err := x509.SystemCertPool()
+			if err != nil || certPool == nil {
+				certPool = x509.NewCertPool()
+			}
+			if ok := certPool.AppendCertsFromPEM(caPEM); ok {
+				tlsConfig.RootCAs = certPool
+			} else {
+				klog.V(0).Infof("failed to append provided certificate authority; proceeding without custom CA")
+			}
+		}
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
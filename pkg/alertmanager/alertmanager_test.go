@@ -0,0 +1,170 @@
+package alertmanager
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+// restConfigClient is a KubernetesClient providing only the REST config used by the direct URL mode
+type restConfigClient struct {
+	api.KubernetesClient
+	restConfig *rest.Config
+}
+
+func (c *restConfigClient) RESTConfig() *rest.Config {
+	return c.restConfig
+}
+
+type AlertmanagerSuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Config     *config.StaticConfig
+}
+
+func (s *AlertmanagerSuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.MockServer.Config().BearerToken = ""
+	s.Config = test.Must(config.ReadToml([]byte(`
+		[toolset_configs.alertmanager]
+		url = "` + s.MockServer.Config().Host + `/alertmanager"
+	`)))
+}
+
+func (s *AlertmanagerSuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *AlertmanagerSuite) TestConfig() {
+	s.Run("url or service is required", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			insecure = true
+		`))
+		s.ErrorContains(err, "either url or service is required")
+	})
+	s.Run("url and service are mutually exclusive", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			url = "https://alertmanager.example"
+			namespace = "monitoring"
+			service = "alertmanager"
+		`))
+		s.ErrorContains(err, "url and service are mutually exclusive")
+	})
+	s.Run("url must be valid", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			url = "://invalid-url"
+		`))
+		s.ErrorContains(err, "url must be a valid URL")
+	})
+	s.Run("namespace is required with service", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			service = "alertmanager"
+		`))
+		s.ErrorContains(err, "namespace is required when service is set")
+	})
+	s.Run("scheme must be http or https", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			namespace = "monitoring"
+			service = "alertmanager"
+			scheme = "ftp"
+		`))
+		s.ErrorContains(err, "scheme must be http or https, got ftp")
+	})
+	s.Run("service proxy name", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.alertmanager]
+			namespace = "openshift-monitoring"
+			service = "alertmanager-main"
+			port = "web"
+			scheme = "https"
+		`)))
+		a := NewAlertmanager(cfg, nil)
+		s.Equal("https:alertmanager-main:web", a.serviceProxyName())
+	})
+}
+
+func (s *AlertmanagerSuite) TestParseMatcher() {
+	s.Run("equal", func() {
+		m, err := ParseMatcher(`alertname="Watchdog"`)
+		s.Require().NoError(err)
+		s.Equal(&Matcher{Name: "alertname", Value: "Watchdog", IsRegex: false, IsEqual: ptr.To(true)}, m)
+	})
+	s.Run("regex not equal with unquoted value", func() {
+		m, err := ParseMatcher(`namespace !~ test-.*`)
+		s.Require().NoError(err)
+		s.Equal(&Matcher{Name: "namespace", Value: "test-.*", IsRegex: true, IsEqual: ptr.To(false)}, m)
+	})
+	s.Run("escaped quotes", func() {
+		m, err := ParseMatcher(`summary="say \"hi\""`)
+		s.Require().NoError(err)
+		s.Equal(`say "hi"`, m.Value)
+	})
+	s.Run("invalid operator", func() {
+		_, err := ParseMatcher(`severity>critical`)
+		s.ErrorContains(err, `invalid matcher "severity>critical"`)
+	})
+	s.Run("invalid regex", func() {
+		_, err := ParseMatcher(`pod=~"web-("`)
+		s.ErrorContains(err, `invalid matcher "pod=~\"web-(\""`)
+	})
+}
+
+func (s *AlertmanagerSuite) TestDirectURL() {
+	var authorization, path, method string
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization, path, method = r.Header.Get("Authorization"), r.URL.Path, r.Method
+		switch r.URL.Path {
+		case "/alertmanager/api/v2/alerts":
+			_, _ = w.Write([]byte(`[{"fingerprint":"f1","labels":{"alertname":"Watchdog"},"startsAt":"2025-01-01T00:00:00Z","endsAt":"2025-01-01T01:00:00Z","status":{"state":"active","silencedBy":[],"inhibitedBy":[]}}]`))
+		case "/alertmanager/api/v2/silences":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`"silence invalid: invalid label matcher"`))
+		}
+	}))
+	s.MockServer.Config().BearerToken = "rest-token"
+	a := NewAlertmanager(s.Config, &restConfigClient{restConfig: s.MockServer.Config()})
+	s.Run("Alerts returns alerts", func() {
+		alerts, err := a.Alerts(context.Background(), AlertsFilter{})
+		s.Require().NoError(err)
+		s.Require().Len(alerts, 1)
+		s.Equal("Watchdog", alerts[0].Labels["alertname"])
+		s.Equal("Bearer rest-token", authorization)
+		s.Equal("/alertmanager/api/v2/alerts", path)
+	})
+	s.Run("CreateSilence returns alertmanager error", func() {
+		now := time.Now()
+		_, err := a.CreateSilence(context.Background(), Silence{
+			Matchers: []Matcher{{Name: "alertname", Value: "Watchdog"}}, StartsAt: now, EndsAt: now.Add(time.Hour), CreatedBy: "me", Comment: "test",
+		})
+		s.EqualError(err, `alertmanager API error: "silence invalid: invalid label matcher"`)
+		s.Equal(http.MethodPost, method)
+	})
+	s.Run("CreateSilence validates the time range", func() {
+		now := time.Now()
+		_, err := a.CreateSilence(context.Background(), Silence{
+			Matchers: []Matcher{{Name: "alertname", Value: "Watchdog"}}, StartsAt: now, EndsAt: now, CreatedBy: "me", Comment: "test",
+		})
+		s.ErrorContains(err, "must be after start")
+	})
+	s.Run("not configured", func() {
+		_, err := NewAlertmanager(config.Default(), nil).Silences(context.Background(), nil)
+		s.ErrorContains(err, "alertmanager not configured")
+	})
+}
+
+func TestAlertmanager(t *testing.T) {
+	suite.Run(t, new(AlertmanagerSuite))
+}
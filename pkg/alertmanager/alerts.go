@@ -0,0 +1,38 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Alerts returns the alerts known to Alertmanager, by default the active (firing) ones that are not silenced or inhibited are included too
+func (a *Alertmanager) Alerts(ctx context.Context, filter AlertsFilter) ([]Alert, error) {
+	params := url.Values{}
+	for key, value := range map[string]*bool{"active": filter.Active, "silenced": filter.Silenced, "inhibited": filter.Inhibited} {
+		if value != nil {
+			params.Set(key, strconv.FormatBool(*value))
+		}
+	}
+	for _, matcher := range filter.Matchers {
+		if _, err := ParseMatcher(matcher); err != nil {
+			return nil, err
+		}
+		params.Add("filter", matcher)
+	}
+	if filter.Receiver != "" {
+		params.Set("receiver", filter.Receiver)
+	}
+	body, err := a.executeRequest(ctx, http.MethodGet, "/api/v2/alerts", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]Alert, 0)
+	if err = json.Unmarshal(body, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to parse alertmanager alerts: %w", err)
+	}
+	return alerts, nil
+}
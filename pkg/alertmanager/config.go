@@ -0,0 +1,83 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// Config holds Alertmanager toolset configuration.
+// Alertmanager is reached either directly (url) or through the Kubernetes API server service proxy (namespace and service).
+type Config struct {
+	Url                  string `toml:"url,omitempty"`
+	Insecure             bool   `toml:"insecure,omitempty"`
+	CertificateAuthority string `toml:"certificate_authority,omitempty"`
+	// Namespace of the Alertmanager Service when accessed through the API server service proxy
+	Namespace string `toml:"namespace,omitempty"`
+	// Service name of Alertmanager when accessed through the API server service proxy
+	Service string `toml:"service,omitempty"`
+	// Port name or number of the Service (optional, defaults to the first port of the Service)
+	Port string `toml:"port,omitempty"`
+	// Scheme used by the API server to connect to the Service (optional, http or https)
+	Scheme string `toml:"scheme,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("alertmanager config is nil")
+	}
+	if c.Url == "" && c.Service == "" {
+		return errors.New("either url or service is required")
+	}
+	if c.Url != "" && c.Service != "" {
+		return errors.New("url and service are mutually exclusive")
+	}
+	if c.Url != "" {
+		if u, err := url.Parse(c.Url); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("url must be a valid URL")
+		}
+	}
+	if c.Service != "" && c.Namespace == "" {
+		return errors.New("namespace is required when service is set")
+	}
+	if c.Scheme != "" && c.Scheme != "http" && c.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %s", c.Scheme)
+	}
+	if caValue := strings.TrimSpace(c.CertificateAuthority); caValue != "" {
+		if _, err := os.Stat(caValue); err != nil {
+			return fmt.Errorf("certificate_authority must be a valid file path: %w", err)
+		}
+	}
+	return nil
+}
+
+func alertmanagerToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+
+	// If certificate_authority is provided, resolve it relative to the config directory if it's a relative path
+	if cfg.CertificateAuthority != "" {
+		configDir := config.ConfigDirPathFromContext(ctx)
+		if configDir != "" && !filepath.IsAbs(cfg.CertificateAuthority) {
+			cfg.CertificateAuthority = filepath.Join(configDir, cfg.CertificateAuthority)
+		}
+	}
+
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("alertmanager", alertmanagerToolsetParser)
+}
@@ -0,0 +1,53 @@
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+)
+
+var matcherPattern = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+
+// ParseMatcher parses a matcher in the Alertmanager matcher syntax (e.g. alertname="Watchdog", namespace=~"prod-.*", severity!=info)
+func ParseMatcher(matcher string) (*Matcher, error) {
+	groups := matcherPattern.FindStringSubmatch(matcher)
+	if groups == nil {
+		return nil, fmt.Errorf("invalid matcher %q, expected <label><op><value> with op one of =, !=, =~, !~", matcher)
+	}
+	value := groups[3]
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q, malformed quoted value", matcher)
+		}
+		value = unquoted
+	}
+	ret := &Matcher{
+		Name:    groups[1],
+		Value:   value,
+		IsRegex: strings.HasSuffix(groups[2], "~"),
+		IsEqual: ptr.To(!strings.HasPrefix(groups[2], "!")),
+	}
+	if ret.IsRegex {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("invalid matcher %q, %w", matcher, err)
+		}
+	}
+	return ret, nil
+}
+
+// ParseMatchers parses a list of matchers in the Alertmanager matcher syntax
+func ParseMatchers(matchers []string) ([]Matcher, error) {
+	ret := make([]Matcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		m, err := ParseMatcher(matcher)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *m)
+	}
+	return ret, nil
+}
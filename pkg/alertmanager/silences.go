@@ -0,0 +1,77 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+var silenceIDPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// Silences returns the silences known to Alertmanager, optionally filtered by matchers in the Alertmanager matcher syntax
+func (a *Alertmanager) Silences(ctx context.Context, matchers []string) ([]Silence, error) {
+	params := url.Values{}
+	for _, matcher := range matchers {
+		if _, err := ParseMatcher(matcher); err != nil {
+			return nil, err
+		}
+		params.Add("filter", matcher)
+	}
+	body, err := a.executeRequest(ctx, http.MethodGet, "/api/v2/silences", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	silences := make([]Silence, 0)
+	if err = json.Unmarshal(body, &silences); err != nil {
+		return nil, fmt.Errorf("failed to parse alertmanager silences: %w", err)
+	}
+	return silences, nil
+}
+
+// CreateSilence creates a new silence and returns its ID
+func (a *Alertmanager) CreateSilence(ctx context.Context, silence Silence) (string, error) {
+	if len(silence.Matchers) == 0 {
+		return "", errors.New("at least one matcher is required")
+	}
+	if silence.Comment == "" {
+		return "", errors.New("comment is required")
+	}
+	if silence.CreatedBy == "" {
+		return "", errors.New("createdBy is required")
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return "", fmt.Errorf("silence end %s must be after start %s", silence.EndsAt.Format(time.RFC3339), silence.StartsAt.Format(time.RFC3339))
+	}
+	silence.ID = ""
+	silence.Status = nil
+	silence.UpdatedAt = nil
+	payload, err := json.Marshal(silence)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal silence: %w", err)
+	}
+	body, err := a.executeRequest(ctx, http.MethodPost, "/api/v2/silences", nil, payload)
+	if err != nil {
+		return "", err
+	}
+	ret := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err = json.Unmarshal(body, &ret); err != nil {
+		return "", fmt.Errorf("failed to parse alertmanager silence response: %w", err)
+	}
+	return ret.SilenceID, nil
+}
+
+// ExpireSilence expires (deletes) the silence with the provided ID
+func (a *Alertmanager) ExpireSilence(ctx context.Context, id string) error {
+	if !silenceIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid silence id: %s", id)
+	}
+	_, err := a.executeRequest(ctx, http.MethodDelete, "/api/v2/silence/"+id, nil, nil)
+	return err
+}
@@ -0,0 +1,65 @@
+package alertmanager
+
+import "time"
+
+// Matcher is an Alertmanager label matcher
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+// AlertStatus is the state of an alert and the silences or alerts that suppress it
+type AlertStatus struct {
+	State       string   `json:"state"`
+	SilencedBy  []string `json:"silencedBy"`
+	InhibitedBy []string `json:"inhibitedBy"`
+}
+
+// Receiver is an Alertmanager notification receiver
+type Receiver struct {
+	Name string `json:"name"`
+}
+
+// Alert is an alert as returned by the Alertmanager API v2
+type Alert struct {
+	Fingerprint  string            `json:"fingerprint"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	UpdatedAt    time.Time         `json:"updatedAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+	Status       AlertStatus       `json:"status"`
+	Receivers    []Receiver        `json:"receivers,omitempty"`
+}
+
+// SilenceStatus is the state of a silence (active, pending, or expired)
+type SilenceStatus struct {
+	State string `json:"state"`
+}
+
+// Silence is a silence as returned by the Alertmanager API v2
+type Silence struct {
+	ID        string         `json:"id,omitempty"`
+	Matchers  []Matcher      `json:"matchers"`
+	StartsAt  time.Time      `json:"startsAt"`
+	EndsAt    time.Time      `json:"endsAt"`
+	CreatedBy string         `json:"createdBy"`
+	Comment   string         `json:"comment"`
+	Status    *SilenceStatus `json:"status,omitempty"`
+	UpdatedAt *time.Time     `json:"updatedAt,omitempty"`
+}
+
+// AlertsFilter narrows the alerts returned by Alertmanager
+type AlertsFilter struct {
+	// Active, Silenced, and Inhibited include (or exclude) alerts in each state, nil keeps the Alertmanager default (true)
+	Active    *bool
+	Silenced  *bool
+	Inhibited *bool
+	// Matchers in the Alertmanager matcher syntax (e.g. alertname="Watchdog", namespace=~"prod-.*")
+	Matchers []string
+	// Receiver is a regular expression matching the receivers of the alerts
+	Receiver string
+}
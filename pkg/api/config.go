@@ -1,10 +1,17 @@
 package api
 
+import (
+	"context"
+	"time"
+)
+
 const (
-	ClusterProviderKubeConfig = "kubeconfig"
-	ClusterProviderInCluster  = "in-cluster"
-	ClusterProviderDisabled   = "disabled"
-	ClusterProviderKcp        = "kcp"
+	ClusterProviderKubeConfig    = "kubeconfig"
+	ClusterProviderInCluster     = "in-cluster"
+	ClusterProviderDisabled      = "disabled"
+	ClusterProviderKcp           = "kcp"
+	ClusterProviderKubeConfigDir = "kubeconfig-dir"
+	ClusterProviderCapi          = "capi"
 )
 
 type AuthProvider interface {
@@ -46,6 +53,20 @@ type DeniedResourcesProvider interface {
 	GetDeniedResources() []GroupVersionKind
 }
 
+type deniedResourcesContextKey struct{}
+
+// WithDeniedResources returns a copy of ctx overriding the denied resources of the configuration
+// for the requests made with it (e.g. with the denied resources of the target of a tool call).
+func WithDeniedResources(ctx context.Context, deniedResources []GroupVersionKind) context.Context {
+	return context.WithValue(ctx, deniedResourcesContextKey{}, deniedResources)
+}
+
+// DeniedResourcesFromContext returns the denied resources override stored in ctx, if any.
+func DeniedResourcesFromContext(ctx context.Context) ([]GroupVersionKind, bool) {
+	deniedResources, ok := ctx.Value(deniedResourcesContextKey{}).([]GroupVersionKind)
+	return deniedResources, ok
+}
+
 type StsConfigProvider interface {
 	GetStsClientId() string
 	GetStsClientSecret() string
@@ -58,10 +79,20 @@ type ValidationEnabledProvider interface {
 	IsValidationEnabled() bool
 }
 
+// DerivedClientCacheProvider provides access to the derived clients cache settings.
+type DerivedClientCacheProvider interface {
+	// GetDerivedClientCacheSize returns the maximum number of derived clients cached per target
+	// (0 for the default size, negative to disable the cache).
+	GetDerivedClientCacheSize() int
+	// GetDerivedClientCacheTTL returns the maximum time a derived client is cached (0 for the default TTL).
+	GetDerivedClientCacheTTL() time.Duration
+}
+
 type BaseConfig interface {
 	AuthProvider
 	ClusterProvider
 	DeniedResourcesProvider
+	DerivedClientCacheProvider
 	ExtendedConfigProvider
 	StsConfigProvider
 	ValidationEnabledProvider
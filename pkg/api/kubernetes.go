@@ -39,6 +39,42 @@ type NodesTopOptions struct {
 	Name string
 }
 
+// KubernetesClientProvider provides the KubernetesClient of any of the targets (clusters or contexts) served,
+// it is used by the tools that operate on several targets at once (e.g. to compare resources between clusters).
+type KubernetesClientProvider interface {
+	// GetDefaultTarget returns the target used when none is specified
+	GetDefaultTarget() string
+	// GetTargetParameterName returns the name of the target parameter (e.g. context, cluster)
+	GetTargetParameterName() string
+	// GetKubernetesClient returns the KubernetesClient for the target
+	GetKubernetesClient(ctx context.Context, target string) (KubernetesClient, error)
+	// GetTargetClient returns the TargetClient for the target parameter value (a target name or selector),
+	// failing if the tool is not available in the target due to its configuration
+	GetTargetClient(ctx context.Context, value string) (*TargetClient, error)
+}
+
+// TargetClient is the KubernetesClient of a target along with the context to run the requests in the target,
+// which enforces the configuration overrides of the target (e.g. its denied resources).
+type TargetClient struct {
+	context.Context
+	KubernetesClient
+	// Target is the name of the target, resolved from the target parameter value
+	Target string
+}
+
+// TargetMetadata describes a target (cluster or context) so that agents can pick the right one by intent.
+type TargetMetadata struct {
+	Name        string            `json:"name"`
+	Default     bool              `json:"default,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	OpenShift   bool              `json:"openshift"`
+	ServerURL   string            `json:"serverURL,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// Error is set if the target could not be reached to detect its server URL and OpenShift flag
+	Error string `json:"error,omitempty"`
+}
+
 // KubernetesClient defines the interface for Kubernetes operations that tool and prompt handlers need.
 // This interface abstracts the concrete Kubernetes implementation to allow controlled access to the underlying resource APIs,
 // better decoupling, and testability.
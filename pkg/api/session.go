@@ -0,0 +1,26 @@
+package api
+
+import "context"
+
+// Session represents the MCP client session a tool call belongs to.
+// It allows tools that keep server-side state across calls (e.g. interactive exec sessions)
+// to scope that state to the client and release it when the client disconnects.
+type Session interface {
+	// ID returns the session identifier, may be empty for transports without session support (e.g. stdio).
+	ID() string
+	// Wait blocks until the session is closed by the client.
+	Wait() error
+}
+
+type sessionContextKey struct{}
+
+// WithSession returns a copy of ctx carrying the provided Session.
+func WithSession(ctx context.Context, session Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the Session stored in ctx, if any.
+func SessionFromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionContextKey{}).(Session)
+	return session, ok && session != nil
+}
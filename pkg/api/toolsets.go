@@ -13,6 +13,8 @@ type ServerTool struct {
 	Handler            ToolHandlerFunc
 	ClusterAware       *bool
 	TargetListProvider *bool
+	MultiTarget        *bool
+	CrossTarget        *bool
 }
 
 // IsClusterAware indicates whether the tool can accept a "cluster" or "context" parameter
@@ -34,6 +36,26 @@ func (s *ServerTool) IsTargetListProvider() bool {
 	return false
 }
 
+// IsMultiTarget indicates whether the tool can run in several targets (clusters/contexts) at once with the "targets" parameter.
+// The handler of a multi-target tool must print its result with ToolHandlerParams.ListOutput so that the results can be merged.
+// Defaults to false if not explicitly set
+func (s *ServerTool) IsMultiTarget() bool {
+	if s.MultiTarget != nil {
+		return *s.MultiTarget
+	}
+	return false
+}
+
+// IsCrossTarget indicates whether the tool operates across several targets (clusters/contexts) at once, e.g. to compare them.
+// Cross-target tools are only registered if more than one target is available.
+// Defaults to false if not explicitly set
+func (s *ServerTool) IsCrossTarget() bool {
+	if s.CrossTarget != nil {
+		return *s.CrossTarget
+	}
+	return false
+}
+
 type Toolset interface {
 	// GetName returns the name of the toolset.
 	// Used to identify the toolset in configuration, logs, and command-line arguments.
@@ -103,6 +125,8 @@ type ToolHandlerParams struct {
 	KubernetesClient
 	ToolCallRequest
 	ListOutput output.Output
+	// Targets provides the KubernetesClient of the other targets for the tools that operate on several targets
+	Targets KubernetesClientProvider
 }
 
 type ToolHandlerFunc func(params ToolHandlerParams) (*ToolCallResult, error)
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -28,6 +29,22 @@ type StaticConfig struct {
 	SSEBaseURL string `toml:"sse_base_url,omitempty"`
 	KubeConfig string `toml:"kubeconfig,omitempty"`
 	ListOutput string `toml:"list_output,omitempty"`
+	// FanOutConcurrency is the maximum number of targets queried concurrently by a tool called with the targets parameter (defaults to 5)
+	FanOutConcurrency int `toml:"fan_out_concurrency,omitzero"`
+	// FanOutTimeoutSeconds is the timeout for each target queried by a tool called with the targets parameter (defaults to 30)
+	FanOutTimeoutSeconds int `toml:"fan_out_timeout_seconds,omitzero"`
+	// ExecSessionIdleTimeoutSeconds is the inactivity period after which an interactive exec session is terminated (defaults to 600, negative disables it)
+	ExecSessionIdleTimeoutSeconds int `toml:"exec_session_idle_timeout_seconds,omitzero"`
+	// ExecSessionsMax is the maximum number of running interactive exec sessions per MCP session (defaults to 5, negative disables the limit)
+	ExecSessionsMax int `toml:"exec_sessions_max,omitzero"`
+	// PortForwardTTLSeconds is the maximum lifetime of a port forward (defaults to 1800, negative disables it)
+	PortForwardTTLSeconds int `toml:"port_forward_ttl_seconds,omitzero"`
+	// PortForwardsMax is the maximum number of active port forwards per MCP session (defaults to 10, negative disables the limit)
+	PortForwardsMax int `toml:"port_forwards_max,omitzero"`
+	// DerivedClientCacheSize is the maximum number of clients derived from OAuth tokens cached per target (defaults to 100, negative disables the cache)
+	DerivedClientCacheSize int `toml:"derived_client_cache_size,omitzero"`
+	// DerivedClientCacheTTLSeconds is the maximum time a derived client is cached, shortened to the token expiry (defaults to 300)
+	DerivedClientCacheTTLSeconds int `toml:"derived_client_cache_ttl_seconds,omitzero"`
 	// Stateless configures the MCP server to operate in stateless mode.
 	// When true, the server will not send notifications to clients (e.g., tools/list_changed, prompts/list_changed).
 	// This is useful for container deployments, load balancing, and serverless environments where
@@ -82,6 +99,10 @@ type StaticConfig struct {
 	// This map holds raw TOML primitives that will be parsed by registered toolset parsers
 	ToolsetConfigs map[string]toml.Primitive `toml:"toolset_configs,omitempty"`
 
+	// Targets contains the metadata of the cluster provider targets keyed by target name ([targets.<name>])
+	// The metadata is listed by the targets list tool and can be used to select targets (e.g. env=prod)
+	Targets map[string]TargetConfig `toml:"targets,omitempty"`
+
 	// Server instructions to be provided by the MCP server to the MCP client
 	// This can be used to provide specific instructions on how the client should use the server
 	ServerInstructions string `toml:"server_instructions,omitempty"`
@@ -350,3 +371,11 @@ func (c *StaticConfig) GetStsScopes() []string {
 func (c *StaticConfig) IsValidationEnabled() bool {
 	return c.ValidationEnabled
 }
+
+func (c *StaticConfig) GetDerivedClientCacheSize() int {
+	return c.DerivedClientCacheSize
+}
+
+func (c *StaticConfig) GetDerivedClientCacheTTL() time.Duration {
+	return time.Duration(c.DerivedClientCacheTTLSeconds) * time.Second
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// TargetConfig contains the metadata of a cluster provider target (cluster or context) configured in [targets.<name>].
+// The metadata is listed by the targets list tool and matched by the target selectors (e.g. env=prod).
+// It can also override the access control and toolsets settings for the target.
+type TargetConfig struct {
+	// Environment of the target (e.g. "prod", "staging"), selected with the env or environment keys.
+	Environment string `toml:"environment,omitempty"`
+
+	// Region of the target (e.g. "eu-west-1"), selected with the region key.
+	Region string `toml:"region,omitempty"`
+
+	// Labels are arbitrary key-value pairs describing the target (e.g. team = "payments").
+	Labels map[string]string `toml:"labels,omitempty"`
+
+	// ReadOnly overrides the read_only setting for the target.
+	ReadOnly *bool `toml:"read_only,omitempty"`
+
+	// DisableDestructive overrides the disable_destructive setting for the target.
+	DisableDestructive *bool `toml:"disable_destructive,omitempty"`
+
+	// DeniedResources overrides the denied_resources setting for the target, an empty list allows all the resources.
+	DeniedResources []api.GroupVersionKind `toml:"denied_resources,omitempty"`
+
+	// Toolsets overrides the toolsets setting for the target.
+	Toolsets []string `toml:"toolsets,omitempty"`
+}
+
+// HasMetadata returns true if the target has an environment, region or labels.
+func (t TargetConfig) HasMetadata() bool {
+	return t.Environment != "" || t.Region != "" || len(t.Labels) > 0
+}
+
+// HasOverrides returns true if the target overrides any of the access control or toolsets settings.
+func (t TargetConfig) HasOverrides() bool {
+	return t.ReadOnly != nil || t.DisableDestructive != nil || t.DeniedResources != nil || t.Toolsets != nil
+}
+
+// SelectorLabels returns the labels the target selectors are matched against:
+// the configured labels plus the env, environment and region keys.
+func (t TargetConfig) SelectorLabels() labels.Set {
+	set := make(labels.Set, len(t.Labels)+3)
+	for k, v := range t.Labels {
+		set[k] = v
+	}
+	if t.Environment != "" {
+		set["env"] = t.Environment
+		set["environment"] = t.Environment
+	}
+	if t.Region != "" {
+		set["region"] = t.Region
+	}
+	return set
+}
+
+// HasTargetOverrides returns true if any target overrides the access control or toolsets settings.
+func (c *StaticConfig) HasTargetOverrides() bool {
+	for _, target := range c.Targets {
+		if target.HasOverrides() {
+			return true
+		}
+	}
+	return false
+}
+
+// ForTarget returns the configuration for the target, a copy of the configuration with the target overrides
+// applied, or the configuration itself if the target has no overrides.
+func (c *StaticConfig) ForTarget(target string) *StaticConfig {
+	override, ok := c.Targets[target]
+	if !ok || !override.HasOverrides() {
+		return c
+	}
+	ret := *c
+	if override.ReadOnly != nil {
+		ret.ReadOnly = *override.ReadOnly
+	}
+	if override.DisableDestructive != nil {
+		ret.DisableDestructive = *override.DisableDestructive
+	}
+	if override.DeniedResources != nil {
+		ret.DeniedResources = override.DeniedResources
+	}
+	if override.Toolsets != nil {
+		ret.Toolsets = override.Toolsets
+	}
+	return &ret
+}
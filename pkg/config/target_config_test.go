@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type TargetConfigSuite struct {
+	suite.Suite
+}
+
+func TestTargetConfig(t *testing.T) {
+	suite.Run(t, new(TargetConfigSuite))
+}
+
+func (s *TargetConfigSuite) TestReadToml() {
+	cfg, err := ReadToml([]byte(`
+		[targets.prod-eu]
+		environment = "prod"
+		region = "eu-west-1"
+		labels = { team = "payments", tier = "1" }
+		[targets."team-a/workload"]
+		environment = "staging"
+	`))
+	s.Require().NoError(err)
+	s.Run("parses the targets metadata", func() {
+		s.Equal(map[string]TargetConfig{
+			"prod-eu":         {Environment: "prod", Region: "eu-west-1", Labels: map[string]string{"team": "payments", "tier": "1"}},
+			"team-a/workload": {Environment: "staging"},
+		}, cfg.Targets)
+	})
+}
+
+func (s *TargetConfigSuite) TestSelectorLabels() {
+	s.Run("returns the labels with the env, environment and region keys", func() {
+		target := TargetConfig{Environment: "prod", Region: "eu-west-1", Labels: map[string]string{"team": "payments"}}
+		s.Equal(labels.Set{"env": "prod", "environment": "prod", "region": "eu-west-1", "team": "payments"}, target.SelectorLabels())
+	})
+	s.Run("returns empty labels for a target without metadata", func() {
+		s.Empty(TargetConfig{}.SelectorLabels())
+	})
+}
+
+func (s *TargetConfigSuite) TestForTarget() {
+	cfg, err := ReadToml([]byte(`
+		read_only = true
+		toolsets = ["core", "config"]
+		denied_resources = [{group = "", version = "v1", kind = "Secret"}]
+		[targets.dev]
+		read_only = false
+		disable_destructive = true
+		denied_resources = []
+		toolsets = ["core", "helm"]
+		[targets.prod]
+		environment = "prod"
+	`))
+	s.Require().NoError(err)
+	s.Run("HasTargetOverrides returns true if a target overrides the configuration", func() {
+		s.True(cfg.HasTargetOverrides())
+	})
+	s.Run("returns the configuration with the target overrides", func() {
+		dev := cfg.ForTarget("dev")
+		s.NotSame(cfg, dev)
+		s.False(dev.ReadOnly)
+		s.True(dev.DisableDestructive)
+		s.NotNil(dev.DeniedResources)
+		s.Empty(dev.DeniedResources)
+		s.Equal([]string{"core", "helm"}, dev.Toolsets)
+	})
+	s.Run("does not modify the configuration", func() {
+		s.True(cfg.ReadOnly)
+		s.False(cfg.DisableDestructive)
+		s.Len(cfg.DeniedResources, 1)
+		s.Equal([]string{"core", "config"}, cfg.Toolsets)
+	})
+	s.Run("returns the configuration for a target without overrides", func() {
+		s.Same(cfg, cfg.ForTarget("prod"))
+	})
+	s.Run("returns the configuration for an unknown target", func() {
+		s.Same(cfg, cfg.ForTarget("unknown"))
+	})
+	s.Run("HasTargetOverrides returns false if no target overrides the configuration", func() {
+		metadataOnly, err := ReadToml([]byte(`
+			[targets.prod]
+			environment = "prod"
+		`))
+		s.Require().NoError(err)
+		s.False(metadataOnly.HasTargetOverrides())
+	})
+}
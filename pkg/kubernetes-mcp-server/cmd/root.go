@@ -58,9 +58,12 @@ kubernetes-mcp-server --port 8443 --sse-base-url https://example.com:8443
 # start a SSE server on port 8080 with multi-cluster tools disabled
 kubernetes-mcp-server --port 8080 --disable-multi-cluster
 
-# start with explicit cluster provider strategy (kubeconfig, in-cluster, kcp, or disabled)
+# start with explicit cluster provider strategy (kubeconfig, in-cluster, kcp, kubeconfig-dir, capi, or disabled)
 kubernetes-mcp-server --cluster-provider kubeconfig
 
+# start with a cluster target for each kubeconfig file in a directory
+kubernetes-mcp-server --cluster-provider kubeconfig-dir --kubeconfig ~/.kube/clusters
+
 # start with kcp cluster provider for multi-workspace support
 kubernetes-mcp-server --cluster-provider kcp
 `))
@@ -166,7 +169,7 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().StringVar(&o.CertificateAuthority, flagCertificateAuthority, o.CertificateAuthority, "Certificate authority path to verify certificates. Optional. Only valid if require-oauth is enabled.")
 	_ = cmd.Flags().MarkHidden(flagCertificateAuthority)
 	cmd.Flags().BoolVar(&o.DisableMultiCluster, flagDisableMultiCluster, o.DisableMultiCluster, "Disable multi cluster tools. Optional. If true, all tools will be run against the default cluster/context.")
-	cmd.Flags().StringVar(&o.ClusterProvider, flagClusterProvider, o.ClusterProvider, "Cluster provider strategy to use (one of: kubeconfig, in-cluster, kcp, disabled). If not set, the server will auto-detect based on the environment.")
+	cmd.Flags().StringVar(&o.ClusterProvider, flagClusterProvider, o.ClusterProvider, "Cluster provider strategy to use (one of: kubeconfig, in-cluster, kcp, kubeconfig-dir, capi, disabled). If not set, the server will auto-detect based on the environment.")
 
 	return cmd
 }
@@ -268,9 +271,14 @@ func (m *MCPServerOptions) Validate() error {
 	if err := toolsets.Validate(m.StaticConfig.Toolsets); err != nil {
 		return err
 	}
+	for target, targetConfig := range m.StaticConfig.Targets {
+		if err := toolsets.Validate(targetConfig.Toolsets); err != nil {
+			return fmt.Errorf("invalid toolsets for target %s: %w", target, err)
+		}
+	}
 	// Validate cluster provider strategy
 	if m.StaticConfig.ClusterProviderStrategy != "" {
-		validStrategies := []string{api.ClusterProviderKubeConfig, api.ClusterProviderInCluster, api.ClusterProviderKcp, api.ClusterProviderDisabled}
+		validStrategies := []string{api.ClusterProviderKubeConfig, api.ClusterProviderInCluster, api.ClusterProviderKcp, api.ClusterProviderKubeConfigDir, api.ClusterProviderCapi, api.ClusterProviderDisabled}
 		if !slices.Contains(validStrategies, m.StaticConfig.ClusterProviderStrategy) {
 			return fmt.Errorf("invalid cluster-provider: %s, valid values are: %s", m.StaticConfig.ClusterProviderStrategy, strings.Join(validStrategies, ", "))
 		}
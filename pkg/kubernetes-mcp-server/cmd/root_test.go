@@ -320,6 +320,19 @@ func TestToolsets(t *testing.T) {
 			t.Fatalf("Expected toolset to be %s, got %s %v", expected, out.String(), err)
 		}
 	})
+	t.Run("invalid target toolsets throws error", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		configPath := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(configPath, []byte("[targets.prod]\ntoolsets = [\"core\", \"invalid\"]\n"), 0600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--config", configPath})
+		err := rootCmd.Execute()
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid toolsets for target prod: invalid toolset name: invalid") {
+			t.Fatalf("Expected invalid target toolsets error, got %v", err)
+		}
+	})
 }
 
 func TestListOutput(t *testing.T) {
@@ -328,7 +341,7 @@ func TestListOutput(t *testing.T) {
 		rootCmd := NewMCPServer(ioStreams)
 		rootCmd.SetArgs([]string{"--help"})
 		o, err := captureOutput(rootCmd.Execute) // --help doesn't use logger/klog, cobra prints directly to stdout
-		if !strings.Contains(o, "Output format for resource list operations (one of: yaml, table)") {
+		if !strings.Contains(o, "Output format for resource list operations (one of: yaml, table, wide, json, csv, markdown-table, name)") {
 			t.Fatalf("Expected all available outputs, got %s %v", o, err)
 		}
 	})
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -79,7 +80,7 @@ func (rt *AccessControlRoundTripper) RoundTrip(req *http.Request) (*http.Respons
 		}
 		return nil, fmt.Errorf("failed to make request: AccessControlRoundTripper failed to get kind for gvr %v: %w", gvr, err)
 	}
-	if !rt.isAllowed(gvk) {
+	if !rt.isAllowed(req.Context(), gvk) {
 		return nil, fmt.Errorf("resource not allowed: %s", gvk.String())
 	}
 
@@ -126,14 +127,17 @@ func (rt *AccessControlRoundTripper) RoundTrip(req *http.Request) (*http.Respons
 
 // isAllowed checks the resource is in denied list or not.
 // If it is in denied list, this function returns false.
+// The denied list of the context (e.g. of the tool call target) takes precedence over the configured one.
 func (rt *AccessControlRoundTripper) isAllowed(
+	ctx context.Context,
 	gvk schema.GroupVersionKind,
 ) bool {
-	if rt.deniedResourcesProvider == nil {
-		return true
+	deniedResources, ok := api.DeniedResourcesFromContext(ctx)
+	if !ok && rt.deniedResourcesProvider != nil {
+		deniedResources = rt.deniedResourcesProvider.GetDeniedResources()
 	}
 
-	for _, val := range rt.deniedResourcesProvider.GetDeniedResources() {
+	for _, val := range deniedResources {
 		// If kind is empty, that means Group/Version pair is denied entirely
 		if val.Kind == "" {
 			if gvk.Group == val.Group && gvk.Version == val.Version {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/stretchr/testify/suite"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -279,6 +280,33 @@ func (s *AccessControlRoundTripperTestSuite) TestRoundTripForDeniedAPIResources(
 
 	})
 
+	s.Run("Denied resources of the context take precedence", func() {
+		s.Require().NoError(toml.Unmarshal([]byte(`
+			denied_resources = [ { version = "v1", kind = "Pod" } ]
+		`), rt.deniedResourcesProvider), "Expected to parse denied resources config")
+
+		s.Run("Pods are allowed if the context allows all the resources", func() {
+			delegateCalled = false
+			req := httptest.NewRequest("GET", "/api/v1/pods", nil)
+			req = req.WithContext(api.WithDeniedResources(req.Context(), []api.GroupVersionKind{}))
+			resp, err := rt.RoundTrip(req)
+			s.NoError(err)
+			s.NotNil(resp)
+			s.True(delegateCalled)
+		})
+
+		s.Run("Nodes are denied if the context denies them", func() {
+			delegateCalled = false
+			req := httptest.NewRequest("GET", "/api/v1/nodes", nil)
+			req = req.WithContext(api.WithDeniedResources(req.Context(), []api.GroupVersionKind{{Version: "v1", Kind: "Node"}}))
+			resp, err := rt.RoundTrip(req)
+			s.Error(err)
+			s.Nil(resp)
+			s.False(delegateCalled)
+			s.Contains(err.Error(), "resource not allowed")
+		})
+	})
+
 	s.Run("RESTMapper error for unknown resource", func() {
 		rt.deniedResourcesProvider = nil
 		delegateCalled = false
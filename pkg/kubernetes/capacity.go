@@ -0,0 +1,442 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// Severities of the findings reported by CapacityReport
+const (
+	CapacitySeverityCritical = "critical"
+	CapacitySeverityWarning  = "warning"
+	CapacitySeverityInfo     = "info"
+)
+
+// Thresholds used by CapacityReport to report over- and under-provisioning findings
+const (
+	// capacityHighPercent is the allocatable or quota percentage above which a resource is considered close to exhaustion
+	capacityHighPercent = 90
+	// capacityOverProvisionedPercent is the usage percentage of the requests below which a namespace is over-provisioned
+	capacityOverProvisionedPercent = 30
+	// capacityMinCPURequests and capacityMinMemoryRequests avoid reporting over-provisioning for negligible requests
+	capacityMinCPURequests    = 100
+	capacityMinMemoryRequests = 128 * 1024 * 1024
+)
+
+// CapacityResources is an amount of CPU (in millicores) and memory (in bytes)
+type CapacityResources struct {
+	CPUMillicores int64 `json:"cpuMillicores"`
+	MemoryBytes   int64 `json:"memoryBytes"`
+}
+
+// CapacityPercent is the percentage of CPU and memory of a CapacityResources relative to another one
+type CapacityPercent struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+}
+
+// NodeCapacity is the capacity of a Node compared to the requests, limits, and usage of the Pods scheduled on it
+type NodeCapacity struct {
+	Name            string             `json:"name"`
+	Pods            int                `json:"pods"`
+	Allocatable     CapacityResources  `json:"allocatable"`
+	Requests        CapacityResources  `json:"requests"`
+	Limits          CapacityResources  `json:"limits"`
+	Usage           *CapacityResources `json:"usage,omitempty"`
+	RequestsPercent CapacityPercent    `json:"requestsPercent"`
+	LimitsPercent   CapacityPercent    `json:"limitsPercent"`
+	UsagePercent    *CapacityPercent   `json:"usagePercent,omitempty"`
+	Unschedulable   bool               `json:"unschedulable,omitempty"`
+}
+
+// QuotaResourceUsage is the usage of a single resource of a ResourceQuota
+type QuotaResourceUsage struct {
+	Resource string  `json:"resource"`
+	Hard     string  `json:"hard"`
+	Used     string  `json:"used"`
+	Percent  float64 `json:"percent"`
+}
+
+// QuotaUsage is the usage of a ResourceQuota
+type QuotaUsage struct {
+	Name      string               `json:"name"`
+	Resources []QuotaResourceUsage `json:"resources"`
+}
+
+// LimitRangeDefaults are the container defaults and bounds of a LimitRange
+type LimitRangeDefaults struct {
+	Name           string            `json:"name"`
+	Default        map[string]string `json:"default,omitempty"`
+	DefaultRequest map[string]string `json:"defaultRequest,omitempty"`
+	Min            map[string]string `json:"min,omitempty"`
+	Max            map[string]string `json:"max,omitempty"`
+}
+
+// NamespaceCapacity is the requests, limits, usage, quotas, and limit ranges of a Namespace
+type NamespaceCapacity struct {
+	Name     string             `json:"name"`
+	Pods     int                `json:"pods"`
+	Requests CapacityResources  `json:"requests"`
+	Limits   CapacityResources  `json:"limits"`
+	Usage    *CapacityResources `json:"usage,omitempty"`
+	// ContainersWithoutRequests is the number of containers with no CPU or memory requests
+	ContainersWithoutRequests int                  `json:"containersWithoutRequests,omitempty"`
+	Quotas                    []QuotaUsage         `json:"quotas,omitempty"`
+	LimitRanges               []LimitRangeDefaults `json:"limitRanges,omitempty"`
+}
+
+// CapacityFinding is an over- or under-provisioning issue found by CapacityReport
+type CapacityFinding struct {
+	Severity  string `json:"severity"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Resource  string `json:"resource,omitempty"`
+	Message   string `json:"message"`
+}
+
+// CapacityReportResult is the result of CapacityReport
+type CapacityReportResult struct {
+	// MetricsAvailable is false when the Metrics Server is not available, usage is not reported in that case
+	MetricsAvailable bool                `json:"metricsAvailable"`
+	Nodes            []NodeCapacity      `json:"nodes,omitempty"`
+	Namespaces       []NamespaceCapacity `json:"namespaces"`
+	Findings         []CapacityFinding   `json:"findings"`
+}
+
+// CapacityReport compares the Node allocatable resources with the requests and limits of the Pods, the
+// ResourceQuota and LimitRange of each Namespace, and the usage reported by the Metrics Server.
+// Nodes are only reported when namespace is empty (cluster-wide report).
+func (c *Core) CapacityReport(ctx context.Context, namespace string) (*CapacityReportResult, error) {
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	result := &CapacityReportResult{
+		MetricsAvailable: c.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version),
+		Findings:         []CapacityFinding{},
+	}
+	report := &capacityReport{
+		CapacityReportResult: result,
+		nodes:                map[string]*NodeCapacity{},
+		namespaces:           map[string]*NamespaceCapacity{},
+	}
+	if namespace == "" {
+		nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		for _, node := range nodes.Items {
+			report.nodes[node.Name] = &NodeCapacity{
+				Name:          node.Name,
+				Allocatable:   capacityResources(node.Status.Allocatable),
+				Unschedulable: node.Spec.Unschedulable,
+			}
+		}
+	} else {
+		report.namespace(namespace)
+	}
+	for i := range pods.Items {
+		report.addPod(&pods.Items[i])
+	}
+	if err = c.capacityQuotas(ctx, report, namespace); err != nil {
+		return nil, err
+	}
+	if result.MetricsAvailable {
+		c.capacityUsage(ctx, report, namespace)
+	}
+	report.evaluate()
+	return result, nil
+}
+
+type capacityReport struct {
+	*CapacityReportResult
+	nodes      map[string]*NodeCapacity
+	namespaces map[string]*NamespaceCapacity
+}
+
+func (r *capacityReport) namespace(name string) *NamespaceCapacity {
+	if _, ok := r.namespaces[name]; !ok {
+		r.namespaces[name] = &NamespaceCapacity{Name: name}
+	}
+	return r.namespaces[name]
+}
+
+func (r *capacityReport) addPod(pod *v1.Pod) {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return
+	}
+	requests, limits := podRequestsAndLimits(pod)
+	ns := r.namespace(pod.Namespace)
+	ns.Pods++
+	ns.Requests.add(requests)
+	ns.Limits.add(limits)
+	for _, container := range pod.Spec.Containers {
+		if container.Resources.Requests.Cpu().IsZero() || container.Resources.Requests.Memory().IsZero() {
+			ns.ContainersWithoutRequests++
+		}
+	}
+	if node, ok := r.nodes[pod.Spec.NodeName]; ok {
+		node.Pods++
+		node.Requests.add(requests)
+		node.Limits.add(limits)
+	}
+}
+
+func (c *Core) capacityQuotas(ctx context.Context, report *capacityReport, namespace string) error {
+	quotas, err := c.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+	for _, quota := range quotas.Items {
+		usage := QuotaUsage{Name: quota.Name, Resources: []QuotaResourceUsage{}}
+		for _, name := range slices.Sorted(maps.Keys(quota.Status.Hard)) {
+			hard, used := quota.Status.Hard[name], quota.Status.Used[name]
+			usage.Resources = append(usage.Resources, QuotaResourceUsage{
+				Resource: string(name), Hard: hard.String(), Used: used.String(), Percent: percent(used.MilliValue(), hard.MilliValue()),
+			})
+		}
+		ns := report.namespace(quota.Namespace)
+		ns.Quotas = append(ns.Quotas, usage)
+	}
+	limitRanges, err := c.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+	for _, limitRange := range limitRanges.Items {
+		for _, limit := range limitRange.Spec.Limits {
+			if limit.Type != v1.LimitTypeContainer {
+				continue
+			}
+			ns := report.namespace(limitRange.Namespace)
+			ns.LimitRanges = append(ns.LimitRanges, LimitRangeDefaults{
+				Name:           limitRange.Name,
+				Default:        resourceListStrings(limit.Default),
+				DefaultRequest: resourceListStrings(limit.DefaultRequest),
+				Min:            resourceListStrings(limit.Min),
+				Max:            resourceListStrings(limit.Max),
+			})
+		}
+	}
+	return nil
+}
+
+// capacityUsage adds the Metrics Server usage, failures are ignored since usage is optional for the report
+func (c *Core) capacityUsage(ctx context.Context, report *capacityReport, namespace string) {
+	if podMetrics, err := c.MetricsV1beta1Client().PodMetricses(namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		for _, podMetric := range podMetrics.Items {
+			ns, ok := report.namespaces[podMetric.Namespace]
+			if !ok {
+				continue
+			}
+			if ns.Usage == nil {
+				ns.Usage = &CapacityResources{}
+			}
+			for _, container := range podMetric.Containers {
+				ns.Usage.add(container.Usage)
+			}
+		}
+	}
+	if len(report.nodes) == 0 {
+		return
+	}
+	if nodeMetrics, err := c.MetricsV1beta1Client().NodeMetricses().List(ctx, metav1.ListOptions{}); err == nil {
+		for _, nodeMetric := range nodeMetrics.Items {
+			if node, ok := report.nodes[nodeMetric.Name]; ok {
+				usage := capacityResources(nodeMetric.Usage)
+				node.Usage = &usage
+			}
+		}
+	}
+}
+
+// evaluate computes the percentages and findings, and sorts the nodes and namespaces by name
+func (r *capacityReport) evaluate() {
+	for _, name := range slices.Sorted(maps.Keys(r.nodes)) {
+		node := r.nodes[name]
+		node.RequestsPercent = node.Requests.percentOf(node.Allocatable)
+		node.LimitsPercent = node.Limits.percentOf(node.Allocatable)
+		if node.Usage != nil {
+			usagePercent := node.Usage.percentOf(node.Allocatable)
+			node.UsagePercent = &usagePercent
+		}
+		r.evaluateNode(node)
+		r.Nodes = append(r.Nodes, *node)
+	}
+	r.Namespaces = []NamespaceCapacity{}
+	for _, name := range slices.Sorted(maps.Keys(r.namespaces)) {
+		ns := r.namespaces[name]
+		r.evaluateNamespace(ns)
+		r.Namespaces = append(r.Namespaces, *ns)
+	}
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		return capacitySeverityRank(r.Findings[i].Severity) < capacitySeverityRank(r.Findings[j].Severity)
+	})
+}
+
+func (r *capacityReport) evaluateNode(node *NodeCapacity) {
+	finding := func(severity, resource, message string, args ...any) {
+		r.Findings = append(r.Findings, CapacityFinding{
+			Severity: severity, Kind: "Node", Name: node.Name, Resource: resource, Message: fmt.Sprintf(message, args...),
+		})
+	}
+	for _, resource := range []struct {
+		name                    string
+		requests, limits, usage float64
+		hasUsage                bool
+	}{
+		{"cpu", node.RequestsPercent.CPU, node.LimitsPercent.CPU, usagePercent(node.UsagePercent, true), node.UsagePercent != nil},
+		{"memory", node.RequestsPercent.Memory, node.LimitsPercent.Memory, usagePercent(node.UsagePercent, false), node.UsagePercent != nil},
+	} {
+		if resource.hasUsage && resource.usage >= capacityHighPercent {
+			finding(CapacitySeverityCritical, resource.name, "%s usage is %.0f%% of allocatable", resource.name, resource.usage)
+		}
+		if resource.requests >= capacityHighPercent {
+			finding(CapacitySeverityWarning, resource.name, "%s requests are %.0f%% of allocatable, new Pods may not be schedulable", resource.name, resource.requests)
+		}
+		if resource.limits > 100 {
+			finding(CapacitySeverityInfo, resource.name, "%s limits are overcommitted (%.0f%% of allocatable)", resource.name, resource.limits)
+		}
+	}
+}
+
+func (r *capacityReport) evaluateNamespace(ns *NamespaceCapacity) {
+	finding := func(severity, kind, name, resource, message string, args ...any) {
+		r.Findings = append(r.Findings, CapacityFinding{
+			Severity: severity, Kind: kind, Namespace: ns.Name, Name: name, Resource: resource, Message: fmt.Sprintf(message, args...),
+		})
+	}
+	for _, quota := range ns.Quotas {
+		for _, resource := range quota.Resources {
+			if resource.Percent >= 100 {
+				finding(CapacitySeverityCritical, "ResourceQuota", quota.Name, resource.Resource, "%s quota is exhausted (%s of %s used)", resource.Resource, resource.Used, resource.Hard)
+			} else if resource.Percent >= capacityHighPercent {
+				finding(CapacitySeverityWarning, "ResourceQuota", quota.Name, resource.Resource, "%s quota is %.0f%% used (%s of %s)", resource.Resource, resource.Percent, resource.Used, resource.Hard)
+			}
+		}
+	}
+	hasDefaultRequests := slices.ContainsFunc(ns.LimitRanges, func(l LimitRangeDefaults) bool { return len(l.DefaultRequest) > 0 || len(l.Default) > 0 })
+	if ns.ContainersWithoutRequests > 0 && !hasDefaultRequests {
+		finding(CapacitySeverityWarning, "Namespace", ns.Name, "", "%d containers have no CPU or memory requests and no LimitRange provides defaults", ns.ContainersWithoutRequests)
+	}
+	if ns.Usage == nil {
+		return
+	}
+	for _, resource := range []struct {
+		name            string
+		requests, usage int64
+		minRequests     int64
+	}{
+		{"cpu", ns.Requests.CPUMillicores, ns.Usage.CPUMillicores, capacityMinCPURequests},
+		{"memory", ns.Requests.MemoryBytes, ns.Usage.MemoryBytes, capacityMinMemoryRequests},
+	} {
+		if resource.requests < resource.minRequests {
+			continue
+		}
+		usage := percent(resource.usage, resource.requests)
+		if usage < capacityOverProvisionedPercent {
+			finding(CapacitySeverityInfo, "Namespace", ns.Name, resource.name, "over-provisioned: %s usage is %.0f%% of requests", resource.name, usage)
+		} else if usage > 100 {
+			finding(CapacitySeverityWarning, "Namespace", ns.Name, resource.name, "under-provisioned: %s usage is %.0f%% of requests", resource.name, usage)
+		}
+	}
+}
+
+func (r *CapacityResources) add(list v1.ResourceList) {
+	r.CPUMillicores += list.Cpu().MilliValue()
+	r.MemoryBytes += list.Memory().Value()
+}
+
+func (r *CapacityResources) percentOf(total CapacityResources) CapacityPercent {
+	return CapacityPercent{CPU: percent(r.CPUMillicores, total.CPUMillicores), Memory: percent(r.MemoryBytes, total.MemoryBytes)}
+}
+
+func capacityResources(list v1.ResourceList) CapacityResources {
+	r := CapacityResources{}
+	r.add(list)
+	return r
+}
+
+// podRequestsAndLimits returns the effective requests and limits of a Pod: the sum of its containers (including
+// restartable init containers), or the largest init container if greater, plus the Pod overhead
+func podRequestsAndLimits(pod *v1.Pod) (v1.ResourceList, v1.ResourceList) {
+	requests, limits := v1.ResourceList{}, v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+	initRequests, initLimits := v1.ResourceList{}, v1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			addResourceList(requests, container.Resources.Requests)
+			addResourceList(limits, container.Resources.Limits)
+			continue
+		}
+		maxResourceList(initRequests, container.Resources.Requests)
+		maxResourceList(initLimits, container.Resources.Limits)
+	}
+	maxResourceList(requests, initRequests)
+	maxResourceList(limits, initLimits)
+	addResourceList(requests, pod.Spec.Overhead)
+	addResourceList(limits, pod.Spec.Overhead)
+	return requests, limits
+}
+
+func addResourceList(list, add v1.ResourceList) {
+	for name, quantity := range add {
+		value := list[name]
+		value.Add(quantity)
+		list[name] = value
+	}
+}
+
+func maxResourceList(list, other v1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func resourceListStrings(list v1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	ret := make(map[string]string, len(list))
+	for name, quantity := range list {
+		ret[string(name)] = quantity.String()
+	}
+	return ret
+}
+
+func usagePercent(p *CapacityPercent, cpu bool) float64 {
+	switch {
+	case p == nil:
+		return 0
+	case cpu:
+		return p.CPU
+	default:
+		return p.Memory
+	}
+}
+
+// percent returns value as a percentage of total rounded to one decimal
+func percent(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(value)*1000/float64(total)) / 10
+}
+
+func capacitySeverityRank(severity string) int {
+	return slices.Index([]string{CapacitySeverityCritical, CapacitySeverityWarning, CapacitySeverityInfo}, severity)
+}
@@ -0,0 +1,338 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// Sources of the certificates inspected by CertificatesCheck
+const (
+	CertificateSourceSecrets     = "secrets"
+	CertificateSourceCertManager = "certificates"
+	CertificateSourceWebhooks    = "webhooks"
+	CertificateSourceAPIServer   = "apiserver"
+)
+
+// Statuses of the certificates reported by CertificatesCheck
+const (
+	CertificateStatusValid        = "Valid"
+	CertificateStatusExpiringSoon = "ExpiringSoon"
+	CertificateStatusExpired      = "Expired"
+	CertificateStatusNotYetValid  = "NotYetValid"
+	CertificateStatusInvalid      = "Invalid"
+)
+
+// CertificateSources are the sources inspected by CertificatesCheck by default
+var CertificateSources = []string{CertificateSourceSecrets, CertificateSourceCertManager, CertificateSourceWebhooks, CertificateSourceAPIServer}
+
+// apiServerCertificateTimeout is the timeout to retrieve the serving certificate of the API server
+const apiServerCertificateTimeout = 10 * time.Second
+
+// CertificateInfo describes an X.509 certificate found in the cluster.
+// Only public certificate data is included, private keys are never returned.
+type CertificateInfo struct {
+	// Kind of the object the certificate was found in (Secret, Certificate, ValidatingWebhookConfiguration, ...)
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Location of the certificate within the object (Secret key, webhook name, ...)
+	Location string `json:"location,omitempty"`
+	// ChainIndex is the position of the certificate in its PEM bundle (0 is the leaf or first CA)
+	ChainIndex   int       `json:"chainIndex,omitempty"`
+	Subject      string    `json:"subject,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+	DNSNames     []string  `json:"dnsNames,omitempty"`
+	IPAddresses  []string  `json:"ipAddresses,omitempty"`
+	IsCA         bool      `json:"isCA,omitempty"`
+	NotBefore    time.Time `json:"notBefore,omitzero"`
+	NotAfter     time.Time `json:"notAfter,omitzero"`
+	DaysToExpiry int       `json:"daysToExpiry"`
+	Status       string    `json:"status"`
+	Message      string    `json:"message,omitempty"`
+}
+
+// CertificatesReport is the result of CertificatesCheck, certificates are sorted by expiry (soonest first)
+type CertificatesReport struct {
+	Total        int               `json:"total"`
+	Expired      int               `json:"expired"`
+	ExpiringSoon int               `json:"expiringSoon"`
+	Invalid      int               `json:"invalid"`
+	Certificates []CertificateInfo `json:"certificates"`
+	// Skipped lists the sources that couldn't be inspected and why
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// CertificatesCheck inspects the certificates of kubernetes.io/tls Secrets, cert-manager Certificates, admission
+// webhook caBundles, and the serving certificate of the API server.
+// Certificates expiring within expiringDays are reported as ExpiringSoon.
+func (c *Core) CertificatesCheck(ctx context.Context, namespace string, sources []string, expiringDays int) (*CertificatesReport, error) {
+	if len(sources) == 0 {
+		sources = CertificateSources
+	}
+	for _, source := range sources {
+		if !slices.Contains(CertificateSources, source) {
+			return nil, fmt.Errorf("unsupported certificate source: %s, supported sources are %v", source, CertificateSources)
+		}
+	}
+	now := time.Now()
+	check := &certificatesCheck{now: now, expiringSoon: now.Add(time.Duration(expiringDays) * 24 * time.Hour)}
+	report := &CertificatesReport{Certificates: []CertificateInfo{}}
+	for _, source := range sources {
+		var err error
+		switch source {
+		case CertificateSourceSecrets:
+			err = c.certificatesFromSecrets(ctx, check, namespace)
+		case CertificateSourceCertManager:
+			err = c.certificatesFromCertManager(ctx, check, namespace)
+		case CertificateSourceWebhooks:
+			err = c.certificatesFromWebhooks(ctx, check)
+		case CertificateSourceAPIServer:
+			err = c.certificatesFromAPIServer(ctx, check)
+		}
+		if err != nil {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: %v", source, err))
+		}
+	}
+	sort.SliceStable(check.certificates, func(i, j int) bool {
+		a, b := check.certificates[i], check.certificates[j]
+		if a.NotAfter.IsZero() != b.NotAfter.IsZero() {
+			return b.NotAfter.IsZero()
+		}
+		return a.NotAfter.Before(b.NotAfter)
+	})
+	for _, certificate := range check.certificates {
+		switch certificate.Status {
+		case CertificateStatusExpired:
+			report.Expired++
+		case CertificateStatusExpiringSoon:
+			report.ExpiringSoon++
+		case CertificateStatusInvalid:
+			report.Invalid++
+		}
+	}
+	report.Certificates = append(report.Certificates, check.certificates...)
+	report.Total = len(report.Certificates)
+	return report, nil
+}
+
+type certificatesCheck struct {
+	now          time.Time
+	expiringSoon time.Time
+	certificates []CertificateInfo
+}
+
+// addPEM adds the certificates of a PEM bundle, blocks other than CERTIFICATE (e.g. private keys) are ignored
+func (check *certificatesCheck) addPEM(ref CertificateInfo, data []byte) {
+	index := 0
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		info := ref
+		info.ChainIndex = index
+		index++
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			info.Status = CertificateStatusInvalid
+			info.Message = fmt.Sprintf("failed to parse certificate: %v", err)
+			check.certificates = append(check.certificates, info)
+			continue
+		}
+		check.addCertificate(info, certificate)
+	}
+	if index == 0 {
+		ref.Status = CertificateStatusInvalid
+		ref.Message = "no PEM encoded certificate found"
+		check.certificates = append(check.certificates, ref)
+	}
+}
+
+func (check *certificatesCheck) addCertificate(info CertificateInfo, certificate *x509.Certificate) {
+	info.Subject = certificate.Subject.String()
+	info.Issuer = certificate.Issuer.String()
+	info.DNSNames = certificate.DNSNames
+	for _, ip := range certificate.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	info.IsCA = certificate.IsCA
+	info.NotBefore = certificate.NotBefore.UTC()
+	info.NotAfter = certificate.NotAfter.UTC()
+	check.addExpiry(info)
+}
+
+// addExpiry computes the days to expiry and the status of a certificate from its validity period
+func (check *certificatesCheck) addExpiry(info CertificateInfo) {
+	info.DaysToExpiry = int(info.NotAfter.Sub(check.now).Hours() / 24)
+	switch {
+	case check.now.After(info.NotAfter):
+		info.Status = CertificateStatusExpired
+	case !info.NotBefore.IsZero() && check.now.Before(info.NotBefore):
+		info.Status = CertificateStatusNotYetValid
+	case check.expiringSoon.After(info.NotAfter):
+		info.Status = CertificateStatusExpiringSoon
+	default:
+		info.Status = CertificateStatusValid
+	}
+	check.certificates = append(check.certificates, info)
+}
+
+func (c *Core) certificatesFromSecrets(ctx context.Context, check *certificatesCheck, namespace string) error {
+	secrets, err := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(v1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets.Items {
+		if secret.Type != v1.SecretTypeTLS {
+			continue
+		}
+		redactSecretData(&secret)
+		for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+			check.addPEM(CertificateInfo{Kind: "Secret", Namespace: secret.Namespace, Name: secret.Name, Location: key}, secret.Data[key])
+		}
+	}
+	return nil
+}
+
+func (c *Core) certificatesFromCertManager(ctx context.Context, check *certificatesCheck, namespace string) error {
+	gvk := &schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	list, err := c.ResourcesList(ctx, gvk, namespace, api.ListOptions{})
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("cert-manager is not installed")
+	} else if err != nil {
+		return err
+	}
+	items, _ := list.UnstructuredContent()["items"].([]any)
+	for _, item := range items {
+		content, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		certificate := unstructured.Unstructured{Object: content}
+		info := CertificateInfo{Kind: "Certificate", Namespace: certificate.GetNamespace(), Name: certificate.GetName()}
+		info.Location, _, _ = unstructured.NestedString(content, "spec", "secretName")
+		info.DNSNames, _, _ = unstructured.NestedStringSlice(content, "spec", "dnsNames")
+		info.IPAddresses, _, _ = unstructured.NestedStringSlice(content, "spec", "ipAddresses")
+		info.IsCA, _, _ = unstructured.NestedBool(content, "spec", "isCA")
+		if commonName, _, _ := unstructured.NestedString(content, "spec", "commonName"); commonName != "" {
+			info.Subject = "CN=" + commonName
+		}
+		if issuer, _, _ := unstructured.NestedString(content, "spec", "issuerRef", "name"); issuer != "" {
+			kind, _, _ := unstructured.NestedString(content, "spec", "issuerRef", "kind")
+			info.Issuer = fmt.Sprintf("%s/%s", kind, issuer)
+		}
+		conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+		for _, condition := range conditions {
+			if condition, ok := condition.(map[string]any); ok && condition["type"] == "Ready" && condition["status"] != "True" {
+				info.Message = fmt.Sprintf("Not ready: %v", condition["message"])
+			}
+		}
+		notAfter, _, _ := unstructured.NestedString(content, "status", "notAfter")
+		if notAfter == "" {
+			info.Status = CertificateStatusInvalid
+			if info.Message == "" {
+				info.Message = "certificate not issued yet"
+			}
+			check.certificates = append(check.certificates, info)
+			continue
+		}
+		if info.NotAfter, err = time.Parse(time.RFC3339, notAfter); err != nil {
+			info.Status = CertificateStatusInvalid
+			info.Message = fmt.Sprintf("invalid notAfter %s", notAfter)
+			check.certificates = append(check.certificates, info)
+			continue
+		}
+		if notBefore, _, _ := unstructured.NestedString(content, "status", "notBefore"); notBefore != "" {
+			info.NotBefore, _ = time.Parse(time.RFC3339, notBefore)
+		}
+		check.addExpiry(info)
+	}
+	return nil
+}
+
+func (c *Core) certificatesFromWebhooks(ctx context.Context, check *certificatesCheck) error {
+	mutating, err := c.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, configuration := range mutating.Items {
+		for _, webhook := range configuration.Webhooks {
+			if len(webhook.ClientConfig.CABundle) > 0 {
+				check.addPEM(CertificateInfo{Kind: "MutatingWebhookConfiguration", Name: configuration.Name, Location: webhook.Name}, webhook.ClientConfig.CABundle)
+			}
+		}
+	}
+	validating, err := c.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, configuration := range validating.Items {
+		for _, webhook := range configuration.Webhooks {
+			if len(webhook.ClientConfig.CABundle) > 0 {
+				check.addPEM(CertificateInfo{Kind: "ValidatingWebhookConfiguration", Name: configuration.Name, Location: webhook.Name}, webhook.ClientConfig.CABundle)
+			}
+		}
+	}
+	return nil
+}
+
+// certificatesFromAPIServer retrieves the serving certificate chain of the API server with a request through the
+// REST config transport, so that its proxy, dialer and TLS server name are honored.
+// The chain is only inspected, it's not verified so that expired certificates are reported too; the request is
+// anonymous so that no credentials are sent over the unverified connection, but it still goes through the wrapped
+// transport (access control, user agent) as any other request.
+func (c *Core) certificatesFromAPIServer(ctx context.Context, check *certificatesCheck) error {
+	restConfig := rest.AnonymousClientConfig(c.RESTConfig())
+	restConfig.WrapTransport = c.RESTConfig().WrapTransport
+	server, err := url.Parse(restConfig.Host)
+	if err != nil {
+		return err
+	}
+	if server.Scheme != "https" {
+		return fmt.Errorf("the API server %s is not served over TLS", server.Host)
+	}
+	// #nosec G402 -- the certificates are only inspected, the request carries no credentials
+	restConfig.Insecure = true
+	restConfig.CAFile, restConfig.CAData = "", nil
+	restConfig.Timeout = apiServerCertificateTimeout
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return err
+	}
+	defer httpClient.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.JoinPath("version").String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.TLS == nil {
+		return fmt.Errorf("the API server %s didn't present a TLS certificate", server.Host)
+	}
+	for i, certificate := range resp.TLS.PeerCertificates {
+		check.addCertificate(CertificateInfo{Kind: "APIServer", Name: server.Host, ChainIndex: i}, certificate)
+	}
+	return nil
+}
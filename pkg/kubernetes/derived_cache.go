@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	defaultDerivedClientCacheSize = 100
+	defaultDerivedClientCacheTTL  = 5 * time.Minute
+)
+
+// DerivedClientCacheStats are the statistics of the derived clients caches of all the targets
+type DerivedClientCacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+var derivedClientCacheStats struct {
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+}
+
+// GetDerivedClientCacheStats returns the statistics of the derived clients caches since the server started
+func GetDerivedClientCacheStats() DerivedClientCacheStats {
+	return DerivedClientCacheStats{
+		Hits:      derivedClientCacheStats.hits.Load(),
+		Misses:    derivedClientCacheStats.misses.Load(),
+		Evictions: derivedClientCacheStats.evictions.Load(),
+	}
+}
+
+// tokenSignatureAlgorithms are the algorithms accepted to read the claims of the OAuth tokens
+var tokenSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.EdDSA,
+	jose.HS256, jose.HS384, jose.HS512,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+}
+
+// tokenExpiry returns the expiry of the token if it's a JWT with the exp claim.
+// The token is not verified, the expiry is only used to shorten the time its derived client is cached.
+func tokenExpiry(token string) (time.Time, bool) {
+	tkn, err := jwt.ParseSigned(token, tokenSignatureAlgorithms)
+	if err != nil {
+		return time.Time{}, false
+	}
+	claims := jwt.Claims{}
+	if err = tkn.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return time.Time{}, false
+	}
+	return claims.Expiry.Time(), true
+}
+
+type derivedClientKey [sha256.Size]byte
+
+// newDerivedClientKey returns the cache key of the client derived for the token and user agent,
+// the token is hashed so that it's not kept in memory by the cache
+func newDerivedClientKey(token, userAgent string) derivedClientKey {
+	return sha256.Sum256([]byte(token + "\x00" + userAgent))
+}
+
+type derivedClientEntry struct {
+	key        derivedClientKey
+	kubernetes *Kubernetes
+	expires    time.Time
+}
+
+// derivedClientCache is a bounded LRU cache of the clients derived from the OAuth tokens for a target.
+// The clients are cached until their token expires, or for the configured TTL if it expires later.
+type derivedClientCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	lru     *list.List
+	entries map[derivedClientKey]*list.Element
+	now     func() time.Time
+}
+
+// newDerivedClientCache returns the derived clients cache for the configuration, or nil if the cache is disabled
+func newDerivedClientCache(config api.DerivedClientCacheProvider) *derivedClientCache {
+	size := config.GetDerivedClientCacheSize()
+	if size < 0 {
+		return nil
+	}
+	if size == 0 {
+		size = defaultDerivedClientCacheSize
+	}
+	ttl := config.GetDerivedClientCacheTTL()
+	if ttl <= 0 {
+		ttl = defaultDerivedClientCacheTTL
+	}
+	return &derivedClientCache{
+		size:    size,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: make(map[derivedClientKey]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached client for the key, or nil if it's not cached or expired
+func (c *derivedClientCache) get(key derivedClientKey) *Kubernetes {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		derivedClientCacheStats.misses.Add(1)
+		return nil
+	}
+	entry := element.Value.(*derivedClientEntry)
+	if !c.now().Before(entry.expires) {
+		expired := c.remove(element)
+		c.mu.Unlock()
+		expired.close()
+		derivedClientCacheStats.misses.Add(1)
+		return nil
+	}
+	defer c.mu.Unlock()
+	c.lru.MoveToFront(element)
+	derivedClientCacheStats.hits.Add(1)
+	return entry.kubernetes
+}
+
+// add caches the client derived from the token, evicting the least recently used clients above the cache size.
+// The replaced and evicted clients are closed.
+func (c *derivedClientCache) add(key derivedClientKey, token string, k *Kubernetes) {
+	if c == nil {
+		return
+	}
+	now := c.now()
+	expires := now.Add(c.ttl)
+	if tokenExpires, ok := tokenExpiry(token); ok && tokenExpires.Before(expires) {
+		expires = tokenExpires
+	}
+	if !now.Before(expires) {
+		return
+	}
+	var removed []*Kubernetes
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		if previous := element.Value.(*derivedClientEntry).kubernetes; previous != k {
+			removed = append(removed, previous)
+		}
+		element.Value = &derivedClientEntry{key: key, kubernetes: k, expires: expires}
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[key] = c.lru.PushFront(&derivedClientEntry{key: key, kubernetes: k, expires: expires})
+		for c.lru.Len() > c.size {
+			removed = append(removed, c.remove(c.lru.Back()))
+			derivedClientCacheStats.evictions.Add(1)
+		}
+	}
+	c.mu.Unlock()
+	// The connections are released outside the lock, requests in flight with the removed clients are not interrupted
+	for _, removedClient := range removed {
+		removedClient.close()
+	}
+}
+
+// clear removes all the cached clients and returns them
+func (c *derivedClientCache) clear() []*Kubernetes {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make([]*Kubernetes, 0, c.lru.Len())
+	for element := c.lru.Front(); element != nil; element = element.Next() {
+		ret = append(ret, element.Value.(*derivedClientEntry).kubernetes)
+	}
+	c.lru.Init()
+	c.entries = make(map[derivedClientKey]*list.Element)
+	return ret
+}
+
+// remove removes the element from the cache and returns its client, which must be closed by the caller
+func (c *derivedClientCache) remove(element *list.Element) *Kubernetes {
+	c.lru.Remove(element)
+	entry := element.Value.(*derivedClientEntry)
+	delete(c.entries, entry.key)
+	return entry.kubernetes
+}
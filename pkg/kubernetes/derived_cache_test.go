@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type DerivedClientCacheSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *DerivedClientCacheSuite) SetupTest() {
+	s.now = time.Now()
+}
+
+func (s *DerivedClientCacheSuite) newCache(toml string) *derivedClientCache {
+	cache := newDerivedClientCache(test.Must(config.ReadToml([]byte(toml))))
+	if cache != nil {
+		cache.now = func() time.Time { return s.now }
+	}
+	return cache
+}
+
+// closeRecorder is the transport of the test clients, recording whether their connections were released
+type closeRecorder struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *closeRecorder) CloseIdleConnections() {
+	c.closed = true
+}
+
+func (s *DerivedClientCacheSuite) newKubernetes() *Kubernetes {
+	return &Kubernetes{httpClient: &http.Client{Transport: &closeRecorder{}}}
+}
+
+func closed(k *Kubernetes) bool {
+	return k.httpClient.Transport.(*closeRecorder).closed
+}
+
+func (s *DerivedClientCacheSuite) signedToken(expiry time.Time) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("a-secret-key-of-at-least-32-bytes")}, nil)
+	s.Require().NoError(err)
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "user", Expiry: jwt.NewNumericDate(expiry)}).Serialize()
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *DerivedClientCacheSuite) TestConfiguration() {
+	s.Run("uses the default size and TTL", func() {
+		cache := s.newCache(``)
+		s.Equal(defaultDerivedClientCacheSize, cache.size)
+		s.Equal(defaultDerivedClientCacheTTL, cache.ttl)
+	})
+	s.Run("uses the configured size and TTL", func() {
+		cache := s.newCache(`
+			derived_client_cache_size = 10
+			derived_client_cache_ttl_seconds = 60
+		`)
+		s.Equal(10, cache.size)
+		s.Equal(time.Minute, cache.ttl)
+	})
+	s.Run("is disabled with a negative size", func() {
+		cache := s.newCache(`derived_client_cache_size = -1`)
+		s.Nil(cache)
+		key := newDerivedClientKey("token", "agent")
+		cache.add(key, "token", s.newKubernetes())
+		s.Nil(cache.get(key))
+	})
+}
+
+func (s *DerivedClientCacheSuite) TestGet() {
+	cache := s.newCache(`derived_client_cache_size = 2`)
+	k := s.newKubernetes()
+	key := newDerivedClientKey("token", "agent")
+	cache.add(key, "token", k)
+	s.Run("returns the cached client for the same token and user agent", func() {
+		s.Same(k, cache.get(newDerivedClientKey("token", "agent")))
+	})
+	s.Run("returns nil for a different token", func() {
+		s.Nil(cache.get(newDerivedClientKey("other-token", "agent")))
+	})
+	s.Run("returns nil for a different user agent", func() {
+		s.Nil(cache.get(newDerivedClientKey("token", "other-agent")))
+	})
+	s.Run("records the hits and misses", func() {
+		before := GetDerivedClientCacheStats()
+		cache.get(key)
+		cache.get(newDerivedClientKey("other-token", "agent"))
+		after := GetDerivedClientCacheStats()
+		s.Equal(int64(1), after.Hits-before.Hits)
+		s.Equal(int64(1), after.Misses-before.Misses)
+	})
+}
+
+func (s *DerivedClientCacheSuite) TestEviction() {
+	cache := s.newCache(`derived_client_cache_size = 2`)
+	first, second, third := newDerivedClientKey("first", ""), newDerivedClientKey("second", ""), newDerivedClientKey("third", "")
+	firstClient, secondClient := s.newKubernetes(), s.newKubernetes()
+	cache.add(first, "first", firstClient)
+	cache.add(second, "second", secondClient)
+	cache.get(first)
+	before := GetDerivedClientCacheStats()
+	cache.add(third, "third", s.newKubernetes())
+	s.Run("evicts the least recently used client above the cache size", func() {
+		s.Nil(cache.get(second))
+		s.NotNil(cache.get(first))
+		s.NotNil(cache.get(third))
+		s.Equal(2, cache.lru.Len())
+	})
+	s.Run("records the evictions", func() {
+		s.Equal(int64(1), GetDerivedClientCacheStats().Evictions-before.Evictions)
+	})
+	s.Run("closes the evicted client", func() {
+		s.True(closed(secondClient))
+		s.False(closed(firstClient))
+	})
+	s.Run("closes the replaced client", func() {
+		replacement := s.newKubernetes()
+		cache.add(first, "first", replacement)
+		s.True(closed(firstClient))
+		s.False(closed(replacement))
+	})
+}
+
+func (s *DerivedClientCacheSuite) TestExpiry() {
+	cache := s.newCache(`derived_client_cache_ttl_seconds = 60`)
+	s.Run("caches opaque tokens for the TTL", func() {
+		key := newDerivedClientKey("opaque", "")
+		k := s.newKubernetes()
+		cache.add(key, "opaque", k)
+		s.now = s.now.Add(59 * time.Second)
+		s.NotNil(cache.get(key))
+		s.False(closed(k))
+		s.now = s.now.Add(time.Second)
+		s.Nil(cache.get(key))
+		s.NotContains(cache.entries, key)
+		s.True(closed(k), "expected the expired client to be closed")
+	})
+	s.Run("caches JWT tokens until they expire if before the TTL", func() {
+		token := s.signedToken(s.now.Add(10 * time.Second))
+		key := newDerivedClientKey(token, "")
+		cache.add(key, token, s.newKubernetes())
+		s.now = s.now.Add(9 * time.Second)
+		s.NotNil(cache.get(key))
+		s.now = s.now.Add(time.Second)
+		s.Nil(cache.get(key))
+	})
+	s.Run("caches JWT tokens for the TTL if they expire after it", func() {
+		token := s.signedToken(s.now.Add(time.Hour))
+		key := newDerivedClientKey(token, "")
+		cache.add(key, token, s.newKubernetes())
+		s.now = s.now.Add(time.Minute)
+		s.Nil(cache.get(key))
+	})
+	s.Run("does not cache expired JWT tokens", func() {
+		token := s.signedToken(s.now.Add(-time.Second))
+		key := newDerivedClientKey(token, "")
+		cache.add(key, token, s.newKubernetes())
+		s.NotContains(cache.entries, key)
+	})
+}
+
+func TestDerivedClientCache(t *testing.T) {
+	suite.Run(t, new(DerivedClientCacheSuite))
+}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	v1 "k8s.io/api/core/v1"
@@ -28,17 +29,9 @@ func (c *Core) EventsList(ctx context.Context, namespace string) ([]map[string]a
 		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
 			return eventMap, err
 		}
-		timestamp := event.EventTime.Time
-		if timestamp.IsZero() && event.Series != nil {
-			timestamp = event.Series.LastObservedTime.Time
-		} else if timestamp.IsZero() && event.Count > 1 {
-			timestamp = event.LastTimestamp.Time
-		} else if timestamp.IsZero() {
-			timestamp = event.FirstTimestamp.Time
-		}
 		eventMap = append(eventMap, map[string]any{
 			"Namespace": event.Namespace,
-			"Timestamp": timestamp.String(),
+			"Timestamp": eventTimestamp(event).String(),
 			"Type":      event.Type,
 			"Reason":    event.Reason,
 			"InvolvedObject": map[string]string{
@@ -51,3 +44,16 @@ func (c *Core) EventsList(ctx context.Context, namespace string) ([]map[string]a
 	}
 	return eventMap, nil
 }
+
+// eventTimestamp returns the time of the (last occurrence of the) event
+func eventTimestamp(event *v1.Event) time.Time {
+	timestamp := event.EventTime.Time
+	if timestamp.IsZero() && event.Series != nil {
+		timestamp = event.Series.LastObservedTime.Time
+	} else if timestamp.IsZero() && event.Count > 1 {
+		timestamp = event.LastTimestamp.Time
+	} else if timestamp.IsZero() {
+		timestamp = event.FirstTimestamp.Time
+	}
+	return timestamp
+}
@@ -43,6 +43,7 @@ type Kubernetes struct {
 	config          api.BaseConfig
 	clientCmdConfig clientcmd.ClientConfig
 	restConfig      *rest.Config
+	httpClient      *http.Client
 	restMapper      meta.ResettableRESTMapper
 	discoveryClient discovery.CachedDiscoveryInterface
 	dynamicClient   dynamic.Interface
@@ -52,6 +53,11 @@ type Kubernetes struct {
 var _ api.KubernetesClient = (*Kubernetes)(nil)
 
 func NewKubernetes(baseConfig api.BaseConfig, clientCmdConfig clientcmd.ClientConfig, restConfig *rest.Config) (*Kubernetes, error) {
+	return newKubernetes(baseConfig, clientCmdConfig, restConfig, nil)
+}
+
+// newKubernetes creates the Kubernetes client, using the discovery cache of the shared client if provided instead of its own
+func newKubernetes(baseConfig api.BaseConfig, clientCmdConfig clientcmd.ClientConfig, restConfig *rest.Config, shared *Kubernetes) (*Kubernetes, error) {
 	k := &Kubernetes{
 		config:          baseConfig,
 		clientCmdConfig: clientCmdConfig,
@@ -74,27 +80,42 @@ func NewKubernetes(baseConfig api.BaseConfig, clientCmdConfig clientcmd.ClientCo
 	k.restConfig.Wrap(func(original http.RoundTripper) http.RoundTripper {
 		return &UserAgentRoundTripper{delegate: original}
 	})
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(k.restConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	// The clients share the same HTTP client so that its connections can be released by close
+	var err error
+	if k.httpClient, err = rest.HTTPClientFor(k.restConfig); err != nil {
+		return nil, err
 	}
-	k.discoveryClient = memory.NewMemCacheClient(discoveryClient)
-	k.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(k.discoveryClient)
-	k.Interface, err = kubernetes.NewForConfig(k.restConfig)
+	if shared != nil {
+		k.discoveryClient = shared.discoveryClient
+		k.restMapper = shared.restMapper
+	} else {
+		discoveryClient, err := discovery.NewDiscoveryClientForConfigAndClient(k.restConfig, k.httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create discovery client: %w", err)
+		}
+		k.discoveryClient = memory.NewMemCacheClient(discoveryClient)
+		k.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(k.discoveryClient)
+	}
+	k.Interface, err = kubernetes.NewForConfigAndClient(k.restConfig, k.httpClient)
 	if err != nil {
 		return nil, err
 	}
-	k.dynamicClient, err = dynamic.NewForConfig(k.restConfig)
+	k.dynamicClient, err = dynamic.NewForConfigAndClient(k.restConfig, k.httpClient)
 	if err != nil {
 		return nil, err
 	}
-	k.metricsV1beta1, err = metricsv1beta1.NewForConfig(k.restConfig)
+	k.metricsV1beta1, err = metricsv1beta1.NewForConfigAndClient(k.restConfig, k.httpClient)
 	if err != nil {
 		return nil, err
 	}
 	return k, nil
 }
 
+// close releases the idle connections of the clients, requests in flight are not interrupted
+func (k *Kubernetes) close() {
+	k.httpClient.CloseIdleConnections()
+}
+
 func (k *Kubernetes) RESTConfig() *rest.Config {
 	return k.restConfig
 }
@@ -2,14 +2,17 @@ package kubernetes
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -139,6 +142,52 @@ users:
 		})
 	})
 
+	s.Run("with cached derived clients", func() {
+		testStaticConfig := test.Must(config.ReadToml([]byte(`
+			kubeconfig = "` + strings.ReplaceAll(kubeconfigPath, `\`, `\\`) + `"
+		`)))
+		testManager, err := NewKubeconfigManager(testStaticConfig, "")
+		s.Require().NoErrorf(err, "failed to create test manager: %v", err)
+		ctx := context.WithValue(s.T().Context(), HeaderKey("Authorization"), "Bearer aiTana-julIA")
+		derived, err := testManager.Derived(ctx)
+		s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+
+		s.Run("with the same bearer token returns the cached client", func() {
+			cached, err := testManager.Derived(ctx)
+			s.Require().NoErrorf(err, "failed to get derived kubernetes: %v", err)
+			s.Same(derived, cached, "expected cached derived client")
+		})
+		s.Run("with another bearer token returns another client", func() {
+			other, err := testManager.Derived(context.WithValue(s.T().Context(), HeaderKey("Authorization"), "Bearer other-token"))
+			s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+			s.NotSame(derived, other, "expected new derived client")
+			s.Equal("other-token", other.RESTConfig().BearerToken)
+			s.Run("sharing the discovery cache of the target", func() {
+				s.Same(testManager.kubernetes.DiscoveryClient(), other.DiscoveryClient())
+				s.Same(testManager.kubernetes.RESTMapper(), other.RESTMapper())
+				s.Same(derived.DiscoveryClient(), other.DiscoveryClient())
+			})
+		})
+		s.Run("with another user agent returns another client", func() {
+			other, err := testManager.Derived(context.WithValue(ctx, UserAgentHeader, "other-agent"))
+			s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+			s.NotSame(derived, other, "expected new derived client")
+		})
+		s.Run("with the cache disabled returns a new client", func() {
+			disabledConfig := test.Must(config.ReadToml([]byte(`
+				kubeconfig = "` + strings.ReplaceAll(kubeconfigPath, `\`, `\\`) + `"
+				derived_client_cache_size = -1
+			`)))
+			disabledManager, err := NewKubeconfigManager(disabledConfig, "")
+			s.Require().NoErrorf(err, "failed to create test manager: %v", err)
+			first, err := disabledManager.Derived(ctx)
+			s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+			second, err := disabledManager.Derived(ctx)
+			s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+			s.NotSame(first, second, "expected new derived client")
+		})
+	})
+
 	s.Run("with no RequireOAuth (default) and RawConfig error", func() {
 		testStaticConfig := test.Must(config.ReadToml([]byte(`
 			kubeconfig = "` + strings.ReplaceAll(kubeconfigPath, `\`, `\\`) + `"
@@ -293,6 +342,41 @@ users:
 	})
 }
 
+func (s *DerivedTestSuite) TestDiscovery() {
+	mockServer := test.NewMockServer()
+	defer mockServer.Close()
+	var authorizations []string
+	var mu sync.Mutex
+	mockServer.Handle(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api" || strings.HasPrefix(req.URL.Path, "/apis") {
+			mu.Lock()
+			authorizations = append(authorizations, req.Header.Get("Authorization"))
+			mu.Unlock()
+		}
+	}))
+	mockServer.Handle(test.NewDiscoveryClientHandler())
+	testStaticConfig := test.Must(config.ReadToml([]byte(`
+		kubeconfig = "` + strings.ReplaceAll(mockServer.KubeconfigFile(s.T()), `\`, `\\`) + `"
+	`)))
+	testManager, err := NewKubeconfigManager(testStaticConfig, "")
+	s.Require().NoErrorf(err, "failed to create test manager: %v", err)
+	for _, token := range []string{"user-a", "user-b", "user-a"} {
+		derived, err := testManager.Derived(context.WithValue(s.T().Context(), HeaderKey("Authorization"), "Bearer "+token))
+		s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+		testManager.Invalidate()
+		_, err = derived.RESTMapper().RESTMapping(schema.GroupKind{Kind: "Pod"})
+		s.Require().NoErrorf(err, "failed to get REST mapping: %v", err)
+	}
+	s.Run("discovery requests of the derived clients are not authenticated with their tokens", func() {
+		mu.Lock()
+		defer mu.Unlock()
+		s.NotEmpty(authorizations)
+		for _, authorization := range authorizations {
+			s.NotContains(authorization, "user-")
+		}
+	})
+}
+
 func TestDerived(t *testing.T) {
 	suite.Run(t, new(DerivedTestSuite))
 }
@@ -19,6 +19,8 @@ type Manager struct {
 	kubernetes *Kubernetes
 
 	config api.BaseConfig
+
+	derivedClients *derivedClientCache
 }
 
 var _ api.Openshift = (*Manager)(nil)
@@ -52,6 +54,20 @@ func NewKubeconfigManager(config api.BaseConfig, kubeconfigContext string) (*Man
 	return NewManager(config, restConfig, clientCmdConfig)
 }
 
+// NewKubeconfigDataManager creates a Manager for the current context of the provided kubeconfig contents,
+// used by the providers that discover their targets from kubeconfig files or Secrets.
+func NewKubeconfigDataManager(config api.BaseConfig, kubeconfig []byte) (*Manager, error) {
+	clientCmdConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	restConfig, err := clientCmdConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes rest config from kubeconfig: %w", err)
+	}
+	return NewManager(config, restConfig, clientCmdConfig)
+}
+
 func NewInClusterManager(config api.BaseConfig) (*Manager, error) {
 	if config.GetKubeConfigPath() != "" {
 		return nil, fmt.Errorf("kubeconfig file %s cannot be used with the in-cluster deployments: %w", config.GetKubeConfigPath(), ErrorKubeconfigInClusterNotAllowed)
@@ -99,7 +115,8 @@ func NewManager(config api.BaseConfig, restConfig *rest.Config, clientCmdConfig
 	applyRateLimitFromEnv(restConfig)
 
 	k8s := &Manager{
-		config: config,
+		config:         config,
+		derivedClients: newDerivedClientCache(config),
 	}
 	var err error
 	// TODO: Won't work because not all client-go clients use the shared context (e.g. discovery client uses context.TODO())
@@ -122,10 +139,15 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 		return m.kubernetes, nil
 	}
 	klog.V(5).Infof("%s header found (Bearer), using provided bearer token", OAuthAuthorizationHeader)
+	token := strings.TrimPrefix(authorization, "Bearer ")
 	userAgent := CustomUserAgent
 	if ua, ok := ctx.Value(UserAgentHeader).(string); ok && ua != "" {
 		userAgent = ua
 	}
+	key := newDerivedClientKey(token, userAgent)
+	if derived := m.derivedClients.get(key); derived != nil {
+		return derived, nil
+	}
 	derivedCfg := &rest.Config{
 		Host:          m.kubernetes.RESTConfig().Host,
 		APIPath:       m.kubernetes.RESTConfig().APIPath,
@@ -137,7 +159,7 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 			CAFile:     m.kubernetes.RESTConfig().CAFile,
 			CAData:     m.kubernetes.RESTConfig().CAData,
 		},
-		BearerToken: strings.TrimPrefix(authorization, "Bearer "),
+		BearerToken: token,
 		// pass custom UserAgent to identify the client
 		UserAgent:   userAgent,
 		QPS:         m.kubernetes.RESTConfig().QPS,
@@ -154,15 +176,17 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 		return m.kubernetes, nil
 	}
 	clientCmdApiConfig.AuthInfos = make(map[string]*clientcmdapi.AuthInfo)
-	derived, err := NewKubernetes(m.config, clientcmd.NewDefaultClientConfig(clientCmdApiConfig, nil), derivedCfg)
-	if err != nil {
-		if m.config.IsRequireOAuth() {
-			klog.Errorf("failed to create derived client: %v", err)
-			return nil, fmt.Errorf("failed to create derived client: %w", err)
-		}
-		return m.kubernetes, nil
+	// The derived clients share the discovery cache of the target, authenticated with the server credentials
+	derived, err := newKubernetes(m.config, clientcmd.NewDefaultClientConfig(clientCmdApiConfig, nil), derivedCfg, m.kubernetes)
+	if err == nil {
+		m.derivedClients.add(key, token, derived)
+		return derived, nil
 	}
-	return derived, nil
+	if m.config.IsRequireOAuth() {
+		klog.Errorf("failed to create derived client: %v", err)
+		return nil, fmt.Errorf("failed to create derived client: %w", err)
+	}
+	return m.kubernetes, nil
 }
 
 // Invalidate invalidates the cached discovery information.
@@ -170,6 +194,17 @@ func (m *Manager) Invalidate() {
 	m.kubernetes.DiscoveryClient().Invalidate()
 }
 
+// Close releases the connections and caches of the Manager and of the clients derived from it.
+// It's called when the Manager is discarded (e.g. its target is no longer available), requests in flight are not
+// interrupted.
+func (m *Manager) Close() {
+	for _, derived := range m.derivedClients.clear() {
+		derived.close()
+	}
+	m.Invalidate()
+	m.kubernetes.close()
+}
+
 // applyRateLimitFromEnv applies QPS and Burst rate limits from environment variables if set.
 // This is primarily useful for tests to avoid client-side rate limiting.
 // Environment variables:
@@ -0,0 +1,399 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetworkPolicyEndpoint identifies a Pod taking part in the evaluated traffic
+type NetworkPolicyEndpoint struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	IP        string `json:"ip,omitempty"`
+}
+
+// NetworkPolicyDirectionResult is the evaluation of the NetworkPolicies for one direction (egress of the source or ingress of the destination)
+type NetworkPolicyDirectionResult struct {
+	// Isolated is true when at least one NetworkPolicy selects the Pod for this direction (otherwise all traffic is allowed)
+	Isolated bool `json:"isolated"`
+	Allowed  bool `json:"allowed"`
+	// SelectingPolicies are the NetworkPolicies selecting the Pod for this direction
+	SelectingPolicies []string `json:"selectingPolicies,omitempty"`
+	// AllowedBy are the NetworkPolicies with a rule allowing the traffic
+	AllowedBy []string `json:"allowedBy,omitempty"`
+}
+
+// NetworkPolicyCheckResult is the result of evaluating whether traffic between two Pods is allowed
+type NetworkPolicyCheckResult struct {
+	Allowed     bool                         `json:"allowed"`
+	Source      NetworkPolicyEndpoint        `json:"source"`
+	Destination NetworkPolicyEndpoint        `json:"destination"`
+	Port        string                       `json:"port"`
+	Protocol    string                       `json:"protocol"`
+	Egress      NetworkPolicyDirectionResult `json:"egress"`
+	Ingress     NetworkPolicyDirectionResult `json:"ingress"`
+	Reason      string                       `json:"reason"`
+	Notes       []string                     `json:"notes,omitempty"`
+}
+
+// NetworkPolicyCoveragePod is a Pod not selected by any NetworkPolicy
+type NetworkPolicyCoveragePod struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// NetworkPolicyNamespaceCoverage summarizes the NetworkPolicy coverage of a Namespace
+type NetworkPolicyNamespaceCoverage struct {
+	Namespace          string `json:"namespace"`
+	Policies           int    `json:"policies"`
+	Pods               int    `json:"pods"`
+	IngressIsolated    int    `json:"ingressIsolated"`
+	EgressIsolated     int    `json:"egressIsolated"`
+	Uncovered          int    `json:"uncovered"`
+	DefaultDenyIngress bool   `json:"defaultDenyIngress"`
+	DefaultDenyEgress  bool   `json:"defaultDenyEgress"`
+}
+
+// NetworkPolicyCoverageReport lists the Pods not selected by any NetworkPolicy
+type NetworkPolicyCoverageReport struct {
+	TotalPods       int                              `json:"totalPods"`
+	IngressIsolated int                              `json:"ingressIsolated"`
+	EgressIsolated  int                              `json:"egressIsolated"`
+	UncoveredPods   []NetworkPolicyCoveragePod       `json:"uncoveredPods"`
+	Namespaces      []NetworkPolicyNamespaceCoverage `json:"namespaces"`
+	// SkippedHostNetwork is the number of host network Pods, NetworkPolicies don't apply to them
+	SkippedHostNetwork int `json:"skippedHostNetwork,omitempty"`
+}
+
+// NetworkPolicyCheck evaluates the Kubernetes NetworkPolicies to determine whether the source Pod can connect to the destination Pod
+// on the provided port (number or name of a container port of the destination) and protocol.
+func (c *Core) NetworkPolicyCheck(ctx context.Context, sourceNamespace, sourcePod, destinationNamespace, destinationPod, port, protocol string) (*NetworkPolicyCheckResult, error) {
+	if protocol == "" {
+		protocol = string(v1.ProtocolTCP)
+	}
+	src, err := c.CoreV1().Pods(sourceNamespace).Get(ctx, sourcePod, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source pod: %w", err)
+	}
+	dst, err := c.CoreV1().Pods(destinationNamespace).Get(ctx, destinationPod, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination pod: %w", err)
+	}
+	srcNamespace, err := c.CoreV1().Namespaces().Get(ctx, src.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source namespace: %w", err)
+	}
+	dstNamespace, err := c.CoreV1().Namespaces().Get(ctx, dst.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination namespace: %w", err)
+	}
+	dstPort, err := resolvePort(dst, port, v1.Protocol(protocol))
+	if err != nil {
+		return nil, err
+	}
+	result := &NetworkPolicyCheckResult{
+		Source:      NetworkPolicyEndpoint{Namespace: src.Namespace, Pod: src.Name, IP: src.Status.PodIP},
+		Destination: NetworkPolicyEndpoint{Namespace: dst.Namespace, Pod: dst.Name, IP: dst.Status.PodIP},
+		Port:        port,
+		Protocol:    protocol,
+	}
+	if src.Spec.HostNetwork || dst.Spec.HostNetwork {
+		result.Notes = append(result.Notes, "host network Pods are not subject to NetworkPolicies, the result depends on the network plugin")
+	}
+
+	srcPolicies, err := c.NetworkingV1().NetworkPolicies(src.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies in %s: %w", src.Namespace, err)
+	}
+	dstPolicies := srcPolicies
+	if dst.Namespace != src.Namespace {
+		if dstPolicies, err = c.NetworkingV1().NetworkPolicies(dst.Namespace).List(ctx, metav1.ListOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to list network policies in %s: %w", dst.Namespace, err)
+		}
+	}
+
+	// Egress: NetworkPolicies in the source Namespace selecting the source Pod, the peer is the destination
+	result.Egress = evaluateNetworkPolicies(srcPolicies.Items, src, networkingv1.PolicyTypeEgress, dst, dstNamespace, dstPort, v1.Protocol(protocol))
+	// Ingress: NetworkPolicies in the destination Namespace selecting the destination Pod, the peer is the source
+	result.Ingress = evaluateNetworkPolicies(dstPolicies.Items, dst, networkingv1.PolicyTypeIngress, src, srcNamespace, dstPort, v1.Protocol(protocol))
+	result.Allowed = result.Egress.Allowed && result.Ingress.Allowed
+	switch {
+	case result.Allowed:
+		result.Reason = "traffic is allowed by the egress policies of the source and the ingress policies of the destination"
+	case !result.Egress.Allowed && !result.Ingress.Allowed:
+		result.Reason = "traffic is denied by both the egress policies of the source and the ingress policies of the destination"
+	case !result.Egress.Allowed:
+		result.Reason = "traffic is denied by the egress policies of the source, no egress rule matches the destination"
+	default:
+		result.Reason = "traffic is denied by the ingress policies of the destination, no ingress rule matches the source"
+	}
+	return result, nil
+}
+
+// NetworkPolicyCoverage reports the Pods that are not selected by any NetworkPolicy (all traffic to and from them is allowed)
+func (c *Core) NetworkPolicyCoverage(ctx context.Context, namespace string) (*NetworkPolicyCoverageReport, error) {
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	policies, err := c.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+	policiesByNamespace := map[string][]networkingv1.NetworkPolicy{}
+	for _, policy := range policies.Items {
+		policiesByNamespace[policy.Namespace] = append(policiesByNamespace[policy.Namespace], policy)
+	}
+	namespaces := map[string]*NetworkPolicyNamespaceCoverage{}
+	coverageFor := func(ns string) *NetworkPolicyNamespaceCoverage {
+		if namespaces[ns] == nil {
+			namespaces[ns] = &NetworkPolicyNamespaceCoverage{Namespace: ns, Policies: len(policiesByNamespace[ns])}
+			// A default deny policy selects all the Pods of the Namespace and has no rules for the direction
+			for _, policy := range policiesByNamespace[ns] {
+				if len(policy.Spec.PodSelector.MatchLabels) > 0 || len(policy.Spec.PodSelector.MatchExpressions) > 0 {
+					continue
+				}
+				if policyHasType(&policy, networkingv1.PolicyTypeIngress) && len(policy.Spec.Ingress) == 0 {
+					namespaces[ns].DefaultDenyIngress = true
+				}
+				if policyHasType(&policy, networkingv1.PolicyTypeEgress) && len(policy.Spec.Egress) == 0 {
+					namespaces[ns].DefaultDenyEgress = true
+				}
+			}
+		}
+		return namespaces[ns]
+	}
+	for ns := range policiesByNamespace {
+		coverageFor(ns)
+	}
+	report := &NetworkPolicyCoverageReport{UncoveredPods: []NetworkPolicyCoveragePod{}, Namespaces: []NetworkPolicyNamespaceCoverage{}}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if pod.Spec.HostNetwork {
+			report.SkippedHostNetwork++
+			continue
+		}
+		coverage := coverageFor(pod.Namespace)
+		coverage.Pods++
+		report.TotalPods++
+		ingress, egress := false, false
+		for j := range policiesByNamespace[pod.Namespace] {
+			policy := &policiesByNamespace[pod.Namespace][j]
+			if !policySelectsPod(policy, pod) {
+				continue
+			}
+			ingress = ingress || policyHasType(policy, networkingv1.PolicyTypeIngress)
+			egress = egress || policyHasType(policy, networkingv1.PolicyTypeEgress)
+		}
+		if ingress {
+			coverage.IngressIsolated++
+			report.IngressIsolated++
+		}
+		if egress {
+			coverage.EgressIsolated++
+			report.EgressIsolated++
+		}
+		if !ingress && !egress {
+			coverage.Uncovered++
+			report.UncoveredPods = append(report.UncoveredPods, NetworkPolicyCoveragePod{Namespace: pod.Namespace, Name: pod.Name, Labels: pod.Labels})
+		}
+	}
+	for _, coverage := range namespaces {
+		report.Namespaces = append(report.Namespaces, *coverage)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Namespace < report.Namespaces[j].Namespace
+	})
+	sort.SliceStable(report.UncoveredPods, func(i, j int) bool {
+		a, b := report.UncoveredPods[i], report.UncoveredPods[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
+
+// evaluateNetworkPolicies evaluates the policies selecting pod for the provided direction against the peer Pod and destination port
+func evaluateNetworkPolicies(policies []networkingv1.NetworkPolicy, pod *v1.Pod, policyType networkingv1.PolicyType, peer *v1.Pod, peerNamespace *v1.Namespace, port *resolvedPort, protocol v1.Protocol) NetworkPolicyDirectionResult {
+	result := NetworkPolicyDirectionResult{}
+	for i := range policies {
+		policy := &policies[i]
+		if !policySelectsPod(policy, pod) || !policyHasType(policy, policyType) {
+			continue
+		}
+		result.Isolated = true
+		result.SelectingPolicies = append(result.SelectingPolicies, policy.Name)
+		allowed := false
+		if policyType == networkingv1.PolicyTypeIngress {
+			for _, rule := range policy.Spec.Ingress {
+				if peersMatch(rule.From, policy.Namespace, peer, peerNamespace) && portsMatch(rule.Ports, port, protocol) {
+					allowed = true
+					break
+				}
+			}
+		} else {
+			for _, rule := range policy.Spec.Egress {
+				if peersMatch(rule.To, policy.Namespace, peer, peerNamespace) && portsMatch(rule.Ports, port, protocol) {
+					allowed = true
+					break
+				}
+			}
+		}
+		if allowed {
+			result.AllowedBy = append(result.AllowedBy, policy.Name)
+		}
+	}
+	result.Allowed = !result.Isolated || len(result.AllowedBy) > 0
+	return result
+}
+
+// policyHasType returns true if the policy applies to the provided direction (policyTypes defaults to Ingress, plus Egress if egress rules are defined)
+func policyHasType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+func policySelectsPod(policy *networkingv1.NetworkPolicy, pod *v1.Pod) bool {
+	if policy.Namespace != pod.Namespace || pod.Spec.HostNetwork {
+		return false
+	}
+	return labelSelectorMatches(&policy.Spec.PodSelector, pod.Labels)
+}
+
+// peersMatch returns true if the peer Pod matches any of the rule peers (an empty list matches all peers)
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, pod *v1.Pod, namespace *v1.Namespace) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			if ipBlockMatches(peer.IPBlock, pod.Status.PodIP) {
+				return true
+			}
+			continue
+		}
+		if peer.NamespaceSelector != nil {
+			if !labelSelectorMatches(peer.NamespaceSelector, namespace.Labels) {
+				continue
+			}
+		} else if pod.Namespace != policyNamespace {
+			continue
+		}
+		if peer.PodSelector == nil || labelSelectorMatches(peer.PodSelector, pod.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func ipBlockMatches(ipBlock *networkingv1.IPBlock, ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	if _, cidr, err := net.ParseCIDR(ipBlock.CIDR); err != nil || !cidr.Contains(parsedIP) {
+		return false
+	}
+	for _, except := range ipBlock.Except {
+		if _, cidr, err := net.ParseCIDR(except); err == nil && cidr.Contains(parsedIP) {
+			return false
+		}
+	}
+	return true
+}
+
+func labelSelectorMatches(selector *metav1.LabelSelector, l map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(l))
+}
+
+// resolvedPort is the destination port, with its number and name when it matches a container port of the destination Pod
+type resolvedPort struct {
+	number int32
+	name   string
+}
+
+// resolvePort resolves the port (number or container port name) of the destination Pod
+func resolvePort(pod *v1.Pod, port string, protocol v1.Protocol) (*resolvedPort, error) {
+	if number, err := strconv.ParseInt(port, 10, 32); err == nil {
+		ret := &resolvedPort{number: int32(number)}
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.ContainerPort == ret.number && protocolOrDefault(containerPort.Protocol) == protocol {
+					ret.name = containerPort.Name
+				}
+			}
+		}
+		return ret, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port && protocolOrDefault(containerPort.Protocol) == protocol {
+				return &resolvedPort{number: containerPort.ContainerPort, name: port}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("port %s/%s is not a port number nor a container port name of pod %s/%s", port, protocol, pod.Namespace, pod.Name)
+}
+
+// portsMatch returns true if the destination port matches any of the rule ports (an empty list matches all ports)
+func portsMatch(ports []networkingv1.NetworkPolicyPort, port *resolvedPort, protocol v1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if p.Protocol != nil && *p.Protocol != protocol || p.Protocol == nil && protocol != v1.ProtocolTCP {
+			continue
+		}
+		if p.Port == nil {
+			return true
+		}
+		if p.Port.Type == intstr.String {
+			if port.name != "" && p.Port.StrVal == port.name {
+				return true
+			}
+			continue
+		}
+		if p.EndPort != nil {
+			if port.number >= p.Port.IntVal && port.number <= *p.EndPort {
+				return true
+			}
+			continue
+		}
+		if p.Port.IntVal == port.number {
+			return true
+		}
+	}
+	return false
+}
+
+func protocolOrDefault(protocol v1.Protocol) v1.Protocol {
+	if protocol == "" {
+		return v1.ProtocolTCP
+	}
+	return protocol
+}
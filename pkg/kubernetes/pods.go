@@ -226,15 +226,38 @@ func (c *Core) PodsTop(ctx context.Context, options api.PodsTopOptions) (*metric
 }
 
 func (c *Core) PodsExec(ctx context.Context, namespace, name, container string, command []string) (string, error) {
+	executor, err := c.podsExecutor(ctx, namespace, name, container, command, false, false)
+	if err != nil {
+		return "", err
+	}
+	stdout := bytes.NewBuffer(make([]byte, 0))
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout, Stderr: stderr, Tty: false,
+	}); err != nil {
+		return "", err
+	}
+	if stdout.Len() > 0 {
+		return stdout.String(), nil
+	}
+	if stderr.Len() > 0 {
+		return stderr.String(), nil
+	}
+	return "", nil
+}
+
+// podsExecutor validates the target Pod and returns a remotecommand.Executor for the provided command.
+// The executor prefers the WebSocket transport and falls back to SPDY when the upgrade is not supported.
+func (c *Core) podsExecutor(ctx context.Context, namespace, name, container string, command []string, stdin, tty bool) (remotecommand.Executor, error) {
 	namespace = c.NamespaceOrDefault(namespace)
 	pods := c.CoreV1().Pods(namespace)
 	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/exec/exec.go#L350-L352
 	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
-		return "", fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
+		return nil, fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
 	}
 	if container == "" {
 		container = pod.Spec.Containers[0].Name
@@ -242,8 +265,11 @@ func (c *Core) PodsExec(ctx context.Context, namespace, name, container string,
 	podExecOptions := &v1.PodExecOptions{
 		Container: container,
 		Command:   command,
+		Stdin:     stdin,
 		Stdout:    true,
-		Stderr:    true,
+		// A TTY multiplexes stderr into stdout
+		Stderr: !tty,
+		TTY:    tty,
 	}
 	// Compute URL
 	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/exec/exec.go#L382-L397
@@ -256,34 +282,17 @@ func (c *Core) PodsExec(ctx context.Context, namespace, name, container string,
 	execRequest.VersionedParams(podExecOptions, ParameterCodec)
 	restConfig, err := c.ToRESTConfig()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	spdyExec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", execRequest.URL())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	webSocketExec, err := remotecommand.NewWebSocketExecutor(restConfig, "GET", execRequest.URL().String())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	executor, err := remotecommand.NewFallbackExecutor(webSocketExec, spdyExec, func(err error) bool {
+	return remotecommand.NewFallbackExecutor(webSocketExec, spdyExec, func(err error) bool {
 		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
 	})
-	if err != nil {
-		return "", err
-	}
-	stdout := bytes.NewBuffer(make([]byte, 0))
-	stderr := bytes.NewBuffer(make([]byte, 0))
-	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: stdout, Stderr: stderr, Tty: false,
-	}); err != nil {
-		return "", err
-	}
-	if stdout.Len() > 0 {
-		return stdout.String(), nil
-	}
-	if stderr.Len() > 0 {
-		return stderr.String(), nil
-	}
-	return "", nil
 }
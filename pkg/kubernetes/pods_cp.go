@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// DefaultPodsCopyMaxBytes is the default maximum size of a file copied from or to a Pod
+	DefaultPodsCopyMaxBytes = int64(1024 * 1024)
+	// PodsCopyMaxBytesLimit is the hard limit for the size of a file copied from or to a Pod
+	PodsCopyMaxBytesLimit = int64(10 * 1024 * 1024)
+)
+
+// PodsCopyFrom reads the regular file at filePath from a Pod container.
+// Like `kubectl cp`, it relies on the tar binary being available in the container image.
+// Files larger than maxBytes are rejected without being transferred.
+func (c *Core) PodsCopyFrom(ctx context.Context, namespace, name, container, filePath string, maxBytes int64) ([]byte, error) {
+	dir, base, err := podsCopySplitPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	executor, err := c.podsExecutor(ctx, namespace, name, container, []string{"tar", "cf", "-", "-C", dir, "--", base}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stdoutReader, stdoutWriter := io.Pipe()
+	// The stream may still be writing to stderr after it's canceled
+	stderr := &execOutputBuffer{}
+	streamErr := make(chan error, 1)
+	go func() {
+		err := executor.StreamWithContext(streamCtx, remotecommand.StreamOptions{Stdout: stdoutWriter, Stderr: stderr})
+		_ = stdoutWriter.CloseWithError(err)
+		streamErr <- err
+	}()
+	content, readErr := podsCopyReadFile(tar.NewReader(stdoutReader), filePath, maxBytes)
+	if readErr == nil {
+		// Consume the archive trailer so that tar exits cleanly
+		_, _ = io.Copy(io.Discard, stdoutReader)
+	} else {
+		// Stop the transfer early if the file was rejected
+		cancel()
+		_ = stdoutReader.CloseWithError(readErr)
+	}
+	if err = <-streamErr; err != nil && readErr != nil {
+		if errOut, _ := stderr.drain(); errOut != "" {
+			return nil, fmt.Errorf("%w: %s", readErr, strings.TrimSpace(errOut))
+		}
+	}
+	return content, readErr
+}
+
+// PodsCopyTo writes content to filePath in a Pod container, replacing the file if it already exists.
+// Like `kubectl cp`, it relies on the tar binary being available in the container image.
+func (c *Core) PodsCopyTo(ctx context.Context, namespace, name, container, filePath string, content []byte) error {
+	dir, base, err := podsCopySplitPath(filePath)
+	if err != nil {
+		return err
+	}
+	archive := &bytes.Buffer{}
+	tw := tar.NewWriter(archive)
+	if err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     base,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err = tw.Write(content); err != nil {
+		return err
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	executor, err := c.podsExecutor(ctx, namespace, name, container, []string{"tar", "xmf", "-", "-C", dir}, true, false)
+	if err != nil {
+		return err
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: archive, Stdout: stdout, Stderr: stderr}); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+// podsCopySplitPath splits a container file path into the directory for tar's -C flag and the file name
+func podsCopySplitPath(filePath string) (string, string, error) {
+	if filePath == "" || strings.HasSuffix(filePath, "/") {
+		return "", "", fmt.Errorf("invalid file path %q, a path to a file is required", filePath)
+	}
+	dir, base := path.Split(path.Clean(filePath))
+	if base == "" || base == "." || base == ".." {
+		return "", "", fmt.Errorf("invalid file path %q, a path to a file is required", filePath)
+	}
+	if dir == "" {
+		dir = "."
+	}
+	// Paths starting with - would be parsed as tar options
+	if strings.HasPrefix(dir, "-") || strings.HasPrefix(base, "-") {
+		return "", "", fmt.Errorf("invalid file path %q, paths starting with - are not supported", filePath)
+	}
+	return dir, base, nil
+}
+
+// podsCopyReadFile extracts the single regular file from the tar stream produced by PodsCopyFrom
+func podsCopyReadFile(tr *tar.Reader, filePath string, maxBytes int64) ([]byte, error) {
+	header, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("file %s not found", filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	switch header.Typeflag {
+	case tar.TypeReg:
+	case tar.TypeDir:
+		return nil, fmt.Errorf("%s is a directory, only regular files can be copied", filePath)
+	default:
+		return nil, fmt.Errorf("%s is not a regular file, only regular files can be copied", filePath)
+	}
+	if maxBytes > 0 && header.Size > maxBytes {
+		return nil, fmt.Errorf("file %s size (%d bytes) exceeds the maximum allowed size (%d bytes)", filePath, header.Size, maxBytes)
+	}
+	content, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	return content, nil
+}
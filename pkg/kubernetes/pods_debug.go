@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const (
+	// DefaultDebugImage is the default image used for ephemeral debug containers and node debug Pods
+	DefaultDebugImage = "busybox:latest"
+	// debugReadyTimeout is the maximum time to wait for a debug container to be running
+	debugReadyTimeout = 2 * time.Minute
+	// debugPollInterval is the interval between debug container status checks
+	debugPollInterval = time.Second
+)
+
+// DebugResult describes a debug container that is ready to be used with PodsExec.
+type DebugResult struct {
+	Namespace string
+	Pod       string
+	Container string
+	// Output is the output of the optional command executed in the debug container
+	Output string
+}
+
+// PodsDebug adds an ephemeral debug container to the provided Pod and waits for it to be running.
+// If target is provided, the debug container shares the process namespace of the target container.
+// If command is provided, it's executed in the debug container once it's running.
+func (c *Core) PodsDebug(ctx context.Context, namespace, name, image, target string, command []string) (*DebugResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	if image == "" {
+		image = DefaultDebugImage
+	}
+	pods := c.CoreV1().Pods(namespace)
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if target != "" && !podHasContainer(pod, target) {
+		return nil, fmt.Errorf("target container %s not found in pod %s", target, name)
+	}
+	containerName := "debugger-" + rand.String(5)
+	pod = pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    image,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+			// Keep the container's default shell alive waiting on stdin so that commands can be executed in it
+			Stdin: true,
+		},
+		TargetContainerName: target,
+	})
+	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/debug/debug.go#L551-L560
+	if _, err = pods.UpdateEphemeralContainers(ctx, name, pod, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to add ephemeral container: %w", err)
+	}
+	if err = c.waitForContainerRunning(ctx, namespace, name, containerName); err != nil {
+		return nil, err
+	}
+	ret := &DebugResult{Namespace: namespace, Pod: name, Container: containerName}
+	if len(command) > 0 {
+		if ret.Output, err = c.PodsExec(ctx, namespace, name, containerName, command); err != nil {
+			return ret, fmt.Errorf("failed to execute command in debug container %s: %w", containerName, err)
+		}
+	}
+	return ret, nil
+}
+
+// NodesDebug creates a privileged Pod sharing the host namespaces (PID, network, and IPC) of the provided Node,
+// with the Node root filesystem mounted at /host, and waits for it to be running.
+// If command is provided, it's executed in the debug Pod once it's running.
+// The Pod is not removed automatically, it should be deleted once it's no longer needed.
+func (c *Core) NodesDebug(ctx context.Context, namespace, node, image string, command []string) (*DebugResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	if image == "" {
+		image = DefaultDebugImage
+	}
+	if _, err := c.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("node-debugger-%s-%s", node, rand.String(5))
+	if len(name) > 63 {
+		name = "node-debugger-" + rand.String(10)
+	}
+	labels := map[string]string{
+		AppKubernetesName:      name,
+		AppKubernetesComponent: "node-debugger",
+		AppKubernetesManagedBy: version.BinaryName,
+		AppKubernetesPartOf:    version.BinaryName + "-debug",
+	}
+	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/debug/profile_legacy.go
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: v1.PodSpec{
+			NodeName:      node,
+			HostPID:       true,
+			HostNetwork:   true,
+			HostIPC:       true,
+			RestartPolicy: v1.RestartPolicyNever,
+			Containers: []v1.Container{{
+				Name:                     "debugger",
+				Image:                    image,
+				ImagePullPolicy:          v1.PullIfNotPresent,
+				TerminationMessagePolicy: v1.TerminationMessageReadFile,
+				// Keep the container's default shell alive waiting on stdin so that commands can be executed in it
+				Stdin:           true,
+				SecurityContext: &v1.SecurityContext{Privileged: ptr.To(true)},
+				VolumeMounts:    []v1.VolumeMount{{Name: "host-root", MountPath: "/host"}},
+			}},
+			Volumes: []v1.Volume{{
+				Name:         "host-root",
+				VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/"}},
+			}},
+			Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}},
+		},
+	}
+	if _, err := c.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create node debug pod: %w", err)
+	}
+	ret := &DebugResult{Namespace: namespace, Pod: name, Container: "debugger"}
+	if err := c.waitForContainerRunning(ctx, namespace, name, ret.Container); err != nil {
+		return ret, err
+	}
+	if len(command) > 0 {
+		var err error
+		if ret.Output, err = c.PodsExec(ctx, namespace, name, ret.Container, command); err != nil {
+			return ret, fmt.Errorf("failed to execute command in node debug pod %s: %w", name, err)
+		}
+	}
+	return ret, nil
+}
+
+// waitForContainerRunning waits until the provided (regular or ephemeral) container of a Pod is running,
+// failing early if it terminates or can't be started.
+func (c *Core) waitForContainerRunning(ctx context.Context, namespace, name, container string) error {
+	lastState := "Unknown"
+	err := wait.PollUntilContextTimeout(ctx, debugPollInterval, debugReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		statuses := append(append([]v1.ContainerStatus{}, pod.Status.ContainerStatuses...), pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name != container {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return true, nil
+			case status.State.Terminated != nil:
+				return false, fmt.Errorf("container %s terminated: %s %s", container, status.State.Terminated.Reason, status.State.Terminated.Message)
+			case status.State.Waiting != nil:
+				lastState = status.State.Waiting.Reason
+				switch status.State.Waiting.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+					return false, fmt.Errorf("container %s can't be started: %s %s", container, status.State.Waiting.Reason, status.State.Waiting.Message)
+				}
+			}
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			return false, fmt.Errorf("pod %s is %s", name, pod.Status.Phase)
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for container %s to be running (last state: %s)", container, lastState)
+	}
+	return err
+}
+
+func podHasContainer(pod *v1.Pod, container string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,454 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Pod failure categories reported by PodsDiagnose, sorted by priority (most likely root cause first)
+const (
+	PodFailureOOMKilled           = "OOMKilled"
+	PodFailureImagePull           = "ImagePullBackOff"
+	PodFailureContainerConfig     = "CreateContainerConfigError"
+	PodFailureCrashLoopBackOff    = "CrashLoopBackOff"
+	PodFailurePVCNotBound         = "PersistentVolumeClaimNotBound"
+	PodFailureQuotaExceeded       = "QuotaExceeded"
+	PodFailureUnschedulable       = "Unschedulable"
+	PodFailureNodeNotReady        = "NodeNotReady"
+	PodFailureProbe               = "ProbeFailure"
+	PodFailureContainerTerminated = "ContainerTerminated"
+	PodFailurePending             = "Pending"
+	PodDiagnosisHealthy           = "Healthy"
+)
+
+// podsDiagnoseLogTailLines is the number of previous container log lines included as evidence
+const podsDiagnoseLogTailLines = int64(20)
+
+var podFailurePriority = []string{
+	PodFailureOOMKilled,
+	PodFailureImagePull,
+	PodFailureContainerConfig,
+	PodFailureCrashLoopBackOff,
+	PodFailurePVCNotBound,
+	PodFailureQuotaExceeded,
+	PodFailureUnschedulable,
+	PodFailureNodeNotReady,
+	PodFailureProbe,
+	PodFailureContainerTerminated,
+	PodFailurePending,
+}
+
+// PodFinding is an issue detected for a Pod, along with the evidence supporting it.
+type PodFinding struct {
+	Category  string   `json:"category"`
+	Container string   `json:"container,omitempty"`
+	Message   string   `json:"message"`
+	Evidence  []string `json:"evidence,omitempty"`
+}
+
+// SuggestedTool is a tool call suggested to further investigate or remediate a Pod failure.
+type SuggestedTool struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Reason    string         `json:"reason"`
+}
+
+// PodDiagnosis is the verdict of PodsDiagnose.
+type PodDiagnosis struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Phase     string `json:"phase"`
+	Node      string `json:"node,omitempty"`
+	// Verdict is the category of the most likely root cause (or Healthy if no issue was detected)
+	Verdict        string          `json:"verdict"`
+	Summary        string          `json:"summary"`
+	Findings       []PodFinding    `json:"findings"`
+	SuggestedTools []SuggestedTool `json:"suggestedTools,omitempty"`
+}
+
+// PodsDiagnose classifies the failure of a Pod (CrashLoopBackOff, ImagePullBackOff, OOMKilled, Pending due to
+// scheduling, taints, quota or PersistentVolumeClaim binding, probe failures) using its status, events,
+// previous container logs, and the conditions of the node it's scheduled on.
+func (c *Core) PodsDiagnose(ctx context.Context, namespace, name string) (*PodDiagnosis, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	d := &podDiagnoser{pod: pod, diagnosis: &PodDiagnosis{
+		Namespace: namespace, Pod: name, Phase: string(pod.Status.Phase), Node: pod.Spec.NodeName, Findings: []PodFinding{},
+	}}
+	// Events and logs are best-effort evidence, failures to retrieve them don't prevent the diagnosis
+	if events, err := c.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"involvedObject.name": name}).String(),
+	}); err == nil {
+		for _, event := range events.Items {
+			if event.InvolvedObject.Kind == "Pod" && event.InvolvedObject.Name == name {
+				d.events = append(d.events, event)
+			}
+		}
+		sort.SliceStable(d.events, func(i, j int) bool { return eventTime(&d.events[i]).Before(eventTime(&d.events[j])) })
+	}
+	d.diagnoseContainers(func(container string) string {
+		logs, err := c.PodsLog(ctx, namespace, name, container, true, podsDiagnoseLogTailLines)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(logs)
+	})
+	d.diagnoseProbes()
+	if pod.Status.Phase == v1.PodPending {
+		d.diagnosePending(func(claim string) (*v1.PersistentVolumeClaim, error) {
+			return c.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claim, metav1.GetOptions{})
+		})
+	}
+	if pod.Spec.NodeName != "" {
+		if node, err := c.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{}); err == nil {
+			d.diagnoseNode(node)
+		}
+	}
+	d.verdict()
+	return d.diagnosis, nil
+}
+
+type podDiagnoser struct {
+	pod       *v1.Pod
+	events    []v1.Event
+	diagnosis *PodDiagnosis
+}
+
+func (d *podDiagnoser) add(finding PodFinding) {
+	d.diagnosis.Findings = append(d.diagnosis.Findings, finding)
+}
+
+// eventEvidence returns the events whose reason is one of the provided reasons and whose message contains the filter
+func (d *podDiagnoser) eventEvidence(filter string, reasons ...string) []string {
+	var ret []string
+	for _, event := range d.events {
+		if len(reasons) > 0 && !slices.Contains(reasons, event.Reason) {
+			continue
+		}
+		if filter != "" && !strings.Contains(event.Message, filter) {
+			continue
+		}
+		ret = append(ret, fmt.Sprintf("Event %s %s (x%d): %s", event.Type, event.Reason, max(event.Count, 1), strings.TrimSpace(event.Message)))
+	}
+	return ret
+}
+
+func (d *podDiagnoser) diagnoseContainers(previousLogs func(container string) string) {
+	statuses := append(append([]v1.ContainerStatus{}, d.pod.Status.InitContainerStatuses...), d.pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		var evidence []string
+		lastTerminated := status.LastTerminationState.Terminated
+		if lastTerminated != nil {
+			evidence = append(evidence, fmt.Sprintf("Last termination: %s (exit code %d) at %s",
+				lastTerminated.Reason, lastTerminated.ExitCode, lastTerminated.FinishedAt.UTC().Format(time.RFC3339)))
+		}
+		if status.RestartCount > 0 {
+			evidence = append(evidence, fmt.Sprintf("Restart count: %d", status.RestartCount))
+		}
+		terminated := status.State.Terminated
+		oomKilled := (terminated != nil && terminated.Reason == "OOMKilled") || (lastTerminated != nil && lastTerminated.Reason == "OOMKilled")
+		waiting := status.State.Waiting
+		switch {
+		case oomKilled:
+			message := fmt.Sprintf("Container %s was killed because it exceeded its memory limit (OOMKilled)", status.Name)
+			if memory, ok := d.containerMemoryLimit(status.Name); ok {
+				evidence = append(evidence, "Memory limit: "+memory.String())
+			} else {
+				evidence = append(evidence, "Memory limit: none")
+				message = fmt.Sprintf("Container %s has no memory limit and was killed by the node under memory pressure (OOMKilled)", status.Name)
+			}
+			d.add(PodFinding{Category: PodFailureOOMKilled, Container: status.Name, Evidence: evidence, Message: message})
+		case waiting != nil && (waiting.Reason == "ErrImagePull" || waiting.Reason == "ImagePullBackOff" || waiting.Reason == "InvalidImageName" || waiting.Reason == "ErrImageNeverPull"):
+			evidence = append([]string{fmt.Sprintf("Image: %s", d.containerImage(status.Name)), fmt.Sprintf("Waiting: %s: %s", waiting.Reason, waiting.Message)}, evidence...)
+			evidence = append(evidence, d.eventEvidence("", "Failed", "BackOff")...)
+			d.add(PodFinding{Category: PodFailureImagePull, Container: status.Name, Evidence: evidence,
+				Message: fmt.Sprintf("Image %s of container %s can't be pulled (%s)", d.containerImage(status.Name), status.Name, waiting.Reason)})
+			continue
+		case waiting != nil && (waiting.Reason == "CreateContainerConfigError" || waiting.Reason == "CreateContainerError" || waiting.Reason == "RunContainerError"):
+			evidence = append([]string{fmt.Sprintf("Waiting: %s: %s", waiting.Reason, waiting.Message)}, evidence...)
+			d.add(PodFinding{Category: PodFailureContainerConfig, Container: status.Name, Evidence: evidence,
+				Message: fmt.Sprintf("Container %s can't be created: %s", status.Name, waiting.Message)})
+			continue
+		case waiting != nil && waiting.Reason == "CrashLoopBackOff":
+			evidence = append([]string{fmt.Sprintf("Waiting: %s: %s", waiting.Reason, waiting.Message)}, evidence...)
+			message := fmt.Sprintf("Container %s keeps crashing", status.Name)
+			if lastTerminated != nil {
+				message += fmt.Sprintf(" (last exit code %d, %s)", lastTerminated.ExitCode, lastTerminated.Reason)
+			}
+			d.add(PodFinding{Category: PodFailureCrashLoopBackOff, Container: status.Name, Evidence: evidence, Message: message})
+		case terminated != nil && terminated.ExitCode != 0:
+			evidence = append([]string{fmt.Sprintf("Terminated: %s (exit code %d): %s", terminated.Reason, terminated.ExitCode, terminated.Message)}, evidence...)
+			d.add(PodFinding{Category: PodFailureContainerTerminated, Container: status.Name, Evidence: evidence,
+				Message: fmt.Sprintf("Container %s terminated with exit code %d (%s)", status.Name, terminated.ExitCode, terminated.Reason)})
+		default:
+			continue
+		}
+		// Previous logs are the most valuable evidence for containers that crashed
+		if lastTerminated != nil || (terminated != nil && status.RestartCount > 0) {
+			if logs := previousLogs(status.Name); logs != "" {
+				finding := &d.diagnosis.Findings[len(d.diagnosis.Findings)-1]
+				finding.Evidence = append(finding.Evidence, "Previous logs (last lines):\n"+logs)
+			}
+		}
+	}
+}
+
+func (d *podDiagnoser) diagnoseProbes() {
+	probeEvents := d.eventEvidence("probe failed", "Unhealthy")
+	if len(probeEvents) == 0 {
+		return
+	}
+	containers := map[string]bool{}
+	for _, event := range d.events {
+		if event.Reason == "Unhealthy" && event.InvolvedObject.FieldPath != "" {
+			// spec.containers{name}
+			fieldPath := event.InvolvedObject.FieldPath
+			if start, end := strings.Index(fieldPath, "{"), strings.Index(fieldPath, "}"); start >= 0 && end > start {
+				containers[fieldPath[start+1:end]] = true
+			}
+		}
+	}
+	var container string
+	if len(containers) == 1 {
+		container = sortedKeys(containers)[0]
+	}
+	var probes []string
+	for _, probe := range []string{"Liveness", "Readiness", "Startup"} {
+		if len(d.eventEvidence(probe+" probe failed", "Unhealthy")) > 0 {
+			probes = append(probes, strings.ToLower(probe))
+		}
+	}
+	message := "Probes are failing"
+	if len(probes) > 0 {
+		message = fmt.Sprintf("The %s probe(s) are failing", strings.Join(probes, " and "))
+	}
+	if container != "" {
+		message += " for container " + container
+	}
+	if slices.Contains(probes, "liveness") {
+		message += ", the kubelet restarts the container when the liveness probe fails"
+	} else if slices.Contains(probes, "readiness") {
+		message += ", the Pod is removed from the Service endpoints while the readiness probe fails"
+	}
+	d.add(PodFinding{Category: PodFailureProbe, Container: container, Message: message, Evidence: probeEvents})
+}
+
+func (d *podDiagnoser) diagnosePending(getClaim func(claim string) (*v1.PersistentVolumeClaim, error)) {
+	found := false
+	for _, volume := range d.pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		claim, err := getClaim(volume.PersistentVolumeClaim.ClaimName)
+		switch {
+		case err != nil:
+			found = true
+			d.add(PodFinding{Category: PodFailurePVCNotBound, Message: fmt.Sprintf("PersistentVolumeClaim %s can't be retrieved: %v", volume.PersistentVolumeClaim.ClaimName, err)})
+		case claim.Status.Phase != v1.ClaimBound:
+			found = true
+			evidence := []string{fmt.Sprintf("PersistentVolumeClaim %s phase: %s", claim.Name, claim.Status.Phase)}
+			if claim.Spec.StorageClassName != nil {
+				evidence = append(evidence, "Storage class: "+*claim.Spec.StorageClassName)
+			} else {
+				evidence = append(evidence, "No storage class set")
+			}
+			d.add(PodFinding{Category: PodFailurePVCNotBound, Evidence: evidence,
+				Message: fmt.Sprintf("PersistentVolumeClaim %s is not bound", claim.Name)})
+		}
+	}
+	if quota := d.eventEvidence("exceeded quota"); len(quota) > 0 {
+		found = true
+		d.add(PodFinding{Category: PodFailureQuotaExceeded, Message: "A ResourceQuota of the namespace is exceeded", Evidence: quota})
+	}
+	for _, condition := range d.pod.Status.Conditions {
+		if condition.Type != v1.PodScheduled || condition.Status != v1.ConditionFalse {
+			continue
+		}
+		found = true
+		evidence := append([]string{fmt.Sprintf("Condition PodScheduled=False (%s): %s", condition.Reason, condition.Message)}, d.eventEvidence("", "FailedScheduling")...)
+		var causes []string
+		for cause, match := range map[string]string{
+			"untolerated node taints":                  "untolerated taint",
+			"insufficient node resources":              "Insufficient",
+			"node affinity or selector mismatch":       "didn't match Pod's node affinity/selector",
+			"unbound PersistentVolumeClaims":           "unbound immediate PersistentVolumeClaims",
+			"volume node affinity conflict":            "volume node affinity conflict",
+			"pod (anti-)affinity rules":                "didn't match pod affinity",
+			"topology spread constraints":              "didn't match pod topology spread constraints",
+			"unschedulable (cordoned) nodes":           "node(s) were unschedulable",
+			"no free ports for the requested hostPort": "didn't have free ports",
+		} {
+			if strings.Contains(condition.Message, match) || len(d.eventEvidence(match, "FailedScheduling")) > 0 {
+				causes = append(causes, cause)
+			}
+		}
+		sort.Strings(causes)
+		message := "The Pod can't be scheduled"
+		if len(causes) > 0 {
+			message += " due to " + strings.Join(causes, ", ")
+		}
+		d.add(PodFinding{Category: PodFailureUnschedulable, Message: message, Evidence: evidence})
+	}
+	if !found && len(d.diagnosis.Findings) == 0 {
+		d.add(PodFinding{Category: PodFailurePending, Message: "The Pod is pending without a known cause, check its events",
+			Evidence: d.eventEvidence("", "")})
+	}
+}
+
+func (d *podDiagnoser) diagnoseNode(node *v1.Node) {
+	var evidence []string
+	for _, condition := range node.Status.Conditions {
+		if (condition.Type == v1.NodeReady && condition.Status != v1.ConditionTrue) ||
+			(condition.Type != v1.NodeReady && condition.Status == v1.ConditionTrue) {
+			evidence = append(evidence, fmt.Sprintf("Node condition %s=%s (%s): %s", condition.Type, condition.Status, condition.Reason, condition.Message))
+		}
+	}
+	if node.Spec.Unschedulable {
+		evidence = append(evidence, "Node is cordoned (unschedulable)")
+	}
+	if len(evidence) > 0 {
+		d.add(PodFinding{Category: PodFailureNodeNotReady, Evidence: evidence,
+			Message: fmt.Sprintf("Node %s where the Pod is scheduled is not healthy", node.Name)})
+	}
+}
+
+// verdict selects the most likely root cause, summarizes it and suggests the next tools to use
+func (d *podDiagnoser) verdict() {
+	diagnosis := d.diagnosis
+	sort.SliceStable(diagnosis.Findings, func(i, j int) bool {
+		return podFailurePriorityOf(diagnosis.Findings[i].Category) < podFailurePriorityOf(diagnosis.Findings[j].Category)
+	})
+	if len(diagnosis.Findings) == 0 {
+		diagnosis.Verdict = PodDiagnosisHealthy
+		diagnosis.Summary = fmt.Sprintf("No issues detected for Pod %s (phase %s)", diagnosis.Pod, diagnosis.Phase)
+		return
+	}
+	root := diagnosis.Findings[0]
+	diagnosis.Verdict = root.Category
+	diagnosis.Summary = root.Message
+	if len(diagnosis.Findings) > 1 {
+		diagnosis.Summary += fmt.Sprintf(" (%d more finding(s))", len(diagnosis.Findings)-1)
+	}
+	pod := map[string]any{"namespace": diagnosis.Namespace, "name": diagnosis.Pod}
+	with := func(extra map[string]any) map[string]any {
+		ret := map[string]any{}
+		for k, v := range pod {
+			ret[k] = v
+		}
+		for k, v := range extra {
+			ret[k] = v
+		}
+		return ret
+	}
+	owner := metav1.GetControllerOfNoCopy(d.pod)
+	describeOwner := func(reason string) {
+		if owner != nil {
+			diagnosis.SuggestedTools = append(diagnosis.SuggestedTools, SuggestedTool{Tool: "resources_describe", Reason: reason,
+				Arguments: map[string]any{"apiVersion": owner.APIVersion, "kind": owner.Kind, "namespace": diagnosis.Namespace, "name": owner.Name}})
+		}
+	}
+	switch root.Category {
+	case PodFailureOOMKilled:
+		if _, ok := d.containerMemoryLimit(root.Container); ok {
+			diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+				SuggestedTool{Tool: "pods_top", Arguments: pod, Reason: "Compare the current memory usage with the memory limit"})
+		} else {
+			diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+				SuggestedTool{Tool: "pods_top", Arguments: pod, Reason: "Check the current memory usage of the Pod, its container has no memory limit"})
+			if diagnosis.Node != "" {
+				diagnosis.SuggestedTools = append(diagnosis.SuggestedTools, SuggestedTool{Tool: "nodes_top", Arguments: map[string]any{"name": diagnosis.Node},
+					Reason: "Check the memory pressure of the node the Pod is scheduled on"})
+			}
+		}
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "pods_log", Arguments: with(map[string]any{"container": root.Container, "previous": true}), Reason: "Check what the container was doing before being killed"})
+		describeOwner("Review the memory requests and limits of the workload")
+	case PodFailureImagePull:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "events_list", Arguments: map[string]any{"namespace": diagnosis.Namespace}, Reason: "Check the image pull errors (wrong image name or tag, missing imagePullSecrets, registry unreachable)"})
+		describeOwner("Verify the image and imagePullSecrets of the workload")
+		if owner != nil && owner.Kind == "ReplicaSet" {
+			diagnosis.SuggestedTools = append(diagnosis.SuggestedTools, SuggestedTool{Tool: "rollout_history", Reason: "Roll back to a revision with a valid image if the image was recently changed",
+				Arguments: map[string]any{"kind": "Deployment", "namespace": diagnosis.Namespace, "name": strings.TrimSuffix(owner.Name, "-"+d.pod.Labels["pod-template-hash"])}})
+		}
+	case PodFailureContainerConfig:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "resources_graph", Arguments: with(map[string]any{"apiVersion": "v1", "kind": "Pod"}), Reason: "Check the ConfigMaps and Secrets referenced by the Pod exist"})
+	case PodFailureCrashLoopBackOff, PodFailureContainerTerminated:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "pods_log", Arguments: with(map[string]any{"container": root.Container, "previous": true}), Reason: "Check the logs of the crashed container"},
+			SuggestedTool{Tool: "pods_debug", Arguments: with(map[string]any{"target": root.Container}), Reason: "Inspect the container environment with an ephemeral debug container"})
+		describeOwner("Review the command, environment, and configuration of the workload")
+	case PodFailurePVCNotBound:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "resources_list", Arguments: map[string]any{"apiVersion": "storage.k8s.io/v1", "kind": "StorageClass"}, Reason: "Check the storage class exists and can provision volumes"},
+			SuggestedTool{Tool: "events_list", Arguments: map[string]any{"namespace": diagnosis.Namespace}, Reason: "Check the provisioning errors of the PersistentVolumeClaim"})
+	case PodFailureQuotaExceeded:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "resources_list", Arguments: map[string]any{"apiVersion": "v1", "kind": "ResourceQuota", "namespace": diagnosis.Namespace}, Reason: "Check the usage and limits of the namespace quotas"})
+	case PodFailureUnschedulable, PodFailurePending:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "nodes_top", Reason: "Check the resource usage of the nodes"},
+			SuggestedTool{Tool: "resources_list", Arguments: map[string]any{"apiVersion": "v1", "kind": "Node"}, Reason: "Check the node taints, labels, and allocatable resources"})
+		describeOwner("Review the resource requests, tolerations, and affinity of the workload")
+	case PodFailureNodeNotReady:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "nodes_log", Arguments: map[string]any{"name": diagnosis.Node, "query": "kubelet"}, Reason: "Check the kubelet logs of the node"},
+			SuggestedTool{Tool: "nodes_stats_summary", Arguments: map[string]any{"name": diagnosis.Node}, Reason: "Check the resource pressure of the node"})
+	case PodFailureProbe:
+		diagnosis.SuggestedTools = append(diagnosis.SuggestedTools,
+			SuggestedTool{Tool: "pods_log", Arguments: with(map[string]any{"container": root.Container}), Reason: "Check whether the application is slow to start or failing"},
+			SuggestedTool{Tool: "pods_port_forward", Arguments: pod, Reason: "Forward the probe port and call the probe endpoint with http_probe"})
+		describeOwner("Review the probe configuration (path, port, timeouts, thresholds) of the workload")
+	}
+}
+
+func (d *podDiagnoser) containerImage(container string) string {
+	for _, c := range append(append([]v1.Container{}, d.pod.Spec.InitContainers...), d.pod.Spec.Containers...) {
+		if c.Name == container {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+// containerMemoryLimit returns the memory limit of the container, if set
+func (d *podDiagnoser) containerMemoryLimit(container string) (resource.Quantity, bool) {
+	for _, c := range append(append([]v1.Container{}, d.pod.Spec.InitContainers...), d.pod.Spec.Containers...) {
+		if c.Name == container {
+			memory, ok := c.Resources.Limits[v1.ResourceMemory]
+			return memory, ok
+		}
+	}
+	return resource.Quantity{}, false
+}
+
+func podFailurePriorityOf(category string) int {
+	if i := slices.Index(podFailurePriority, category); i >= 0 {
+		return i
+	}
+	return len(podFailurePriority)
+}
+
+func eventTime(event *v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.FirstTimestamp.Time
+	}
+}
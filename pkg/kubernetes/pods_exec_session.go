@@ -0,0 +1,427 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// DefaultExecSessionIdleTimeout is the period of inactivity after which an interactive exec session is terminated
+	DefaultExecSessionIdleTimeout = 10 * time.Minute
+	// DefaultExecSessionsMax is the maximum number of running interactive exec sessions per MCP session
+	DefaultExecSessionsMax = 5
+	// execSessionOutputLimit is the maximum number of unread bytes buffered per output stream,
+	// older output is discarded when the limit is exceeded
+	execSessionOutputLimit = 1024 * 1024
+	// execSessionWriteTimeout is the maximum time a write waits for the command to consume its stdin
+	execSessionWriteTimeout = 30 * time.Second
+)
+
+var ErrExecSessionNotFound = errors.New("exec session not found")
+
+// ErrMcpSessionRequired is returned by the tools keeping state across tool calls (exec sessions and port forwards)
+// when the tool call doesn't belong to an MCP session they can be scoped to (e.g. stateless HTTP servers)
+var ErrMcpSessionRequired = errors.New("this tool requires an MCP session and is not available in stateless mode")
+
+// ExecSessions is the registry of interactive exec sessions shared by all tool calls.
+var ExecSessions = NewExecSessionRegistry(DefaultExecSessionIdleTimeout, DefaultExecSessionsMax)
+
+// ExecSession is a long-running command executed in a Pod container with stdin attached.
+// Output is buffered until it's read with ExecSessionRegistry.Read.
+type ExecSession struct {
+	ID        string
+	Namespace string
+	Name      string
+	Container string
+	Command   []string
+	Tty       bool
+
+	owner string
+	stdin *io.PipeWriter
+	// stdinLock serializes the writes to stdin, it's held until a write completes even if the caller timed out
+	stdinLock chan struct{}
+	stdout    *execOutputBuffer
+	stderr    *execOutputBuffer
+	notify    chan struct{}
+	done      chan struct{}
+	cancel    context.CancelFunc
+	idleTimer *time.Timer
+	err       error
+}
+
+// ExecSessionOutput is the output buffered by an ExecSession since the last read.
+type ExecSessionOutput struct {
+	Stdout string
+	Stderr string
+	// Discarded is the number of bytes dropped because they exceeded the buffer limit before being read
+	Discarded int
+	Running   bool
+	// Err is the error the command terminated with (if any), only meaningful when Running is false
+	Err error
+}
+
+// PodsExecStart starts the provided command in a Pod container with stdin attached and registers it in ExecSessions.
+// The command keeps running after the tool call finishes until it exits, the session is closed, it stays idle for
+// longer than the registry idle timeout, or the owning MCP session disconnects.
+func (c *Core) PodsExecStart(ctx context.Context, namespace, name, container string, command []string, tty bool) (*ExecSession, error) {
+	owner, err := mcpSessionOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = ExecSessions.reserve(owner); err != nil {
+		return nil, err
+	}
+	executor, err := c.podsExecutor(ctx, namespace, name, container, command, true, tty)
+	if err != nil {
+		ExecSessions.release(owner)
+		return nil, err
+	}
+	stdinReader, stdinWriter := io.Pipe()
+	session := &ExecSession{
+		ID:        "exec-" + rand.String(8),
+		Namespace: c.NamespaceOrDefault(namespace),
+		Name:      name,
+		Container: container,
+		Command:   command,
+		Tty:       tty,
+		owner:     owner,
+		stdin:     stdinWriter,
+		stdinLock: make(chan struct{}, 1),
+		notify:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	session.stdout = &execOutputBuffer{notify: session.notify}
+	session.stderr = &execOutputBuffer{notify: session.notify}
+	// The stream must outlive the tool call request, so it's bound to its own context
+	streamCtx, cancel := context.WithCancel(context.Background())
+	session.cancel = cancel
+	streamOptions := remotecommand.StreamOptions{Stdin: stdinReader, Stdout: session.stdout, Tty: tty}
+	if !tty {
+		streamOptions.Stderr = session.stderr
+	}
+	go func() {
+		defer close(session.done)
+		session.err = executor.StreamWithContext(streamCtx, streamOptions)
+		_ = stdinReader.Close()
+		klog.V(3).Infof("exec session %s in pod %s/%s finished: %v", session.ID, session.Namespace, session.Name, session.err)
+	}()
+	ExecSessions.add(ctx, session)
+	return session, nil
+}
+
+// ExecSessionRegistry keeps track of the running interactive exec sessions.
+// Sessions are scoped to the MCP session that started them.
+type ExecSessionRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*ExecSession
+	watched  map[string]struct{}
+	// reserved is the number of sessions being started per MCP session, counted towards the limit until added
+	reserved    map[string]int
+	idleTimeout time.Duration
+	max         int
+}
+
+// NewExecSessionRegistry creates an ExecSessionRegistry with the provided idle timeout and maximum number of
+// running sessions per MCP session.
+func NewExecSessionRegistry(idleTimeout time.Duration, max int) *ExecSessionRegistry {
+	return &ExecSessionRegistry{
+		sessions:    make(map[string]*ExecSession),
+		watched:     make(map[string]struct{}),
+		reserved:    make(map[string]int),
+		idleTimeout: idleTimeout,
+		max:         max,
+	}
+}
+
+// Configure sets the idle timeout and the maximum number of running sessions per MCP session,
+// zero values keep the defaults and negative values disable the limit.
+func (r *ExecSessionRegistry) Configure(idleTimeout time.Duration, max int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.idleTimeout = configuredLimit(idleTimeout, DefaultExecSessionIdleTimeout)
+	r.max = configuredLimit(max, DefaultExecSessionsMax)
+}
+
+// configuredLimit returns the default limit for zero values and disables it (0) for negative values
+func configuredLimit[T int | time.Duration](value, defaultValue T) T {
+	switch {
+	case value == 0:
+		return defaultValue
+	case value < 0:
+		return 0
+	default:
+		return value
+	}
+}
+
+// Write sends the provided input to the session stdin, closing stdin afterward if closeStdin is true.
+func (r *ExecSessionRegistry) Write(ctx context.Context, id, input string, closeStdin bool) error {
+	session, err := r.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !session.running() {
+		return fmt.Errorf("exec session %s has already finished", id)
+	}
+	// Writes to the stdin pipe block until the command reads them, so they're bound to ctx and a timeout.
+	// A pending write holds the stdin lock until the command reads its stdin or the session is closed.
+	writeCtx, cancel := context.WithTimeout(ctx, execSessionWriteTimeout)
+	defer cancel()
+	select {
+	case session.stdinLock <- struct{}{}:
+	case <-writeCtx.Done():
+		return fmt.Errorf("failed to write to exec session %s, a previous write is still pending as the command isn't reading its stdin: %w", id, writeCtx.Err())
+	}
+	written := make(chan error, 1)
+	go func() {
+		defer func() { <-session.stdinLock }()
+		var writeErr error
+		if input != "" {
+			_, writeErr = io.WriteString(session.stdin, input)
+		}
+		if writeErr == nil && closeStdin {
+			writeErr = session.stdin.Close()
+		}
+		written <- writeErr
+	}()
+	select {
+	case err = <-written:
+		if err != nil {
+			return fmt.Errorf("failed to write to exec session %s: %w", id, err)
+		}
+		return nil
+	case <-writeCtx.Done():
+		return fmt.Errorf("failed to write to exec session %s, the command isn't reading its stdin (the write is pending until it does or the session is closed): %w", id, writeCtx.Err())
+	}
+}
+
+// Read returns and drains the output buffered by the session.
+// If there's no buffered output and the session is still running, it waits up to wait for new output.
+func (r *ExecSessionRegistry) Read(ctx context.Context, id string, wait time.Duration) (*ExecSessionOutput, error) {
+	session, err := r.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 && session.running() && session.stdout.len()+session.stderr.len() == 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-session.notify:
+		case <-session.done:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+	}
+	output := &ExecSessionOutput{Running: session.running()}
+	var discarded int
+	output.Stdout, discarded = session.stdout.drain()
+	output.Discarded += discarded
+	output.Stderr, discarded = session.stderr.drain()
+	output.Discarded += discarded
+	if !output.Running {
+		output.Err = session.err
+		// Without idle timeout the finished sessions are removed once their remaining output is read
+		r.mu.Lock()
+		purge := r.idleTimeout <= 0
+		r.mu.Unlock()
+		if purge {
+			r.remove(id)
+		}
+	}
+	return output, nil
+}
+
+// Close terminates the session and removes it from the registry.
+func (r *ExecSessionRegistry) Close(ctx context.Context, id string) error {
+	if _, err := r.get(ctx, id); err != nil {
+		return err
+	}
+	r.remove(id)
+	return nil
+}
+
+// reserve checks the limit of running sessions for owner and reserves a slot for a new one in the same critical
+// section, the slot is taken by add or must be returned with release if the session fails to start
+func (r *ExecSessionRegistry) reserve(owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	running := r.reserved[owner]
+	for _, session := range r.sessions {
+		if session.owner == owner && session.running() {
+			running++
+		}
+	}
+	if r.max > 0 && running >= r.max {
+		return fmt.Errorf("maximum number of exec sessions (%d) reached, close an existing session before starting a new one", r.max)
+	}
+	r.reserved[owner]++
+	return nil
+}
+
+func (r *ExecSessionRegistry) release(owner string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.releaseLocked(owner)
+}
+
+func (r *ExecSessionRegistry) releaseLocked(owner string) {
+	if r.reserved[owner]--; r.reserved[owner] <= 0 {
+		delete(r.reserved, owner)
+	}
+}
+
+func (r *ExecSessionRegistry) add(ctx context.Context, session *ExecSession) {
+	r.mu.Lock()
+	var finished []*ExecSession
+	defer func() {
+		r.mu.Unlock()
+		for _, s := range finished {
+			s.close()
+		}
+	}()
+	r.releaseLocked(session.owner)
+	r.sessions[session.ID] = session
+	if r.idleTimeout > 0 {
+		session.idleTimer = time.AfterFunc(r.idleTimeout, func() {
+			klog.V(2).Infof("exec session %s idle for more than %s, closing", session.ID, r.idleTimeout)
+			r.remove(session.ID)
+		})
+	} else {
+		// Without idle timeout the finished sessions whose output is never read are purged when new ones start
+		for id, s := range r.sessions {
+			if !s.running() {
+				finished = append(finished, s)
+				delete(r.sessions, id)
+			}
+		}
+	}
+	// Release the sessions once the MCP session they belong to is closed
+	mcpSession, ok := api.SessionFromContext(ctx)
+	if _, watched := r.watched[session.owner]; ok && session.owner != "" && !watched {
+		r.watched[session.owner] = struct{}{}
+		go func() {
+			_ = mcpSession.Wait()
+			r.removeOwner(session.owner)
+		}()
+	}
+}
+
+func (r *ExecSessionRegistry) get(ctx context.Context, id string) (*ExecSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	owner, err := mcpSessionOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, ok := r.sessions[id]
+	if !ok || session.owner != owner {
+		return nil, fmt.Errorf("%w: %s", ErrExecSessionNotFound, id)
+	}
+	if session.idleTimer != nil && r.idleTimeout > 0 {
+		session.idleTimer.Reset(r.idleTimeout)
+	}
+	return session, nil
+}
+
+func (r *ExecSessionRegistry) remove(id string) {
+	r.mu.Lock()
+	session, ok := r.sessions[id]
+	delete(r.sessions, id)
+	r.mu.Unlock()
+	if ok {
+		session.close()
+	}
+}
+
+func (r *ExecSessionRegistry) removeOwner(owner string) {
+	r.mu.Lock()
+	delete(r.watched, owner)
+	var toClose []*ExecSession
+	for id, session := range r.sessions {
+		if session.owner == owner {
+			toClose = append(toClose, session)
+			delete(r.sessions, id)
+		}
+	}
+	r.mu.Unlock()
+	for _, session := range toClose {
+		session.close()
+	}
+}
+
+func (s *ExecSession) running() bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (s *ExecSession) close() {
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+	}
+	_ = s.stdin.Close()
+	s.cancel()
+}
+
+// mcpSessionOwner returns the identifier of the MCP session in ctx (empty for transports without session
+// identifiers, e.g. stdio), or ErrMcpSessionRequired if there's none to scope the session state to
+func mcpSessionOwner(ctx context.Context) (string, error) {
+	if session, ok := api.SessionFromContext(ctx); ok {
+		return session.ID(), nil
+	}
+	return "", ErrMcpSessionRequired
+}
+
+// execOutputBuffer is a concurrency-safe buffer that retains at most execSessionOutputLimit unread bytes.
+type execOutputBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	discarded int
+	notify    chan struct{}
+}
+
+var _ io.Writer = (*execOutputBuffer)(nil)
+
+func (b *execOutputBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n, _ := b.buf.Write(p)
+	if overflow := b.buf.Len() - execSessionOutputLimit; overflow > 0 {
+		b.buf.Next(overflow)
+		b.discarded += overflow
+	}
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+	return n, nil
+}
+
+func (b *execOutputBuffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+func (b *execOutputBuffer) drain() (string, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ret, discarded := b.buf.String(), b.discarded
+	b.buf.Reset()
+	b.discarded = 0
+	return ret, discarded
+}
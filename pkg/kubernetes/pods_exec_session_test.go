@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+type ExecSessionRegistrySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// testMcpSession is an MCP session that is never closed
+type testMcpSession struct{}
+
+func (testMcpSession) ID() string  { return "mcp-session" }
+func (testMcpSession) Wait() error { select {} }
+
+func (s *ExecSessionRegistrySuite) SetupTest() {
+	s.ctx = api.WithSession(context.Background(), testMcpSession{})
+}
+
+// newSession returns a session whose command never reads its stdin and keeps running until done is closed
+func (s *ExecSessionRegistrySuite) newSession(id string) (*ExecSession, *io.PipeReader) {
+	stdinReader, stdinWriter := io.Pipe()
+	session := &ExecSession{
+		ID:        id,
+		owner:     "mcp-session",
+		stdin:     stdinWriter,
+		stdinLock: make(chan struct{}, 1),
+		stdout:    &execOutputBuffer{},
+		stderr:    &execOutputBuffer{},
+		notify:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		cancel:    func() {},
+	}
+	return session, stdinReader
+}
+
+func (s *ExecSessionRegistrySuite) TestWrite() {
+	registry := NewExecSessionRegistry(time.Minute, 0)
+	session, stdinReader := s.newSession("exec-write")
+	registry.add(s.ctx, session)
+	s.Run("times out if the command isn't reading its stdin", func() {
+		ctx, cancel := context.WithTimeout(s.ctx, 50*time.Millisecond)
+		defer cancel()
+		err := registry.Write(ctx, "exec-write", "first\n", false)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "the command isn't reading its stdin")
+	})
+	s.Run("rejects new writes while a previous write is pending", func() {
+		ctx, cancel := context.WithTimeout(s.ctx, 50*time.Millisecond)
+		defer cancel()
+		err := registry.Write(ctx, "exec-write", "second\n", false)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "a previous write is still pending")
+	})
+	s.Run("writes in order once the command reads its stdin", func() {
+		read := make(chan string, 1)
+		go func() {
+			buf := make([]byte, 64)
+			n, _ := stdinReader.Read(buf)
+			m, _ := stdinReader.Read(buf[n:])
+			read <- string(buf[:n+m])
+		}()
+		s.Require().NoError(registry.Write(s.ctx, "exec-write", "third\n", false))
+		s.Equal("first\nthird\n", <-read)
+	})
+}
+
+func (s *ExecSessionRegistrySuite) TestPurgeWithoutIdleTimeout() {
+	registry := NewExecSessionRegistry(-1, 0)
+	read, _ := s.newSession("exec-read")
+	unread, _ := s.newSession("exec-unread")
+	registry.add(s.ctx, read)
+	registry.add(s.ctx, unread)
+	close(read.done)
+	close(unread.done)
+	s.Run("removes the finished sessions once their output is read", func() {
+		output, err := registry.Read(s.ctx, "exec-read", 0)
+		s.Require().NoError(err)
+		s.False(output.Running)
+		_, err = registry.Read(s.ctx, "exec-read", 0)
+		s.ErrorIs(err, ErrExecSessionNotFound)
+	})
+	s.Run("purges the finished sessions when a new one starts", func() {
+		running, _ := s.newSession("exec-running")
+		registry.add(s.ctx, running)
+		s.NotContains(registry.sessions, "exec-unread")
+		s.Contains(registry.sessions, "exec-running")
+	})
+}
+
+func TestExecSessionRegistry(t *testing.T) {
+	suite.Run(t, new(ExecSessionRegistrySuite))
+}
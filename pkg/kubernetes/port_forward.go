@@ -0,0 +1,426 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog/v2"
+	kubectlutil "k8s.io/kubectl/pkg/util"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// DefaultPortForwardTTL is the maximum lifetime of a port forward before it's closed automatically
+	DefaultPortForwardTTL = 30 * time.Minute
+	// DefaultPortForwardsMax is the maximum number of active port forwards per MCP session
+	DefaultPortForwardsMax = 10
+	// portForwardReadyTimeout is the maximum time to wait for the local listener to be ready
+	portForwardReadyTimeout = 30 * time.Second
+	// portForwardHTTPProbeMaxBody is the maximum number of response body bytes returned by HTTPProbe
+	portForwardHTTPProbeMaxBody = 64 * 1024
+)
+
+var ErrPortForwardNotFound = errors.New("port forward not found")
+
+// PortForwards is the registry of active port forwards shared by all tool calls.
+var PortForwards = NewPortForwardRegistry(DefaultPortForwardTTL, DefaultPortForwardsMax)
+
+// PortForward is a local listener on the server host forwarding connections to a Pod port.
+type PortForward struct {
+	ID        string
+	Namespace string
+	Pod       string
+	// Service is the name of the Service the forward was requested for, empty for Pod forwards
+	Service      string
+	RemotePort   int32
+	LocalAddress string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+
+	owner    string
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	ttlTimer *time.Timer
+}
+
+// PortForwardHTTPProbeResult is the response to an HTTP GET request issued through a PortForward.
+type PortForwardHTTPProbeResult struct {
+	URL        string
+	Status     string
+	StatusCode int
+	Header     http.Header
+	Body       string
+	// Truncated is true when the response body exceeded the maximum size and was cut
+	Truncated bool
+}
+
+// PodsPortForward forwards a local port on the server host to the provided Pod port.
+// The forward stays open until it's closed, its TTL expires, the connection to the Pod is lost,
+// or the owning MCP session disconnects.
+func (c *Core) PodsPortForward(ctx context.Context, namespace, name string, port int32) (*PortForward, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, fmt.Errorf("unable to forward port because pod is not running. Current status=%v", pod.Status.Phase)
+	}
+	return c.portForward(ctx, pod, "", port)
+}
+
+// ServicesPortForward forwards a local port on the server host to the provided Service port.
+// Like `kubectl port-forward svc/name`, the connection is established to a single running Pod selected by the Service,
+// resolving the Service target port (named or numeric) against the Pod container ports.
+func (c *Core) ServicesPortForward(ctx context.Context, namespace, name string, port int32) (*PortForward, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	svc, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no selector", name)
+	}
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	var lookupErr error
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		// The target port may be a named port that is only exposed by some of the pods
+		podPort, err := kubectlutil.LookupContainerPortNumberByServicePort(*svc, *pod, port)
+		if err != nil {
+			lookupErr = err
+			continue
+		}
+		return c.portForward(ctx, pod, name, podPort)
+	}
+	if lookupErr != nil {
+		return nil, fmt.Errorf("no running pods of service %s expose port %d: %w", name, port, lookupErr)
+	}
+	return nil, fmt.Errorf("no running pods found for service %s", name)
+}
+
+func (c *Core) portForward(ctx context.Context, pod *v1.Pod, service string, port int32) (*PortForward, error) {
+	owner, err := mcpSessionOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = PortForwards.reserve(owner); err != nil {
+		return nil, err
+	}
+	// The reserved slot is taken by add, or returned if the port forward fails to start
+	added := false
+	defer func() {
+		if !added {
+			PortForwards.release(owner)
+		}
+	}()
+	restConfig, err := c.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/portforward/portforward.go#L137-L157
+	portForwardURL := c.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, portForwardURL)
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(portForwardURL, restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dialer = portforward.NewFallbackDialer(tunnelingDialer, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	forward := &PortForward{
+		ID:         "pf-" + rand.String(8),
+		Namespace:  pod.Namespace,
+		Pod:        pod.Name,
+		Service:    service,
+		RemotePort: port,
+		CreatedAt:  time.Now(),
+		owner:      owner,
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)},
+		forward.stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	forwardErr := make(chan error, 1)
+	go func() {
+		defer close(forward.done)
+		err := forwarder.ForwardPorts()
+		forwardErr <- err
+		klog.V(3).Infof("port forward %s to pod %s/%s:%d finished: %v", forward.ID, forward.Namespace, forward.Pod, port, err)
+		PortForwards.remove(forward.ID)
+	}()
+	select {
+	case <-readyCh:
+	case err = <-forwardErr:
+		return nil, fmt.Errorf("failed to forward port %d: %w", port, err)
+	case <-time.After(portForwardReadyTimeout):
+		forward.stop()
+		return nil, fmt.Errorf("timed out waiting for port %d to be forwarded", port)
+	case <-ctx.Done():
+		forward.stop()
+		return nil, ctx.Err()
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		forward.stop()
+		return nil, fmt.Errorf("failed to get the forwarded local port: %v", err)
+	}
+	forward.LocalAddress = "127.0.0.1:" + strconv.Itoa(int(ports[0].Local))
+	PortForwards.add(ctx, forward)
+	added = true
+	return forward, nil
+}
+
+// PortForwardRegistry keeps track of the active port forwards.
+// Port forwards are scoped to the MCP session that opened them.
+type PortForwardRegistry struct {
+	mu       sync.Mutex
+	forwards map[string]*PortForward
+	watched  map[string]struct{}
+	// reserved is the number of port forwards being opened per MCP session, counted towards the limit until added
+	reserved map[string]int
+	ttl      time.Duration
+	max      int
+}
+
+// NewPortForwardRegistry creates a PortForwardRegistry with the provided TTL and maximum number of
+// active port forwards per MCP session.
+func NewPortForwardRegistry(ttl time.Duration, max int) *PortForwardRegistry {
+	return &PortForwardRegistry{
+		forwards: make(map[string]*PortForward),
+		watched:  make(map[string]struct{}),
+		reserved: make(map[string]int),
+		ttl:      ttl,
+		max:      max,
+	}
+}
+
+// Configure sets the TTL and the maximum number of active port forwards per MCP session,
+// zero values keep the defaults and negative values disable the limit.
+func (r *PortForwardRegistry) Configure(ttl time.Duration, max int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttl = configuredLimit(ttl, DefaultPortForwardTTL)
+	r.max = configuredLimit(max, DefaultPortForwardsMax)
+}
+
+// List returns the active port forwards of the MCP session in ctx sorted by creation time.
+func (r *PortForwardRegistry) List(ctx context.Context) ([]*PortForward, error) {
+	owner, err := mcpSessionOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]*PortForward, 0)
+	for _, forward := range r.forwards {
+		if forward.owner == owner {
+			ret = append(ret, forward)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].CreatedAt.Before(ret[j].CreatedAt) })
+	return ret, nil
+}
+
+// Close stops the port forward and removes it from the registry.
+func (r *PortForwardRegistry) Close(ctx context.Context, id string) error {
+	if _, err := r.get(ctx, id); err != nil {
+		return err
+	}
+	r.remove(id)
+	return nil
+}
+
+// HTTPProbe issues an HTTP GET request for path through the port forward and returns the response.
+// TLS certificates are not verified since the request targets the local listener instead of the original host.
+func (r *PortForwardRegistry) HTTPProbe(ctx context.Context, id, scheme, path string, timeout time.Duration) (*PortForwardHTTPProbeResult, error) {
+	forward, err := r.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	probeURL := scheme + "://" + forward.LocalAddress + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- the local listener can't match the original certificate
+		},
+		// Return redirects as-is, following them would leave the forwarded port
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	defer client.CloseIdleConnections()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, portForwardHTTPProbeMaxBody+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	result := &PortForwardHTTPProbeResult{
+		URL:        probeURL,
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+	if len(body) > portForwardHTTPProbeMaxBody {
+		body = body[:portForwardHTTPProbeMaxBody]
+		result.Truncated = true
+	}
+	result.Body = string(body)
+	return result, nil
+}
+
+// reserve checks the limit of active port forwards for owner and reserves a slot for a new one in the same critical
+// section, the slot is taken by add or must be returned with release if the port forward fails to start
+func (r *PortForwardRegistry) reserve(owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	active := r.reserved[owner]
+	for _, forward := range r.forwards {
+		if forward.owner == owner {
+			active++
+		}
+	}
+	if r.max > 0 && active >= r.max {
+		return fmt.Errorf("maximum number of port forwards (%d) reached, close an existing port forward before opening a new one", r.max)
+	}
+	r.reserved[owner]++
+	return nil
+}
+
+func (r *PortForwardRegistry) release(owner string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.releaseLocked(owner)
+}
+
+func (r *PortForwardRegistry) releaseLocked(owner string) {
+	if r.reserved[owner]--; r.reserved[owner] <= 0 {
+		delete(r.reserved, owner)
+	}
+}
+
+func (r *PortForwardRegistry) add(ctx context.Context, forward *PortForward) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.releaseLocked(forward.owner)
+	select {
+	case <-forward.done:
+		// The forward finished before it could be registered
+		return
+	default:
+	}
+	r.forwards[forward.ID] = forward
+	if r.ttl > 0 {
+		forward.ExpiresAt = forward.CreatedAt.Add(r.ttl)
+		forward.ttlTimer = time.AfterFunc(r.ttl, func() {
+			klog.V(2).Infof("port forward %s TTL of %s expired, closing", forward.ID, r.ttl)
+			r.remove(forward.ID)
+		})
+	}
+	// Release the port forwards once the MCP session they belong to is closed
+	mcpSession, ok := api.SessionFromContext(ctx)
+	if _, watched := r.watched[forward.owner]; ok && forward.owner != "" && !watched {
+		r.watched[forward.owner] = struct{}{}
+		go func() {
+			_ = mcpSession.Wait()
+			r.removeOwner(forward.owner)
+		}()
+	}
+}
+
+func (r *PortForwardRegistry) get(ctx context.Context, id string) (*PortForward, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	owner, err := mcpSessionOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	forward, ok := r.forwards[id]
+	if !ok || forward.owner != owner {
+		return nil, fmt.Errorf("%w: %s", ErrPortForwardNotFound, id)
+	}
+	return forward, nil
+}
+
+func (r *PortForwardRegistry) remove(id string) {
+	r.mu.Lock()
+	forward, ok := r.forwards[id]
+	delete(r.forwards, id)
+	r.mu.Unlock()
+	if ok {
+		forward.stop()
+	}
+}
+
+func (r *PortForwardRegistry) removeOwner(owner string) {
+	r.mu.Lock()
+	delete(r.watched, owner)
+	var toStop []*PortForward
+	for id, forward := range r.forwards {
+		if forward.owner == owner {
+			toStop = append(toStop, forward)
+			delete(r.forwards, id)
+		}
+	}
+	r.mu.Unlock()
+	for _, forward := range toStop {
+		forward.stop()
+	}
+}
+
+func (f *PortForward) stop() {
+	if f.ttlTimer != nil {
+		f.ttlTimer.Stop()
+	}
+	f.stopOnce.Do(func() { close(f.stopCh) })
+}
@@ -0,0 +1,263 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes/watcher"
+)
+
+// CapiTargetParameterName is the parameter name used to specify
+// the cluster when using the capi cluster provider strategy.
+const CapiTargetParameterName = "cluster"
+
+// CapiDefaultManagementTarget is the default target name of the Cluster API management cluster.
+const CapiDefaultManagementTarget = "management"
+
+// The kubeconfig Secret created by Cluster API for each workload cluster
+const (
+	capiKubeconfigSecretType = "cluster.x-k8s.io/secret"
+	capiKubeconfigSecretKey  = "value"
+)
+
+// capiClusterGroupKind is the Cluster API Cluster, the version is resolved with the management cluster RESTMapper
+var capiClusterGroupKind = schema.GroupKind{Group: "cluster.x-k8s.io", Kind: "Cluster"}
+
+// CapiProviderConfig is the configuration of the capi cluster provider ([cluster_provider_configs.capi]).
+type CapiProviderConfig struct {
+	// Namespace to discover the Cluster objects from, defaults to all the namespaces
+	Namespace string `toml:"namespace,omitempty"`
+	// ManagementTarget is the target name of the management cluster, defaults to CapiDefaultManagementTarget
+	ManagementTarget string `toml:"management_target,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*CapiProviderConfig)(nil)
+
+func (c *CapiProviderConfig) Validate() error {
+	if strings.Contains(c.ManagementTarget, "/") {
+		return fmt.Errorf("invalid management_target %s: must not contain /", c.ManagementTarget)
+	}
+	return nil
+}
+
+// capiClusterProvider implements Provider for managing the workload clusters of a Cluster API management cluster.
+// The workload clusters are discovered from the Cluster objects, named namespace/name, and accessed with the
+// kubeconfig stored by Cluster API in their <name>-kubeconfig Secret. The management cluster is the default target.
+type capiClusterProvider struct {
+	config              api.BaseConfig
+	namespace           string
+	managementTarget    string
+	management          *Manager
+	mu                  sync.Mutex
+	clusters            []string
+	managers            map[string]*Manager
+	clustersWatcher     *watcher.Targets
+	clusterStateWatcher *watcher.ClusterState
+}
+
+var _ Provider = &capiClusterProvider{}
+
+func init() {
+	RegisterProvider(api.ClusterProviderCapi, newCapiClusterProvider)
+	config.RegisterProviderConfig(api.ClusterProviderCapi, capiProviderConfigParser)
+}
+
+func capiProviderConfigParser(_ context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg CapiProviderConfig
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// newCapiClusterProvider creates a provider that manages the workload clusters of the Cluster API management cluster
+// configured in the kubeconfig (or the in-cluster one).
+func newCapiClusterProvider(cfg api.BaseConfig) (Provider, error) {
+	ret := &capiClusterProvider{config: cfg, managementTarget: CapiDefaultManagementTarget}
+	if providerConfig, ok := cfg.GetProviderConfig(api.ClusterProviderCapi); ok {
+		if capiConfig, ok := providerConfig.(*CapiProviderConfig); ok {
+			ret.namespace = capiConfig.Namespace
+			if capiConfig.ManagementTarget != "" {
+				ret.managementTarget = capiConfig.ManagementTarget
+			}
+		}
+	}
+	var err error
+	if IsInCluster(cfg) {
+		ret.management, err = NewInClusterManager(cfg)
+	} else {
+		ret.management, err = NewKubeconfigManager(cfg, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manager for the management cluster: %w", err)
+	}
+	if err = ret.reset(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *capiClusterProvider) reset() error {
+	clusters, err := p.discoverClusters(context.Background())
+	if err != nil {
+		klog.Warningf("Failed to discover Cluster API clusters, only the management cluster is available: %v", err)
+	}
+
+	p.mu.Lock()
+	p.clusters = clusters
+	// The workload cluster managers are recreated, their kubeconfig Secret may have changed
+	previous := p.managers
+	p.managers = make(map[string]*Manager, len(clusters))
+	p.mu.Unlock()
+	for _, m := range previous {
+		m.Close()
+	}
+
+	p.Close()
+	p.clustersWatcher = watcher.NewTargets(func() ([]string, error) {
+		return p.discoverClusters(context.Background())
+	})
+	p.clusterStateWatcher = watcher.NewClusterState(p.management.kubernetes.DiscoveryClient())
+	return nil
+}
+
+// discoverClusters returns the namespace/name of the Cluster objects in the management cluster
+func (p *capiClusterProvider) discoverClusters(ctx context.Context) ([]string, error) {
+	mapping, err := p.management.kubernetes.RESTMapper().RESTMapping(capiClusterGroupKind)
+	if err != nil {
+		return nil, err
+	}
+	list, err := p.management.kubernetes.DynamicClient().Resource(mapping.Resource).Namespace(p.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.GetDeletionTimestamp() != nil {
+			continue
+		}
+		clusters = append(clusters, item.GetNamespace()+"/"+item.GetName())
+	}
+	sort.Strings(clusters)
+	return clusters, nil
+}
+
+// managerForTarget returns the Manager for the target, the workload cluster managers are created lazily from
+// their kubeconfig Secret, which is retrieved without holding the lock so that a slow cluster doesn't block the others
+func (p *capiClusterProvider) managerForTarget(ctx context.Context, target string) (*Manager, error) {
+	if target == "" || target == p.managementTarget {
+		return p.management, nil
+	}
+	p.mu.Lock()
+	m, ok := p.managers[target]
+	known := slices.Contains(p.clusters, target)
+	p.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+	if !known {
+		return nil, fmt.Errorf("cluster %s not found", target)
+	}
+	kubeconfig, err := p.kubeconfig(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	m, err = NewKubeconfigDataManager(p.config, kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manager for cluster %s: %w", target, err)
+	}
+	p.mu.Lock()
+	// Another call may have created the manager meanwhile, or the clusters may have been reset
+	existing, ok := p.managers[target]
+	known = slices.Contains(p.clusters, target)
+	if !ok && known {
+		p.managers[target] = m
+	}
+	p.mu.Unlock()
+	switch {
+	case ok:
+		m.Close()
+		return existing, nil
+	case !known:
+		m.Close()
+		return nil, fmt.Errorf("cluster %s not found", target)
+	}
+	return m, nil
+}
+
+// kubeconfig returns the kubeconfig of the workload cluster from its Cluster API kubeconfig Secret
+func (p *capiClusterProvider) kubeconfig(ctx context.Context, target string) ([]byte, error) {
+	namespace, name, _ := strings.Cut(target, "/")
+	secret, err := p.management.kubernetes.CoreV1().Secrets(namespace).Get(ctx, name+"-kubeconfig", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig Secret of cluster %s: %w", target, err)
+	}
+	if secret.Type != capiKubeconfigSecretType {
+		return nil, fmt.Errorf("the kubeconfig Secret %s/%s of cluster %s has type %q, expected %q",
+			secret.Namespace, secret.Name, target, secret.Type, capiKubeconfigSecretType)
+	}
+	kubeconfig := secret.Data[capiKubeconfigSecretKey]
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("the kubeconfig Secret %s/%s of cluster %s has no %s key",
+			secret.Namespace, secret.Name, target, capiKubeconfigSecretKey)
+	}
+	return kubeconfig, nil
+}
+
+func (p *capiClusterProvider) IsOpenShift(ctx context.Context) bool {
+	return p.management.IsOpenShift(ctx)
+}
+
+func (p *capiClusterProvider) GetTargets(_ context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{p.managementTarget}, p.clusters...), nil
+}
+
+func (p *capiClusterProvider) GetTargetParameterName() string {
+	return CapiTargetParameterName
+}
+
+func (p *capiClusterProvider) GetDerivedKubernetes(ctx context.Context, target string) (*Kubernetes, error) {
+	m, err := p.managerForTarget(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return m.Derived(ctx)
+}
+
+func (p *capiClusterProvider) GetDefaultTarget() string {
+	return p.managementTarget
+}
+
+func (p *capiClusterProvider) WatchTargets(reload McpReload) {
+	reloadWithReset := func() error {
+		if err := p.reset(); err != nil {
+			return err
+		}
+		p.WatchTargets(reload)
+		return reload()
+	}
+	p.clustersWatcher.Watch(reloadWithReset)
+	p.clusterStateWatcher.Watch(reload)
+}
+
+func (p *capiClusterProvider) Close() {
+	for _, w := range []watcher.Watcher{p.clustersWatcher, p.clusterStateWatcher} {
+		if !reflect.ValueOf(w).IsNil() {
+			w.Close()
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type ProviderCapiTestSuite struct {
+	BaseProviderSuite
+	managementServer *test.MockServer
+	workloadServer   *test.MockServer
+	provider         Provider
+}
+
+func (s *ProviderCapiTestSuite) SetupTest() {
+	s.BaseProviderSuite.SetupTest()
+	s.workloadServer = test.NewMockServer()
+	s.workloadServer.Handle(test.NewDiscoveryClientHandler())
+	workloadKubeconfig, err := clientcmd.Write(*s.workloadServer.Kubeconfig())
+	s.Require().NoError(err)
+
+	s.managementServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "cluster.x-k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "clusters", Kind: "Cluster", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}}},
+	})
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.managementServer.Handle(discovery)
+	cluster := func(namespace, name string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "cluster.x-k8s.io/v1beta1",
+			"kind":       "Cluster",
+			"metadata":   map[string]interface{}{"namespace": namespace, "name": name},
+		}}
+	}
+	s.managementServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/apis/cluster.x-k8s.io/v1beta1/clusters":
+			test.WriteObject(w, &unstructured.UnstructuredList{
+				Object: map[string]interface{}{"apiVersion": "cluster.x-k8s.io/v1beta1", "kind": "ClusterList"},
+				Items: []unstructured.Unstructured{
+					cluster("team-a", "workload"), cluster("team-b", "no-secret"), cluster("team-b", "opaque"), cluster("team-b", "no-value"),
+				},
+			})
+		case "/api/v1/namespaces/team-a/secrets/workload-kubeconfig":
+			test.WriteObject(w, &v1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "workload-kubeconfig"},
+				Type:       "cluster.x-k8s.io/secret",
+				Data:       map[string][]byte{"value": workloadKubeconfig},
+			})
+		case "/api/v1/namespaces/team-b/secrets/opaque-kubeconfig":
+			test.WriteObject(w, &v1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "opaque-kubeconfig"},
+				Type:       v1.SecretTypeOpaque,
+				Data:       map[string][]byte{"value": workloadKubeconfig},
+			})
+		case "/api/v1/namespaces/team-b/secrets/no-value-kubeconfig":
+			test.WriteObject(w, &v1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "no-value-kubeconfig"},
+				Type:       "cluster.x-k8s.io/secret",
+				Data:       map[string][]byte{"kubeconfig": workloadKubeconfig},
+			})
+		case "/api/v1/namespaces/team-b/secrets/no-secret-kubeconfig":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	provider, err := NewProvider(&config.StaticConfig{
+		KubeConfig:              s.managementServer.KubeconfigFile(s.T()),
+		ClusterProviderStrategy: "capi",
+	})
+	s.Require().NoError(err, "Expected no error creating capi provider")
+	s.provider = provider
+}
+
+func (s *ProviderCapiTestSuite) TearDownTest() {
+	s.BaseProviderSuite.TearDownTest()
+	s.provider.Close()
+	s.managementServer.Close()
+	s.workloadServer.Close()
+}
+
+func (s *ProviderCapiTestSuite) TestType() {
+	s.IsType(&capiClusterProvider{}, s.provider)
+}
+
+func (s *ProviderCapiTestSuite) TestGetTargets() {
+	s.Run("GetTargets returns the management cluster and the Cluster API clusters", func() {
+		targets, err := s.provider.GetTargets(s.T().Context())
+		s.Require().NoError(err, "Expected no error from GetTargets")
+		s.Equal([]string{"management", "team-a/workload", "team-b/no-secret", "team-b/no-value", "team-b/opaque"}, targets)
+	})
+}
+
+func (s *ProviderCapiTestSuite) TestGetDerivedKubernetes() {
+	s.Run("GetDerivedKubernetes returns Kubernetes for the management cluster", func() {
+		k8s, err := s.provider.GetDerivedKubernetes(s.T().Context(), "management")
+		s.Require().NoError(err)
+		s.Equal(s.managementServer.Config().Host, k8s.RESTConfig().Host)
+	})
+	s.Run("GetDerivedKubernetes returns Kubernetes for a workload cluster from its kubeconfig Secret", func() {
+		k8s, err := s.provider.GetDerivedKubernetes(s.T().Context(), "team-a/workload")
+		s.Require().NoError(err)
+		s.Equal(s.workloadServer.Config().Host, k8s.RESTConfig().Host)
+	})
+	s.Run("GetDerivedKubernetes returns error for a workload cluster without kubeconfig Secret", func() {
+		_, err := s.provider.GetDerivedKubernetes(s.T().Context(), "team-b/no-secret")
+		s.ErrorContains(err, "failed to get the kubeconfig Secret of cluster team-b/no-secret")
+	})
+	s.Run("GetDerivedKubernetes returns error for a workload cluster with a kubeconfig Secret of another type", func() {
+		_, err := s.provider.GetDerivedKubernetes(s.T().Context(), "team-b/opaque")
+		s.EqualError(err, `the kubeconfig Secret team-b/opaque-kubeconfig of cluster team-b/opaque has type "Opaque", expected "cluster.x-k8s.io/secret"`)
+	})
+	s.Run("GetDerivedKubernetes returns error for a workload cluster with a kubeconfig Secret without value", func() {
+		_, err := s.provider.GetDerivedKubernetes(s.T().Context(), "team-b/no-value")
+		s.EqualError(err, "the kubeconfig Secret team-b/no-value-kubeconfig of cluster team-b/no-value has no value key")
+	})
+	s.Run("GetDerivedKubernetes recreates the workload cluster managers when the clusters are reloaded", func() {
+		provider := s.provider.(*capiClusterProvider)
+		before, err := provider.managerForTarget(s.T().Context(), "team-a/workload")
+		s.Require().NoError(err)
+		s.Require().NoError(provider.reset())
+		after, err := provider.managerForTarget(s.T().Context(), "team-a/workload")
+		s.Require().NoError(err)
+		s.NotSame(before, after)
+	})
+	s.Run("GetDerivedKubernetes returns error for unknown cluster", func() {
+		_, err := s.provider.GetDerivedKubernetes(s.T().Context(), "team-a/unknown")
+		s.ErrorContains(err, "cluster team-a/unknown not found")
+	})
+}
+
+func (s *ProviderCapiTestSuite) TestGetDefaultTarget() {
+	s.Run("GetDefaultTarget returns the management cluster", func() {
+		s.Equal("management", s.provider.GetDefaultTarget())
+	})
+	s.Run("GetDefaultTarget returns the configured management target", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			cluster_provider_strategy = "capi"
+			[cluster_provider_configs.capi]
+			management_target = "hub"
+		`)))
+		cfg.KubeConfig = s.managementServer.KubeconfigFile(s.T())
+		provider, err := NewProvider(cfg)
+		s.Require().NoError(err)
+		s.T().Cleanup(provider.Close)
+		s.Equal("hub", provider.GetDefaultTarget())
+	})
+}
+
+func (s *ProviderCapiTestSuite) TestGetTargetParameterName() {
+	s.Equal("cluster", s.provider.GetTargetParameterName(), "Expected cluster as target parameter name")
+}
+
+func TestProviderCapi(t *testing.T) {
+	suite.Run(t, new(ProviderCapiTestSuite))
+}
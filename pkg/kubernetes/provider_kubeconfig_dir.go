@@ -0,0 +1,256 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes/watcher"
+)
+
+// KubeConfigDirTargetParameterName is the parameter name used to specify
+// the cluster when using the kubeconfig-dir cluster provider strategy.
+const KubeConfigDirTargetParameterName = "cluster"
+
+// KubeConfigDirProviderConfig is the configuration of the kubeconfig-dir cluster provider
+// ([cluster_provider_configs.kubeconfig-dir]).
+type KubeConfigDirProviderConfig struct {
+	// Directory containing the kubeconfig files, defaults to the kubeconfig setting
+	Directory string `toml:"directory,omitempty"`
+	// DefaultTarget is the cluster used when none is specified, defaults to the first one in alphabetical order
+	DefaultTarget string `toml:"default_target,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*KubeConfigDirProviderConfig)(nil)
+
+func (c *KubeConfigDirProviderConfig) Validate() error {
+	return nil
+}
+
+// kubeConfigDirClusterProvider implements Provider for managing multiple Kubernetes clusters
+// from a directory of kubeconfig files, each file (current context) being a cluster target named after the file
+// (without extension). Hidden files and files that aren't valid kubeconfigs are ignored.
+type kubeConfigDirClusterProvider struct {
+	config              api.BaseConfig
+	directory           string
+	configuredDefault   string
+	defaultTarget       string
+	mu                  sync.Mutex
+	files               map[string]string
+	managers            map[string]*Manager
+	directoryWatcher    *watcher.Kubeconfig
+	clusterStateWatcher *watcher.ClusterState
+}
+
+var _ Provider = &kubeConfigDirClusterProvider{}
+
+func init() {
+	RegisterProvider(api.ClusterProviderKubeConfigDir, newKubeConfigDirClusterProvider)
+	config.RegisterProviderConfig(api.ClusterProviderKubeConfigDir, kubeConfigDirProviderConfigParser)
+}
+
+func kubeConfigDirProviderConfigParser(_ context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg KubeConfigDirProviderConfig
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// newKubeConfigDirClusterProvider creates a provider that manages multiple clusters via a directory of kubeconfig files.
+func newKubeConfigDirClusterProvider(cfg api.BaseConfig) (Provider, error) {
+	ret := &kubeConfigDirClusterProvider{config: cfg, directory: cfg.GetKubeConfigPath()}
+	if providerConfig, ok := cfg.GetProviderConfig(api.ClusterProviderKubeConfigDir); ok {
+		if dirConfig, ok := providerConfig.(*KubeConfigDirProviderConfig); ok {
+			if dirConfig.Directory != "" {
+				ret.directory = dirConfig.Directory
+			}
+			ret.configuredDefault = dirConfig.DefaultTarget
+		}
+	}
+	if ret.directory == "" {
+		return nil, errors.New("kubeconfig-dir ClusterProviderStrategy requires a directory, provide it with --kubeconfig or cluster_provider_configs.kubeconfig-dir.directory")
+	}
+	if info, err := os.Stat(ret.directory); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("kubeconfig-dir ClusterProviderStrategy requires a directory, %s is not a directory", ret.directory)
+	}
+	if err := ret.reset(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (p *kubeConfigDirClusterProvider) reset() error {
+	files, err := kubeconfigDirFiles(p.directory)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no valid kubeconfig files found in %s", p.directory)
+	}
+	defaultTarget := p.configuredDefault
+	if files[defaultTarget] == "" {
+		targets := make([]string, 0, len(files))
+		for target := range files {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		defaultTarget = targets[0]
+	}
+	m, err := newKubeconfigFileManager(p.config, files[defaultTarget])
+	if err != nil {
+		return fmt.Errorf("failed to create manager for %s: %w", defaultTarget, err)
+	}
+
+	if p.configuredDefault != "" && p.configuredDefault != defaultTarget {
+		klog.Warningf("Default cluster %s not found in %s, using %s", p.configuredDefault, p.directory, defaultTarget)
+	}
+
+	p.mu.Lock()
+	p.files = files
+	previous := p.managers
+	p.managers = map[string]*Manager{defaultTarget: m}
+	p.defaultTarget = defaultTarget
+	p.mu.Unlock()
+	for _, previousManager := range previous {
+		previousManager.Close()
+	}
+
+	p.Close()
+	p.directoryWatcher = watcher.NewKubeconfigPaths(p.directory)
+	p.clusterStateWatcher = watcher.NewClusterState(m.kubernetes.DiscoveryClient())
+	return nil
+}
+
+// kubeconfigDirFiles returns the kubeconfig files in the directory keyed by their target name
+func kubeconfigDirFiles(directory string) (map[string]string, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig directory: %w", err)
+	}
+	files := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// Skip hidden files (e.g. the ..data symlinks of mounted ConfigMaps and Secrets) and directories
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(directory, entry.Name())
+		if info, statErr := os.Stat(path); statErr != nil || info.IsDir() {
+			continue
+		}
+		if kubeconfig, loadErr := clientcmd.LoadFromFile(path); loadErr != nil || kubeconfig.Contexts[kubeconfig.CurrentContext] == nil {
+			klog.Warningf("Skipping invalid kubeconfig file %s (no current context): %v", path, loadErr)
+			continue
+		}
+		target := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if _, exists := files[target]; exists {
+			klog.Warningf("Skipping kubeconfig file %s, another file already provides the %s cluster", path, target)
+			continue
+		}
+		files[target] = path
+	}
+	return files, nil
+}
+
+// newKubeconfigFileManager creates a Manager for the current context of the kubeconfig file
+func newKubeconfigFileManager(config api.BaseConfig, path string) (*Manager, error) {
+	clientCmdConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: path},
+		&clientcmd.ConfigOverrides{})
+	restConfig, err := clientCmdConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes rest config from kubeconfig: %w", err)
+	}
+	return NewManager(config, restConfig, clientCmdConfig)
+}
+
+// managerForTarget returns the Manager for the target, or for the default target if empty
+func (p *kubeConfigDirClusterProvider) managerForTarget(target string) (*Manager, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if target == "" {
+		target = p.defaultTarget
+	}
+	if m, ok := p.managers[target]; ok {
+		return m, nil
+	}
+	path, ok := p.files[target]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s not found in %s", target, p.directory)
+	}
+	m, err := newKubeconfigFileManager(p.config, path)
+	if err != nil {
+		return nil, err
+	}
+	p.managers[target] = m
+	return m, nil
+}
+
+func (p *kubeConfigDirClusterProvider) IsOpenShift(ctx context.Context) bool {
+	m, err := p.managerForTarget("")
+	if err != nil {
+		return false
+	}
+	return m.IsOpenShift(ctx)
+}
+
+func (p *kubeConfigDirClusterProvider) GetTargets(_ context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	targets := make([]string, 0, len(p.files))
+	for target := range p.files {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+func (p *kubeConfigDirClusterProvider) GetTargetParameterName() string {
+	return KubeConfigDirTargetParameterName
+}
+
+func (p *kubeConfigDirClusterProvider) GetDerivedKubernetes(ctx context.Context, target string) (*Kubernetes, error) {
+	m, err := p.managerForTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	return m.Derived(ctx)
+}
+
+func (p *kubeConfigDirClusterProvider) GetDefaultTarget() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.defaultTarget
+}
+
+func (p *kubeConfigDirClusterProvider) WatchTargets(reload McpReload) {
+	reloadWithReset := func() error {
+		if err := p.reset(); err != nil {
+			return err
+		}
+		p.WatchTargets(reload)
+		return reload()
+	}
+	p.directoryWatcher.Watch(reloadWithReset)
+	p.clusterStateWatcher.Watch(reload)
+}
+
+func (p *kubeConfigDirClusterProvider) Close() {
+	for _, w := range []watcher.Watcher{p.directoryWatcher, p.clusterStateWatcher} {
+		if !reflect.ValueOf(w).IsNil() {
+			w.Close()
+		}
+	}
+}
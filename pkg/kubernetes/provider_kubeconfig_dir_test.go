@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type ProviderKubeconfigDirTestSuite struct {
+	BaseProviderSuite
+	mockServer *test.MockServer
+	directory  string
+	provider   Provider
+}
+
+func (s *ProviderKubeconfigDirTestSuite) SetupTest() {
+	s.BaseProviderSuite.SetupTest()
+	s.T().Setenv("KUBECONFIG_DEBOUNCE_WINDOW_MS", "50")
+	s.mockServer = test.NewMockServer()
+	s.directory = s.T().TempDir()
+	for _, name := range []string{"prod.yaml", "staging.kubeconfig", "dev"} {
+		s.Require().NoError(clientcmd.WriteToFile(*s.mockServer.Kubeconfig(), filepath.Join(s.directory, name)))
+	}
+	// Ignored files
+	s.Require().NoError(os.WriteFile(filepath.Join(s.directory, ".hidden"), []byte("hidden"), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.directory, "invalid.yaml"), []byte("not: [a kubeconfig"), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.directory, "README.md"), []byte("# Kubeconfigs"), 0600))
+	s.Require().NoError(os.Mkdir(filepath.Join(s.directory, "nested"), 0700))
+	provider, err := NewProvider(&config.StaticConfig{KubeConfig: s.directory, ClusterProviderStrategy: "kubeconfig-dir"})
+	s.Require().NoError(err, "Expected no error creating provider with kubeconfig directory")
+	s.provider = provider
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TearDownTest() {
+	s.BaseProviderSuite.TearDownTest()
+	s.provider.Close()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestType() {
+	s.IsType(&kubeConfigDirClusterProvider{}, s.provider)
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestGetTargets() {
+	s.Run("GetTargets returns a target for each valid kubeconfig file", func() {
+		targets, err := s.provider.GetTargets(s.T().Context())
+		s.Require().NoError(err, "Expected no error from GetTargets")
+		s.Equal([]string{"dev", "prod", "staging"}, targets)
+	})
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestGetDerivedKubernetes() {
+	s.Run("GetDerivedKubernetes returns Kubernetes for valid cluster", func() {
+		k8s, err := s.provider.GetDerivedKubernetes(s.T().Context(), "staging")
+		s.Require().NoError(err, "Expected no error from GetDerivedKubernetes with valid cluster")
+		s.NotNil(k8s, "Expected Kubernetes from GetDerivedKubernetes with valid cluster")
+	})
+	s.Run("GetDerivedKubernetes returns Kubernetes for empty cluster (default)", func() {
+		k8s, err := s.provider.GetDerivedKubernetes(s.T().Context(), "")
+		s.Require().NoError(err, "Expected no error from GetDerivedKubernetes with empty cluster")
+		s.NotNil(k8s, "Expected Kubernetes from GetDerivedKubernetes with empty cluster")
+	})
+	s.Run("GetDerivedKubernetes returns error for invalid cluster", func() {
+		k8s, err := s.provider.GetDerivedKubernetes(s.T().Context(), "invalid")
+		s.Require().Error(err, "Expected error from GetDerivedKubernetes with invalid cluster")
+		s.ErrorContains(err, "cluster invalid not found")
+		s.Nil(k8s, "Expected no Kubernetes from GetDerivedKubernetes with invalid cluster")
+	})
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestGetDefaultTarget() {
+	s.Run("GetDefaultTarget returns the first cluster in alphabetical order", func() {
+		s.Equal("dev", s.provider.GetDefaultTarget())
+	})
+	s.Run("GetDefaultTarget returns the configured default cluster", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			cluster_provider_strategy = "kubeconfig-dir"
+			[cluster_provider_configs.kubeconfig-dir]
+			directory = "` + filepath.ToSlash(s.directory) + `"
+			default_target = "prod"
+		`)))
+		provider, err := NewProvider(cfg)
+		s.Require().NoError(err)
+		s.T().Cleanup(provider.Close)
+		s.Equal("prod", provider.GetDefaultTarget())
+	})
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestGetTargetParameterName() {
+	s.Equal("cluster", s.provider.GetTargetParameterName(), "Expected cluster as target parameter name")
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestWatchTargets() {
+	s.Run("adding a kubeconfig file reloads the targets", func() {
+		reloaded := make(chan struct{}, 10)
+		s.provider.WatchTargets(func() error {
+			reloaded <- struct{}{}
+			return nil
+		})
+		s.Require().NoError(clientcmd.WriteToFile(*s.mockServer.Kubeconfig(), filepath.Join(s.directory, "qa.yaml")))
+		select {
+		case <-reloaded:
+		case <-time.After(5 * time.Second):
+			s.Fail("timeout waiting for reload")
+		}
+		targets, err := s.provider.GetTargets(s.T().Context())
+		s.Require().NoError(err)
+		s.Contains(targets, "qa")
+	})
+}
+
+func (s *ProviderKubeconfigDirTestSuite) TestNewProviderErrors() {
+	s.Run("fails if the kubeconfig is not a directory", func() {
+		file := test.KubeconfigFile(s.T(), s.mockServer.Kubeconfig())
+		_, err := NewProvider(&config.StaticConfig{KubeConfig: file, ClusterProviderStrategy: "kubeconfig-dir"})
+		s.ErrorContains(err, "is not a directory")
+	})
+	s.Run("fails if the directory has no kubeconfig files", func() {
+		_, err := NewProvider(&config.StaticConfig{KubeConfig: s.T().TempDir(), ClusterProviderStrategy: "kubeconfig-dir"})
+		s.ErrorContains(err, "no valid kubeconfig files found")
+	})
+	s.Run("fails if no directory is provided", func() {
+		_, err := NewProvider(&config.StaticConfig{ClusterProviderStrategy: "kubeconfig-dir"})
+		s.ErrorContains(err, "requires a directory")
+	})
+}
+
+func TestProviderKubeconfigDir(t *testing.T) {
+	suite.Run(t, new(ProviderKubeconfigDirTestSuite))
+}
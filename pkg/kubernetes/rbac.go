@@ -0,0 +1,298 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	authv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	authv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+)
+
+// RBACAccessRequest describes the action to check (a verb on a resource or subresource, optionally a named object)
+type RBACAccessRequest struct {
+	Verb        string `json:"verb"`
+	Group       string `json:"group,omitempty"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+// RBACCanIResult is the result of a SelfSubjectAccessReview
+type RBACCanIResult struct {
+	RBACAccessRequest
+	// User is the impersonated ServiceAccount user, empty for the current identity
+	User    string `json:"user,omitempty"`
+	Allowed bool   `json:"allowed"`
+	Denied  bool   `json:"denied,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// RBACSubjectGrant is a subject granted a permission through a binding
+type RBACSubjectGrant struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Binding is the RoleBinding or ClusterRoleBinding granting the permission
+	Binding string `json:"binding"`
+	// BindingNamespace is the namespace of the RoleBinding, empty for ClusterRoleBindings
+	BindingNamespace string `json:"bindingNamespace,omitempty"`
+	// Role is the Role or ClusterRole referenced by the binding
+	Role string `json:"role"`
+}
+
+// RBACWhoCanResult lists the subjects with a permission
+type RBACWhoCanResult struct {
+	RBACAccessRequest
+	Subjects []RBACSubjectGrant `json:"subjects"`
+	Notes    []string           `json:"notes,omitempty"`
+}
+
+// RBACSubjectBindingPermissions are the rules granted to a subject through a binding
+type RBACSubjectBindingPermissions struct {
+	Binding string `json:"binding"`
+	// Namespace is the scope of the permissions, empty for cluster-wide permissions
+	Namespace string              `json:"namespace,omitempty"`
+	Role      string              `json:"role"`
+	Rules     []rbacv1.PolicyRule `json:"rules"`
+	// Via is the Group the subject is bound through, empty if bound directly
+	Via string `json:"via,omitempty"`
+}
+
+// RBACSubjectPermissionsResult summarizes the RBAC permissions of a subject
+type RBACSubjectPermissionsResult struct {
+	Kind        string                          `json:"kind"`
+	Name        string                          `json:"name"`
+	Namespace   string                          `json:"namespace,omitempty"`
+	Permissions []RBACSubjectBindingPermissions `json:"permissions"`
+	Notes       []string                        `json:"notes,omitempty"`
+}
+
+// RBACServiceAccountUser returns the username of a ServiceAccount
+func RBACServiceAccountUser(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// rbacServiceAccountGroups returns the groups a ServiceAccount belongs to
+func rbacServiceAccountGroups(namespace string) []string {
+	return []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace, "system:authenticated"}
+}
+
+// RBACCanI checks whether the current identity (or the impersonated ServiceAccount) can perform the requested action
+// with a SelfSubjectAccessReview
+func (c *Core) RBACCanI(ctx context.Context, request RBACAccessRequest, serviceAccountNamespace, serviceAccount string) (*RBACCanIResult, error) {
+	authClient := c.AuthorizationV1()
+	result := &RBACCanIResult{RBACAccessRequest: request}
+	if serviceAccount != "" {
+		result.User = RBACServiceAccountUser(serviceAccountNamespace, serviceAccount)
+		restConfig := rest.CopyConfig(c.RESTConfig())
+		restConfig.Impersonate = rest.ImpersonationConfig{UserName: result.User, Groups: rbacServiceAccountGroups(serviceAccountNamespace)}
+		impersonated, err := authv1client.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create impersonated client: %w", err)
+		}
+		authClient = impersonated
+	}
+	review, err := authClient.SelfSubjectAccessReviews().Create(ctx, &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   request.Namespace,
+				Verb:        request.Verb,
+				Group:       request.Group,
+				Resource:    request.Resource,
+				Subresource: request.Subresource,
+				Name:        request.Name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result.Allowed = review.Status.Allowed
+	result.Denied = review.Status.Denied
+	result.Reason = review.Status.Reason
+	return result, nil
+}
+
+// RBACWhoCan evaluates the Roles, ClusterRoles, and their bindings to list the subjects that can perform the requested action.
+// Only ClusterRoleBindings are evaluated for cluster-scoped requests (empty namespace).
+func (c *Core) RBACWhoCan(ctx context.Context, request RBACAccessRequest) (*RBACWhoCanResult, error) {
+	rbac, err := c.rbacSnapshot(ctx, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	result := &RBACWhoCanResult{
+		RBACAccessRequest: request,
+		Subjects:          []RBACSubjectGrant{},
+		Notes: []string{
+			"only RBAC is evaluated, other authorizers (e.g. Node, webhooks) and the system:masters group may grant additional access",
+		},
+	}
+	seen := sets.New[string]()
+	add := func(binding, role string, subjects []rbacv1.Subject, bindingNamespace string) {
+		for _, subject := range subjects {
+			grant := RBACSubjectGrant{
+				Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace, Binding: binding, BindingNamespace: bindingNamespace, Role: role,
+			}
+			if subject.Kind == rbacv1.ServiceAccountKind && grant.Namespace == "" {
+				grant.Namespace = bindingNamespace
+			}
+			key := strings.Join([]string{grant.Kind, grant.Namespace, grant.Name, grant.BindingNamespace, grant.Binding}, "/")
+			if !seen.Has(key) {
+				seen.Insert(key)
+				result.Subjects = append(result.Subjects, grant)
+			}
+		}
+	}
+	for _, binding := range rbac.clusterRoleBindings {
+		if rulesAllow(rbac.rules(binding.RoleRef, ""), request) {
+			add("ClusterRoleBinding/"+binding.Name, "ClusterRole/"+binding.RoleRef.Name, binding.Subjects, "")
+		}
+	}
+	for _, binding := range rbac.roleBindings {
+		if request.Namespace != "" && rulesAllow(rbac.rules(binding.RoleRef, binding.Namespace), request) {
+			add("RoleBinding/"+binding.Name, binding.RoleRef.Kind+"/"+binding.RoleRef.Name, binding.Subjects, binding.Namespace)
+		}
+	}
+	sort.SliceStable(result.Subjects, func(i, j int) bool {
+		a, b := result.Subjects[i], result.Subjects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// RBACSubjectPermissions summarizes the rules granted to a User, Group, or ServiceAccount by the RoleBindings and ClusterRoleBindings.
+// RoleBindings are only evaluated in the provided namespace (all namespaces if empty).
+func (c *Core) RBACSubjectPermissions(ctx context.Context, kind, name, subjectNamespace, namespace string) (*RBACSubjectPermissionsResult, error) {
+	if kind == rbacv1.ServiceAccountKind && subjectNamespace == "" {
+		return nil, fmt.Errorf("namespace of the ServiceAccount is required")
+	}
+	rbac, err := c.rbacSnapshot(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	result := &RBACSubjectPermissionsResult{Kind: kind, Name: name, Namespace: subjectNamespace, Permissions: []RBACSubjectBindingPermissions{}}
+	var groups []string
+	switch kind {
+	case rbacv1.ServiceAccountKind:
+		groups = rbacServiceAccountGroups(subjectNamespace)
+	case rbacv1.UserKind:
+		groups = []string{"system:authenticated"}
+		result.Notes = append(result.Notes, "the groups of a User are provided by the authenticator, only the bindings to the User and to system:authenticated are evaluated")
+	}
+	// matches returns the Group the subject is bound through (empty if bound directly) and whether the binding applies to the subject
+	matches := func(subjects []rbacv1.Subject, bindingNamespace string) (string, bool) {
+		via, matched := "", false
+		for _, subject := range subjects {
+			switch {
+			case subject.Kind == kind && subject.Name == name && kind != rbacv1.ServiceAccountKind:
+				return "", true
+			case subject.Kind == kind && subject.Name == name && (subject.Namespace == subjectNamespace || subject.Namespace == "" && bindingNamespace == subjectNamespace):
+				return "", true
+			case subject.Kind == rbacv1.GroupKind && slices.Contains(groups, subject.Name) && !matched:
+				via, matched = subject.Name, true
+			}
+		}
+		return via, matched
+	}
+	for _, binding := range rbac.clusterRoleBindings {
+		if via, ok := matches(binding.Subjects, ""); ok {
+			result.Permissions = append(result.Permissions, RBACSubjectBindingPermissions{
+				Binding: "ClusterRoleBinding/" + binding.Name, Role: "ClusterRole/" + binding.RoleRef.Name, Rules: rbac.rules(binding.RoleRef, ""), Via: via,
+			})
+		}
+	}
+	for _, binding := range rbac.roleBindings {
+		if via, ok := matches(binding.Subjects, binding.Namespace); ok {
+			result.Permissions = append(result.Permissions, RBACSubjectBindingPermissions{
+				Binding: "RoleBinding/" + binding.Name, Namespace: binding.Namespace, Role: binding.RoleRef.Kind + "/" + binding.RoleRef.Name,
+				Rules: rbac.rules(binding.RoleRef, binding.Namespace), Via: via,
+			})
+		}
+	}
+	return result, nil
+}
+
+// rbacSnapshot holds the RBAC objects used to evaluate the permissions
+type rbacSnapshot struct {
+	clusterRoles        map[string]rbacv1.ClusterRole
+	roles               map[string]rbacv1.Role
+	clusterRoleBindings []rbacv1.ClusterRoleBinding
+	roleBindings        []rbacv1.RoleBinding
+}
+
+// rbacSnapshot lists the ClusterRoles and ClusterRoleBindings, and the Roles and RoleBindings in the namespace (all namespaces if empty)
+func (c *Core) rbacSnapshot(ctx context.Context, namespace string) (*rbacSnapshot, error) {
+	snapshot := &rbacSnapshot{clusterRoles: map[string]rbacv1.ClusterRole{}, roles: map[string]rbacv1.Role{}}
+	clusterRoles, err := c.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	for _, role := range clusterRoles.Items {
+		snapshot.clusterRoles[role.Name] = role
+	}
+	clusterRoleBindings, err := c.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	snapshot.clusterRoleBindings = clusterRoleBindings.Items
+	roles, err := c.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	for _, role := range roles.Items {
+		snapshot.roles[role.Namespace+"/"+role.Name] = role
+	}
+	roleBindings, err := c.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	snapshot.roleBindings = roleBindings.Items
+	return snapshot, nil
+}
+
+// rules returns the rules of the Role (in the binding namespace) or ClusterRole referenced by a binding
+func (s *rbacSnapshot) rules(roleRef rbacv1.RoleRef, namespace string) []rbacv1.PolicyRule {
+	if roleRef.Kind == "ClusterRole" {
+		return s.clusterRoles[roleRef.Name].Rules
+	}
+	return s.roles[namespace+"/"+roleRef.Name].Rules
+}
+
+// rulesAllow returns true if any rule allows the request
+func rulesAllow(rules []rbacv1.PolicyRule, request RBACAccessRequest) bool {
+	resource := request.Resource
+	if request.Subresource != "" {
+		resource += "/" + request.Subresource
+	}
+	for _, rule := range rules {
+		if !ruleContains(rule.Verbs, request.Verb) || !ruleContains(rule.APIGroups, request.Group) {
+			continue
+		}
+		if !ruleContains(rule.Resources, resource) && !(request.Subresource != "" && slices.Contains(rule.Resources, "*/"+request.Subresource)) {
+			continue
+		}
+		if len(rule.ResourceNames) > 0 && !slices.Contains(rule.ResourceNames, request.Name) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func ruleContains(values []string, value string) bool {
+	return slices.Contains(values, rbacv1.ResourceAll) || slices.Contains(values, value)
+}
@@ -0,0 +1,390 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// describeMaxOwnerDepth is the maximum number of owner references followed when describing a resource
+	describeMaxOwnerDepth = 10
+	// describeMaxEvents is the maximum number of (most recent) events included when describing a resource
+	describeMaxEvents = 20
+)
+
+// describeChildKinds maps the kinds of the workload controllers to the kinds of the objects they own
+var describeChildKinds = map[string]schema.GroupVersionKind{
+	"Deployment":  {Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	"ReplicaSet":  {Version: "v1", Kind: "Pod"},
+	"StatefulSet": {Version: "v1", Kind: "Pod"},
+	"DaemonSet":   {Version: "v1", Kind: "Pod"},
+	"Job":         {Version: "v1", Kind: "Pod"},
+	"CronJob":     {Group: "batch", Version: "v1", Kind: "Job"},
+}
+
+// DescribedObject is a compact reference to an object related to a described resource.
+type DescribedObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Status is a short human-readable summary of the object status (e.g. Pod phase and readiness)
+	Status string `json:"status,omitempty"`
+}
+
+// DescribeResult aggregates a resource with its related objects and recent events,
+// similar to the information provided by `kubectl describe`.
+type DescribeResult struct {
+	Resource DescribedObject   `json:"resource"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	// Owners is the owner reference chain of the resource, starting with its direct owner
+	Owners []DescribedObject `json:"owners,omitempty"`
+	// Children are the objects controlled by the resource (recursively, e.g. ReplicaSets and Pods of a Deployment)
+	Children []DescribedObject `json:"children,omitempty"`
+	// Pods are the Pods selected by the resource label selector (e.g. Service)
+	Pods                     []DescribedObject `json:"pods,omitempty"`
+	Endpoints                []string          `json:"endpoints,omitempty"`
+	PersistentVolumeClaims   []DescribedObject `json:"persistentVolumeClaims,omitempty"`
+	HorizontalPodAutoscalers []DescribedObject `json:"horizontalPodAutoscalers,omitempty"`
+	Events                   []string          `json:"events,omitempty"`
+}
+
+// ResourcesDescribe gathers a resource along with its owner chain, the objects it controls or selects,
+// the PersistentVolumeClaims mounted by its Pods, the HorizontalPodAutoscalers targeting it, and its recent events.
+// Related objects that can't be retrieved (e.g. missing permissions or unavailable APIs) are skipped.
+func (c *Core) ResourcesDescribe(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*DescribeResult, error) {
+	obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	namespace = obj.GetNamespace()
+	ret := &DescribeResult{Resource: describedObject(obj), Labels: obj.GetLabels()}
+	// involved objects (by kind and name, or UID) whose events are included in the result
+	involved := map[string]bool{describeKey(obj.GetKind(), obj.GetName()): true}
+
+	// Owner chain
+	owner := obj
+	for i := 0; i < describeMaxOwnerDepth; i++ {
+		ref := metav1.GetControllerOfNoCopy(owner)
+		if ref == nil {
+			if refs := owner.GetOwnerReferences(); len(refs) > 0 {
+				ref = &refs[0]
+			} else {
+				break
+			}
+		}
+		gv, _ := schema.ParseGroupVersion(ref.APIVersion)
+		next, err := c.ResourcesGet(ctx, &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: ref.Kind}, namespace, ref.Name)
+		if err != nil {
+			ret.Owners = append(ret.Owners, DescribedObject{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: namespace, Name: ref.Name, Status: "unavailable"})
+			break
+		}
+		ret.Owners = append(ret.Owners, describedObject(next))
+		owner = next
+	}
+
+	// Controlled objects
+	var pods []unstructured.Unstructured
+	if obj.GetKind() == "Pod" {
+		pods = append(pods, *obj)
+	}
+	parents := []*unstructured.Unstructured{obj}
+	for len(parents) > 0 {
+		parent := parents[0]
+		parents = parents[1:]
+		childGvk, ok := describeChildKinds[parent.GetKind()]
+		if !ok {
+			continue
+		}
+		children, err := c.describeList(ctx, &childGvk, namespace, describeSelector(parent))
+		if err != nil {
+			continue
+		}
+		for i := range children {
+			if controller := metav1.GetControllerOfNoCopy(&children[i]); controller == nil || controller.UID != parent.GetUID() {
+				continue
+			}
+			ret.Children = append(ret.Children, describedObject(&children[i]))
+			involved[describeKey(children[i].GetKind(), children[i].GetName())] = true
+			if children[i].GetKind() == "Pod" {
+				pods = append(pods, children[i])
+			}
+			parents = append(parents, &children[i])
+		}
+	}
+
+	// Selected Pods and Endpoints (Services)
+	if obj.GetKind() == "Service" && obj.GetAPIVersion() == "v1" {
+		if selector, found, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector"); found && len(selector) > 0 {
+			if selected, err := c.describeList(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, namespace, labels.SelectorFromSet(selector).String()); err == nil {
+				for i := range selected {
+					ret.Pods = append(ret.Pods, describedObject(&selected[i]))
+					involved[describeKey(selected[i].GetKind(), selected[i].GetName())] = true
+					if uid := selected[i].GetUID(); uid != "" {
+						involved[string(uid)] = true
+					}
+					pods = append(pods, selected[i])
+				}
+			}
+		}
+		if endpoints, err := c.ResourcesGet(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Endpoints"}, namespace, name); err == nil {
+			ret.Endpoints = describeEndpoints(endpoints)
+		}
+	}
+
+	// PersistentVolumeClaims mounted by the Pods
+	claims := map[string]bool{}
+	for i := range pods {
+		volumes, _, _ := unstructured.NestedSlice(pods[i].Object, "spec", "volumes")
+		for _, volume := range volumes {
+			if claimName, found, _ := unstructured.NestedString(volume.(map[string]any), "persistentVolumeClaim", "claimName"); found {
+				claims[claimName] = true
+			}
+		}
+	}
+	if obj.GetKind() == "PersistentVolumeClaim" {
+		delete(claims, name)
+	}
+	for _, claimName := range sortedKeys(claims) {
+		pvc, err := c.ResourcesGet(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"}, namespace, claimName)
+		if err != nil {
+			ret.PersistentVolumeClaims = append(ret.PersistentVolumeClaims, DescribedObject{APIVersion: "v1", Kind: "PersistentVolumeClaim", Namespace: namespace, Name: claimName, Status: "unavailable"})
+			continue
+		}
+		ret.PersistentVolumeClaims = append(ret.PersistentVolumeClaims, describedObject(pvc))
+		involved[describeKey(pvc.GetKind(), pvc.GetName())] = true
+	}
+
+	// HorizontalPodAutoscalers targeting the resource or any of its owners
+	targets := map[string]bool{describeKey(obj.GetKind(), obj.GetName()): true}
+	for _, o := range ret.Owners {
+		targets[describeKey(o.Kind, o.Name)] = true
+	}
+	if hpas, err := c.describeList(ctx, &schema.GroupVersionKind{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"}, namespace, ""); err == nil {
+		for i := range hpas {
+			kind, _, _ := unstructured.NestedString(hpas[i].Object, "spec", "scaleTargetRef", "kind")
+			targetName, _, _ := unstructured.NestedString(hpas[i].Object, "spec", "scaleTargetRef", "name")
+			if targets[describeKey(kind, targetName)] {
+				ret.HorizontalPodAutoscalers = append(ret.HorizontalPodAutoscalers, describedObject(&hpas[i]))
+				involved[describeKey(hpas[i].GetKind(), hpas[i].GetName())] = true
+			}
+		}
+	}
+
+	// Recent events of the resource and its related objects
+	if namespace != "" {
+		ret.Events = c.describeEvents(ctx, namespace, involved)
+	}
+	return ret, nil
+}
+
+func (c *Core) describeList(ctx context.Context, gvk *schema.GroupVersionKind, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	list, err := c.ResourcesList(ctx, gvk, namespace, api.ListOptions{ListOptions: metav1.ListOptions{LabelSelector: labelSelector}})
+	if err != nil {
+		return nil, err
+	}
+	items := list.(*unstructured.UnstructuredList).Items
+	for i := range items {
+		// Lists don't include the type information of their items
+		items[i].SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+	return items, nil
+}
+
+func (c *Core) describeEvents(ctx context.Context, namespace string, involved map[string]bool) []string {
+	list, err := c.ResourcesList(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Event"}, namespace, api.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	var events []*v1.Event
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		event := &v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
+			return nil
+		}
+		if involved[string(event.InvolvedObject.UID)] || involved[describeKey(event.InvolvedObject.Kind, event.InvolvedObject.Name)] {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTimestamp(events[i]).Before(eventTimestamp(events[j]))
+	})
+	if len(events) > describeMaxEvents {
+		events = events[len(events)-describeMaxEvents:]
+	}
+	ret := make([]string, 0, len(events))
+	for _, event := range events {
+		ret = append(ret, fmt.Sprintf("%s %s %s %s/%s: %s", eventTimestamp(event), event.Type, event.Reason,
+			event.InvolvedObject.Kind, event.InvolvedObject.Name, strings.TrimSpace(event.Message)))
+	}
+	return ret
+}
+
+func describeKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// describeSelector returns the label selector of a workload controller (empty if it has none)
+func describeSelector(obj *unstructured.Unstructured) string {
+	selector, found, _ := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if !found {
+		return ""
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, labelSelector); err != nil {
+		return ""
+	}
+	s, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return ""
+	}
+	return s.String()
+}
+
+func describeEndpoints(obj *unstructured.Unstructured) []string {
+	endpoints := &v1.Endpoints{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, endpoints); err != nil {
+		return nil
+	}
+	var ret []string
+	for _, subset := range endpoints.Subsets {
+		var ports []string
+		for _, port := range subset.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+		}
+		for _, address := range subset.Addresses {
+			ret = append(ret, describeAddress(address, ports, "ready"))
+		}
+		for _, address := range subset.NotReadyAddresses {
+			ret = append(ret, describeAddress(address, ports, "not ready"))
+		}
+	}
+	return ret
+}
+
+func describeAddress(address v1.EndpointAddress, ports []string, state string) string {
+	ret := address.IP
+	if len(ports) > 0 {
+		ret += " " + strings.Join(ports, ",")
+	}
+	if address.TargetRef != nil {
+		ret += " (" + address.TargetRef.Kind + "/" + address.TargetRef.Name + ")"
+	}
+	return ret + " " + state
+}
+
+func describedObject(obj *unstructured.Unstructured) DescribedObject {
+	return DescribedObject{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Status:     describeStatus(obj),
+	}
+}
+
+// describeStatus summarizes the status of the most common kinds
+func describeStatus(obj *unstructured.Unstructured) string {
+	switch obj.GetKind() {
+	case "Pod":
+		pod := &v1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+			return ""
+		}
+		ready, restarts := 0, int32(0)
+		reason := string(pod.Status.Phase)
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+			restarts += status.RestartCount
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				reason = status.State.Waiting.Reason
+			} else if status.State.Terminated != nil && status.State.Terminated.Reason != "" {
+				reason = status.State.Terminated.Reason
+			}
+		}
+		if pod.DeletionTimestamp != nil {
+			reason = "Terminating"
+		}
+		ret := fmt.Sprintf("%s, ready %d/%d, restarts %d", reason, ready, len(pod.Spec.Containers), restarts)
+		if pod.Spec.NodeName != "" {
+			ret += ", node " + pod.Spec.NodeName
+		}
+		return ret
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+			return ""
+		}
+		return fmt.Sprintf("ready %d/%d, updated %d, available %d", deployment.Status.ReadyReplicas, ptrInt32OrDefault(deployment.Spec.Replicas, 1),
+			deployment.Status.UpdatedReplicas, deployment.Status.AvailableReplicas)
+	case "ReplicaSet", "StatefulSet":
+		replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		return fmt.Sprintf("ready %d/%d", ready, replicas)
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		return fmt.Sprintf("ready %d/%d", ready, desired)
+	case "Job":
+		job := &batchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, job); err != nil {
+			return ""
+		}
+		return fmt.Sprintf("active %d, succeeded %d, failed %d", job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+	case "PersistentVolumeClaim":
+		pvc := &v1.PersistentVolumeClaim{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pvc); err != nil {
+			return ""
+		}
+		ret := string(pvc.Status.Phase)
+		if capacity, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+			ret += ", " + capacity.String()
+		}
+		if pvc.Spec.StorageClassName != nil {
+			ret += ", storageClass " + *pvc.Spec.StorageClassName
+		}
+		return ret
+	case "HorizontalPodAutoscaler":
+		minReplicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "minReplicas")
+		if !found {
+			minReplicas = 1
+		}
+		maxReplicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "maxReplicas")
+		current, _, _ := unstructured.NestedInt64(obj.Object, "status", "currentReplicas")
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredReplicas")
+		return fmt.Sprintf("replicas %d (desired %d, min %d, max %d)", current, desired, minReplicas, maxReplicas)
+	}
+	// Fallback to the Ready condition if available
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		if c, ok := condition.(map[string]any); ok && c["type"] == "Ready" {
+			return fmt.Sprintf("Ready=%v", c["status"])
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
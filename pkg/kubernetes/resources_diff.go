@@ -0,0 +1,299 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// Status of a resource in a ResourcesDiff
+const (
+	DiffStatusIdentical         = "identical"
+	DiffStatusChanged           = "changed"
+	DiffStatusOnlyInSource      = "only-in-source"
+	DiffStatusOnlyInDestination = "only-in-destination"
+)
+
+// DiffIgnoredFields are the fields populated by the API server (or its controllers) that are ignored when comparing
+// the resources of two targets, in addition to the output.DefaultCompactPruneFields.
+var DiffIgnoredFields = append(slices.Clone(output.DefaultCompactPruneFields),
+	"status",
+	"metadata.creationTimestamp",
+	"metadata.deletionTimestamp",
+	"metadata.deletionGracePeriodSeconds",
+	`metadata.annotations.deployment\.kubernetes\.io/revision`,
+	"spec.clusterIP",
+	"spec.clusterIPs",
+	"spec.nodeName",
+)
+
+// ResourceFieldDiff is a field whose value differs between the source and the destination resource.
+// Source or Destination are omitted if the field is only set in the other resource.
+type ResourceFieldDiff struct {
+	Path        string `json:"path"`
+	Source      any    `json:"source,omitempty"`
+	Destination any    `json:"destination,omitempty"`
+}
+
+// ResourceDiff is the comparison of a resource between the source and the destination targets.
+type ResourceDiff struct {
+	APIVersion  string              `json:"apiVersion"`
+	Kind        string              `json:"kind"`
+	Namespace   string              `json:"namespace,omitempty"`
+	Name        string              `json:"name"`
+	Status      string              `json:"status"`
+	Differences []ResourceFieldDiff `json:"differences,omitempty"`
+}
+
+// ResourcesDiffSummary counts the compared resources by status.
+type ResourcesDiffSummary struct {
+	Identical         int `json:"identical"`
+	Changed           int `json:"changed"`
+	OnlyInSource      int `json:"onlyInSource"`
+	OnlyInDestination int `json:"onlyInDestination"`
+}
+
+// ResourcesDiff is the semantic diff of the resources of a kind (or of a single resource) between two targets.
+type ResourcesDiff struct {
+	Source      string               `json:"source"`
+	Destination string               `json:"destination"`
+	Namespace   string               `json:"namespace,omitempty"`
+	Summary     ResourcesDiffSummary `json:"summary"`
+	Resources   []ResourceDiff       `json:"resources"`
+}
+
+// ResourcesDiff compares the resource with the provided name, or all the resources of the kind in the namespace if the
+// name is empty, between this (source) cluster and the destination cluster.
+// The requests to each cluster are made with its own context (sourceCtx, destinationCtx).
+// Resources are normalized before the comparison by removing the DiffIgnoredFields.
+func (c *Core) ResourcesDiff(sourceCtx, destinationCtx context.Context, destination *Core, gvk *schema.GroupVersionKind, namespace, name string) (*ResourcesDiff, error) {
+	namespaced, err := c.isNamespaced(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if namespaced {
+		namespace = c.NamespaceOrDefault(namespace)
+	} else {
+		namespace = ""
+	}
+	sourceObjects, err := c.resourcesToDiff(sourceCtx, gvk, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	destinationObjects, err := destination.resourcesToDiff(destinationCtx, gvk, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+	return DiffResources(sourceObjects, destinationObjects, namespace), nil
+}
+
+// resourcesToDiff returns the normalized resource with the provided name (none if not found),
+// or all the resources of the kind in the namespace if the name is empty
+func (c *Core) resourcesToDiff(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	if name != "" {
+		obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *obj)
+	} else {
+		gvr, err := c.resourceFor(gvk)
+		if err != nil {
+			return nil, err
+		}
+		list, err := c.DynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		objects = list.Items
+	}
+	for i := range objects {
+		output.Compact(&objects[i], DiffIgnoredFields)
+	}
+	return objects, nil
+}
+
+// DiffResources compares the source and destination resources matched by kind, namespace, and name.
+// The resources are expected to be normalized already.
+func DiffResources(source, destination []unstructured.Unstructured, namespace string) *ResourcesDiff {
+	key := func(obj *unstructured.Unstructured) string {
+		return obj.GetKind() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	}
+	sources := make(map[string]*unstructured.Unstructured, len(source))
+	destinations := make(map[string]*unstructured.Unstructured, len(destination))
+	var keys []string
+	for i := range source {
+		sources[key(&source[i])] = &source[i]
+		keys = append(keys, key(&source[i]))
+	}
+	for i := range destination {
+		if _, ok := sources[key(&destination[i])]; !ok {
+			keys = append(keys, key(&destination[i]))
+		}
+		destinations[key(&destination[i])] = &destination[i]
+	}
+	sort.Strings(keys)
+
+	diff := &ResourcesDiff{Namespace: namespace, Resources: make([]ResourceDiff, 0, len(keys))}
+	for _, k := range keys {
+		s, d := sources[k], destinations[k]
+		obj := s
+		if obj == nil {
+			obj = d
+		}
+		resource := ResourceDiff{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		switch {
+		case d == nil:
+			resource.Status = DiffStatusOnlyInSource
+			diff.Summary.OnlyInSource++
+		case s == nil:
+			resource.Status = DiffStatusOnlyInDestination
+			diff.Summary.OnlyInDestination++
+		default:
+			diffValues("", s.Object, d.Object, &resource.Differences)
+			if resource.APIVersion == "v1" && resource.Kind == "Secret" {
+				redactSecretDifferences(resource.Differences)
+			}
+			if len(resource.Differences) == 0 {
+				resource.Status = DiffStatusIdentical
+				diff.Summary.Identical++
+			} else {
+				resource.Status = DiffStatusChanged
+				diff.Summary.Changed++
+			}
+		}
+		diff.Resources = append(diff.Resources, resource)
+	}
+	return diff
+}
+
+// diffValues appends the differences between the source and destination values at path to diffs.
+// Lists of named items (e.g. containers, ports, env) are matched by name instead of by position.
+func diffValues(path string, source, destination any, diffs *[]ResourceFieldDiff) {
+	switch s := source.(type) {
+	case map[string]interface{}:
+		if d, ok := destination.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(s)+len(d))
+			for k := range s {
+				keys = append(keys, k)
+			}
+			for k := range d {
+				if _, ok := s[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				childPath := strings.ReplaceAll(k, ".", `\.`)
+				if path != "" {
+					childPath = path + "." + childPath
+				}
+				diffValues(childPath, s[k], d[k], diffs)
+			}
+			return
+		}
+	case []interface{}:
+		if d, ok := destination.([]interface{}); ok {
+			if sourceNames, destinationNames := itemNames(s), itemNames(d); sourceNames != nil && destinationNames != nil {
+				names := slices.Clone(sourceNames)
+				for _, name := range destinationNames {
+					if !slices.Contains(names, name) {
+						names = append(names, name)
+					}
+				}
+				for _, name := range names {
+					var sourceItem, destinationItem any
+					if i := slices.Index(sourceNames, name); i >= 0 {
+						sourceItem = s[i]
+					}
+					if i := slices.Index(destinationNames, name); i >= 0 {
+						destinationItem = d[i]
+					}
+					diffValues(fmt.Sprintf("%s[name=%s]", path, name), sourceItem, destinationItem, diffs)
+				}
+				return
+			}
+			for i := 0; i < max(len(s), len(d)); i++ {
+				var sourceItem, destinationItem any
+				if i < len(s) {
+					sourceItem = s[i]
+				}
+				if i < len(d) {
+					destinationItem = d[i]
+				}
+				diffValues(fmt.Sprintf("%s[%d]", path, i), sourceItem, destinationItem, diffs)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(source, destination) {
+		*diffs = append(*diffs, ResourceFieldDiff{Path: path, Source: source, Destination: destination})
+	}
+}
+
+// redactSecretDifferences replaces the sensitive values of the Secret data (or stringData) differences, the values are
+// compared but only whether they differ is returned
+func redactSecretDifferences(diffs []ResourceFieldDiff) {
+	for i := range diffs {
+		diffs[i].Source = redactSecretValue(diffs[i].Path, diffs[i].Source)
+		diffs[i].Destination = redactSecretValue(diffs[i].Path, diffs[i].Destination)
+	}
+}
+
+// redactSecretValue returns the value of the Secret field at path with its sensitive data redacted
+func redactSecretValue(path string, value any) any {
+	if value == nil {
+		return nil
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if path == field {
+			data, ok := value.(map[string]interface{})
+			if !ok {
+				return SecretRedacted
+			}
+			redacted := make(map[string]interface{}, len(data))
+			for key, v := range data {
+				if isSecretKeyRedacted(key) {
+					v = SecretRedacted
+				}
+				redacted[key] = v
+			}
+			return redacted
+		}
+		if key, ok := strings.CutPrefix(path, field+"."); ok && isSecretKeyRedacted(strings.ReplaceAll(key, `\.`, ".")) {
+			return SecretRedacted
+		}
+	}
+	return value
+}
+
+// itemNames returns the names of the list items, or nil if any of the items has no unique name
+func itemNames(items []interface{}) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" || slices.Contains(names, name) {
+			return nil
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ResourcesDiffSuite struct {
+	suite.Suite
+}
+
+func secret(name string, data, stringData map[string]interface{}) unstructured.Unstructured {
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": name},
+	}
+	if data != nil {
+		obj["data"] = data
+	}
+	if stringData != nil {
+		obj["stringData"] = stringData
+	}
+	return unstructured.Unstructured{Object: obj}
+}
+
+func (s *ResourcesDiffSuite) TestDiffResourcesSecrets() {
+	diff := DiffResources(
+		[]unstructured.Unstructured{
+			secret("changed", map[string]interface{}{"password": "c291cmNl", "tls.crt": "c291cmNlLWNlcnQ=", "user": "YWRtaW4="}, nil),
+			secret("added", nil, nil),
+			secret("string", nil, map[string]interface{}{"config.json": "{}"}),
+		},
+		[]unstructured.Unstructured{
+			secret("changed", map[string]interface{}{"password": "ZGVzdGluYXRpb24=", "tls.crt": "ZGVzdGluYXRpb24tY2VydA==", "user": "YWRtaW4="}, nil),
+			secret("added", map[string]interface{}{"token": "dG9rZW4=", "ca.crt": "Y2E="}, nil),
+			secret("string", nil, map[string]interface{}{"config.json": `{"auth":"secret"}`}),
+		},
+		"default",
+	)
+	differences := map[string][]ResourceFieldDiff{}
+	for _, resource := range diff.Resources {
+		differences[resource.Name] = resource.Differences
+	}
+	s.Run("reports the changed data keys with their values redacted", func() {
+		s.Equal([]ResourceFieldDiff{
+			{Path: "data.password", Source: SecretRedacted, Destination: SecretRedacted},
+			{Path: `data.tls\.crt`, Source: "c291cmNlLWNlcnQ=", Destination: "ZGVzdGluYXRpb24tY2VydA=="},
+		}, differences["changed"])
+	})
+	s.Run("redacts the values of the data added as a whole", func() {
+		s.Equal([]ResourceFieldDiff{
+			{Path: "data", Destination: map[string]interface{}{"token": SecretRedacted, "ca.crt": "Y2E="}},
+		}, differences["added"])
+	})
+	s.Run("redacts the values of the stringData keys", func() {
+		s.Equal([]ResourceFieldDiff{
+			{Path: `stringData.config\.json`, Source: SecretRedacted, Destination: SecretRedacted},
+		}, differences["string"])
+	})
+	s.Run("reports the Secrets with different values as changed", func() {
+		s.Equal(ResourcesDiffSummary{Changed: 3}, diff.Summary)
+	})
+}
+
+func TestResourcesDiff(t *testing.T) {
+	suite.Run(t, new(ResourcesDiffSuite))
+}
@@ -0,0 +1,388 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Relations between the nodes of a ResourceGraph
+const (
+	GraphRelationOwns       = "owns"
+	GraphRelationEndpoints  = "endpoints"
+	GraphRelationTargets    = "targets"
+	GraphRelationSelects    = "selects"
+	GraphRelationRoutes     = "routes"
+	GraphRelationMounts     = "mounts"
+	GraphRelationReferences = "references"
+	GraphRelationUses       = "uses"
+)
+
+// graphKinds are the kinds listed to build the graph of a namespace.
+// Kinds that aren't available in the cluster or can't be listed are skipped.
+var graphKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Version: "v1", Kind: "Pod"},
+	{Version: "v1", Kind: "Service"},
+	{Version: "v1", Kind: "Endpoints"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "route.openshift.io", Version: "v1", Kind: "Route"},
+}
+
+// GraphNode is an object in a ResourceGraph, identified by Kind/name.
+type GraphNode struct {
+	ID         string `json:"id"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Referenced is true for nodes that are only known because another object references them (e.g. ConfigMaps
+	// mounted by a Pod or owners that aren't listed), their existence is not verified
+	Referenced bool `json:"referenced,omitempty"`
+}
+
+// GraphEdge is a directed relation between two nodes of a ResourceGraph.
+type GraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// ResourceGraph is the ownership and dependency graph of the objects in a namespace.
+type ResourceGraph struct {
+	Namespace string      `json:"namespace"`
+	Root      string      `json:"root,omitempty"`
+	Nodes     []GraphNode `json:"nodes"`
+	Edges     []GraphEdge `json:"edges"`
+}
+
+// ResourcesGraph builds the graph of ownerReferences, Service→Endpoints→Pod selection, Ingress/Route→Service routing,
+// and Pod→ConfigMap/Secret/PersistentVolumeClaim/ServiceAccount references of the objects in a namespace.
+// If root is provided, the graph is limited to the objects connected (in any direction) to it, the objects referenced
+// by Pods are not followed back to the other Pods referencing them.
+func (c *Core) ResourcesGraph(ctx context.Context, namespace string, root *schema.GroupVersionKind, rootName string) (*ResourceGraph, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	g := &graphBuilder{
+		graph: &ResourceGraph{Namespace: namespace, Nodes: []GraphNode{}, Edges: []GraphEdge{}},
+		nodes: map[string]int{},
+		edges: map[GraphEdge]bool{},
+	}
+	if root != nil {
+		obj, err := c.ResourcesGet(ctx, root, namespace, rootName)
+		if err != nil {
+			return nil, err
+		}
+		if obj.GetNamespace() == "" {
+			return nil, fmt.Errorf("%s %s is not namespaced, only namespaced resources can be used as graph root", root.Kind, rootName)
+		}
+		g.graph.Root = graphNodeID(obj.GetKind(), obj.GetName())
+		g.add(obj)
+	}
+	var services []*unstructured.Unstructured
+	endpoints := map[string]bool{}
+	var pods []*unstructured.Unstructured
+	for _, gvk := range graphKinds {
+		items, err := c.describeList(ctx, &gvk, namespace, "")
+		if err != nil {
+			continue
+		}
+		for i := range items {
+			obj := &items[i]
+			g.add(obj)
+			switch gvk.Kind {
+			case "Pod":
+				pods = append(pods, obj)
+			case "Service":
+				services = append(services, obj)
+			case "Endpoints":
+				endpoints[obj.GetName()] = true
+				graphEndpoints(g, obj)
+			case "Ingress":
+				graphIngress(g, obj)
+			case "Route":
+				graphRoute(g, obj)
+			}
+		}
+	}
+	for _, service := range services {
+		id := g.add(service)
+		if endpoints[service.GetName()] {
+			g.edge(id, graphNodeID("Endpoints", service.GetName()), GraphRelationEndpoints)
+			continue
+		}
+		// Fall back to the Service selector if there are no Endpoints
+		selector, found, _ := unstructured.NestedStringMap(service.Object, "spec", "selector")
+		if !found || len(selector) == 0 {
+			continue
+		}
+		s := labels.SelectorFromSet(selector)
+		for _, pod := range pods {
+			if s.Matches(labels.Set(pod.GetLabels())) {
+				g.edge(id, graphNodeID("Pod", pod.GetName()), GraphRelationSelects)
+			}
+		}
+	}
+	for _, pod := range pods {
+		graphPod(g, pod)
+	}
+	if g.graph.Root != "" {
+		g.prune(g.graph.Root)
+	}
+	sort.Slice(g.graph.Nodes, func(i, j int) bool { return g.graph.Nodes[i].ID < g.graph.Nodes[j].ID })
+	sort.Slice(g.graph.Edges, func(i, j int) bool {
+		a, b := g.graph.Edges[i], g.graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Relation < b.Relation
+	})
+	return g.graph, nil
+}
+
+type graphBuilder struct {
+	graph *ResourceGraph
+	nodes map[string]int
+	edges map[GraphEdge]bool
+}
+
+// add adds a listed object and its owner references to the graph, returning its node ID
+func (g *graphBuilder) add(obj *unstructured.Unstructured) string {
+	id := graphNodeID(obj.GetKind(), obj.GetName())
+	if i, ok := g.nodes[id]; ok {
+		if !g.graph.Nodes[i].Referenced {
+			return id
+		}
+		g.graph.Nodes[i].APIVersion = obj.GetAPIVersion()
+		g.graph.Nodes[i].Referenced = false
+	} else {
+		g.nodes[id] = len(g.graph.Nodes)
+		g.graph.Nodes = append(g.graph.Nodes, GraphNode{ID: id, APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		g.edge(g.reference(ref.APIVersion, ref.Kind, ref.Name), id, GraphRelationOwns)
+	}
+	return id
+}
+
+// reference adds a node for an object referenced by another one (if not already in the graph), returning its node ID
+func (g *graphBuilder) reference(apiVersion, kind, name string) string {
+	id := graphNodeID(kind, name)
+	if _, ok := g.nodes[id]; !ok {
+		g.nodes[id] = len(g.graph.Nodes)
+		g.graph.Nodes = append(g.graph.Nodes, GraphNode{ID: id, APIVersion: apiVersion, Kind: kind, Namespace: g.graph.Namespace, Name: name, Referenced: true})
+	}
+	return id
+}
+
+func (g *graphBuilder) edge(from, to, relation string) {
+	edge := GraphEdge{From: from, To: to, Relation: relation}
+	if from == to || g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.graph.Edges = append(g.graph.Edges, edge)
+}
+
+// prune removes the nodes and edges that aren't connected to the root node.
+// The objects referenced by Pods (ConfigMaps, Secrets, PersistentVolumeClaims and ServiceAccounts) are leaves of the
+// graph unless they're the root: they're often shared by unrelated workloads (e.g. the default ServiceAccount), so
+// the traversal doesn't continue from them to the other Pods referencing them.
+func (g *graphBuilder) prune(root string) {
+	adjacent := map[string][]string{}
+	for _, edge := range g.graph.Edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		if edge.From == root || edge.To == root || !graphReferenceRelations[edge.Relation] {
+			adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+		}
+	}
+	connected := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacent[id] {
+			if !connected[next] {
+				connected[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	nodes := make([]GraphNode, 0, len(connected))
+	for _, node := range g.graph.Nodes {
+		if connected[node.ID] {
+			nodes = append(nodes, node)
+		}
+	}
+	edges := make([]GraphEdge, 0, len(g.graph.Edges))
+	for _, edge := range g.graph.Edges {
+		if connected[edge.From] && connected[edge.To] {
+			edges = append(edges, edge)
+		}
+	}
+	g.graph.Nodes, g.graph.Edges = nodes, edges
+}
+
+// graphReferenceRelations are the relations from a Pod to the objects it references
+var graphReferenceRelations = map[string]bool{
+	GraphRelationMounts:     true,
+	GraphRelationReferences: true,
+	GraphRelationUses:       true,
+}
+
+func graphNodeID(kind, name string) string {
+	return kind + "/" + name
+}
+
+func graphEndpoints(g *graphBuilder, obj *unstructured.Unstructured) {
+	endpoints := &v1.Endpoints{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, endpoints); err != nil {
+		return
+	}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range append(append([]v1.EndpointAddress{}, subset.Addresses...), subset.NotReadyAddresses...) {
+			if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+				g.edge(graphNodeID("Endpoints", obj.GetName()), g.reference("v1", "Pod", address.TargetRef.Name), GraphRelationTargets)
+			}
+		}
+	}
+}
+
+func graphIngress(g *graphBuilder, obj *unstructured.Unstructured) {
+	id := graphNodeID(obj.GetKind(), obj.GetName())
+	if service, found, _ := unstructured.NestedString(obj.Object, "spec", "defaultBackend", "service", "name"); found {
+		g.edge(id, g.reference("v1", "Service", service), GraphRelationRoutes)
+	}
+	rules, _, _ := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	for _, rule := range rules {
+		paths, _, _ := unstructured.NestedSlice(rule.(map[string]any), "http", "paths")
+		for _, path := range paths {
+			if service, found, _ := unstructured.NestedString(path.(map[string]any), "backend", "service", "name"); found {
+				g.edge(id, g.reference("v1", "Service", service), GraphRelationRoutes)
+			}
+		}
+	}
+}
+
+func graphRoute(g *graphBuilder, obj *unstructured.Unstructured) {
+	id := graphNodeID(obj.GetKind(), obj.GetName())
+	backends, _, _ := unstructured.NestedSlice(obj.Object, "spec", "alternateBackends")
+	if to, found, _ := unstructured.NestedMap(obj.Object, "spec", "to"); found {
+		backends = append(backends, to)
+	}
+	for _, backend := range backends {
+		b := backend.(map[string]any)
+		if kind, _ := b["kind"].(string); kind != "" && kind != "Service" {
+			continue
+		}
+		if name, _ := b["name"].(string); name != "" {
+			g.edge(id, g.reference("v1", "Service", name), GraphRelationRoutes)
+		}
+	}
+}
+
+func graphPod(g *graphBuilder, obj *unstructured.Unstructured) {
+	pod := &v1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+		return
+	}
+	id := graphNodeID("Pod", pod.Name)
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			g.edge(id, g.reference("v1", "ConfigMap", volume.ConfigMap.Name), GraphRelationMounts)
+		case volume.Secret != nil:
+			g.edge(id, g.reference("v1", "Secret", volume.Secret.SecretName), GraphRelationMounts)
+		case volume.PersistentVolumeClaim != nil:
+			g.edge(id, g.reference("v1", "PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName), GraphRelationMounts)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					g.edge(id, g.reference("v1", "ConfigMap", source.ConfigMap.Name), GraphRelationMounts)
+				}
+				if source.Secret != nil {
+					g.edge(id, g.reference("v1", "Secret", source.Secret.Name), GraphRelationMounts)
+				}
+			}
+		}
+	}
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				g.edge(id, g.reference("v1", "ConfigMap", envFrom.ConfigMapRef.Name), GraphRelationReferences)
+			}
+			if envFrom.SecretRef != nil {
+				g.edge(id, g.reference("v1", "Secret", envFrom.SecretRef.Name), GraphRelationReferences)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				g.edge(id, g.reference("v1", "ConfigMap", env.ValueFrom.ConfigMapKeyRef.Name), GraphRelationReferences)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				g.edge(id, g.reference("v1", "Secret", env.ValueFrom.SecretKeyRef.Name), GraphRelationReferences)
+			}
+		}
+	}
+	for _, pullSecret := range pod.Spec.ImagePullSecrets {
+		g.edge(id, g.reference("v1", "Secret", pullSecret.Name), GraphRelationReferences)
+	}
+	if pod.Spec.ServiceAccountName != "" {
+		g.edge(id, g.reference("v1", "ServiceAccount", pod.Spec.ServiceAccountName), GraphRelationUses)
+	}
+}
+
+// Mermaid renders the graph as a Mermaid flowchart
+func (g *ResourceGraph) Mermaid() string {
+	ids := map[string]string{}
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		shape := `["%s"]`
+		if node.Referenced {
+			shape = `("%s")`
+		}
+		sb.WriteString(fmt.Sprintf("  %s"+shape+"\n", ids[node.ID], strings.ReplaceAll(node.ID, `"`, "#quot;")))
+	}
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %s -->|%s| %s\n", ids[edge.From], edge.Relation, ids[edge.To]))
+	}
+	return sb.String()
+}
+
+// DOT renders the graph in the Graphviz DOT language
+func (g *ResourceGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("digraph %q {\n  rankdir=LR;\n", g.Namespace))
+	for _, node := range g.Nodes {
+		style := ""
+		if node.Referenced {
+			style = ", style=dashed"
+		}
+		sb.WriteString(fmt.Sprintf("  %q [shape=box%s];\n", node.ID, style))
+	}
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Relation))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
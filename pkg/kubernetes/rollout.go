@@ -0,0 +1,458 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// deploymentRevisionAnnotation is the revision annotation set by the Deployment controller on Deployments and ReplicaSets
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// changeCauseAnnotation is the annotation that records the cause of a rollout
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	// restartedAtAnnotation is the Pod template annotation used to trigger a rollout restart (same as kubectl)
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// RolloutKinds are the workload kinds supported by the rollout operations
+var RolloutKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+// RolloutStatus is a compact summary of the rollout progress of a workload.
+type RolloutStatus struct {
+	Kind               string   `json:"kind"`
+	Namespace          string   `json:"namespace"`
+	Name               string   `json:"name"`
+	Done               bool     `json:"done"`
+	Message            string   `json:"message"`
+	Paused             bool     `json:"paused,omitempty"`
+	Generation         int64    `json:"generation"`
+	ObservedGeneration int64    `json:"observedGeneration"`
+	Desired            int32    `json:"desired"`
+	Updated            int32    `json:"updated"`
+	Ready              int32    `json:"ready"`
+	Available          int32    `json:"available"`
+	Conditions         []string `json:"conditions,omitempty"`
+}
+
+// RolloutRevision is an entry of the rollout history of a workload,
+// backed by a ReplicaSet (Deployments) or a ControllerRevision (StatefulSets and DaemonSets).
+type RolloutRevision struct {
+	Revision    int64    `json:"revision"`
+	Name        string   `json:"name"`
+	Current     bool     `json:"current,omitempty"`
+	ChangeCause string   `json:"changeCause,omitempty"`
+	Images      []string `json:"images,omitempty"`
+	Created     string   `json:"created"`
+}
+
+// RolloutStatus returns the rollout progress of a Deployment, StatefulSet, or DaemonSet.
+func (c *Core) RolloutStatus(ctx context.Context, kind, namespace, name string) (*RolloutStatus, error) {
+	obj, err := c.rolloutGet(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	status := &RolloutStatus{Kind: kind, Namespace: obj.GetNamespace(), Name: name, Generation: obj.GetGeneration()}
+	switch kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+			return nil, err
+		}
+		deploymentRolloutStatus(deployment, status)
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sts); err != nil {
+			return nil, err
+		}
+		statefulSetRolloutStatus(sts, status)
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ds); err != nil {
+			return nil, err
+		}
+		daemonSetRolloutStatus(ds, status)
+	}
+	return status, nil
+}
+
+// RolloutHistory returns the revisions of a Deployment, StatefulSet, or DaemonSet sorted by revision number.
+func (c *Core) RolloutHistory(ctx context.Context, kind, namespace, name string) ([]RolloutRevision, error) {
+	obj, err := c.rolloutGet(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	revisions, _, err := c.rolloutRevisions(ctx, obj)
+	return revisions, err
+}
+
+// RolloutRestart triggers a rolling restart of the Pods of a Deployment, StatefulSet, or DaemonSet
+// by updating the restartedAt annotation of its Pod template.
+func (c *Core) RolloutRestart(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	obj, err := c.rolloutGet(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		return nil, fmt.Errorf("can't restart paused %s %s, resume it first", kind, name)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"template": map[string]any{"metadata": map[string]any{"annotations": map[string]any{
+			restartedAtAnnotation: time.Now().Format(time.RFC3339),
+		}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.rolloutPatch(ctx, obj, types.MergePatchType, patch)
+}
+
+// RolloutPause pauses the rollout of a Deployment, changes to its Pod template won't be rolled out until it's resumed.
+func (c *Core) RolloutPause(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.rolloutSetPaused(ctx, kind, namespace, name, true)
+}
+
+// RolloutResume resumes the rollout of a paused Deployment.
+func (c *Core) RolloutResume(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.rolloutSetPaused(ctx, kind, namespace, name, false)
+}
+
+// RolloutUndo rolls back a Deployment, StatefulSet, or DaemonSet to the provided revision (previous revision if 0).
+// Returns the revision the workload was rolled back to and whether the rollback was skipped because the
+// workload already matches the revision.
+func (c *Core) RolloutUndo(ctx context.Context, kind, namespace, name string, toRevision int64) (*RolloutRevision, bool, error) {
+	obj, err := c.rolloutGet(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		return nil, false, fmt.Errorf("can't roll back paused %s %s, resume it first", kind, name)
+	}
+	revisions, sources, err := c.rolloutRevisions(ctx, obj)
+	if err != nil {
+		return nil, false, err
+	}
+	target := -1
+	for i := range revisions {
+		if (toRevision == 0 && !revisions[i].Current) || (toRevision != 0 && revisions[i].Revision == toRevision) {
+			// Revisions are sorted, the last non-current one is the previous revision
+			target = i
+		}
+	}
+	if target < 0 {
+		if toRevision == 0 {
+			return nil, false, fmt.Errorf("no previous revision found for %s %s", kind, name)
+		}
+		return nil, false, fmt.Errorf("revision %d not found for %s %s", toRevision, kind, name)
+	}
+	if revisions[target].Current {
+		return &revisions[target], true, nil
+	}
+	var patchType types.PatchType
+	var patch []byte
+	switch kind {
+	case "Deployment":
+		// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/polymorphichelpers/rollback.go#L110-L167
+		rs := &appsv1.ReplicaSet{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(sources[target].Object, rs); err != nil {
+			return nil, false, err
+		}
+		deployment := &appsv1.Deployment{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+			return nil, false, err
+		}
+		template := rs.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		if equality.Semantic.DeepEqual(template, &deployment.Spec.Template) {
+			return &revisions[target], true, nil
+		}
+		patchType = types.JSONPatchType
+		patch, err = json.Marshal([]map[string]any{{"op": "replace", "path": "/spec/template", "value": template}})
+	default:
+		// ControllerRevision data is a strategic merge patch that restores the Pod template of the revision
+		// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/polymorphichelpers/rollback.go#L374-L383
+		data, found, _ := unstructured.NestedMap(sources[target].Object, "data")
+		if !found {
+			return nil, false, fmt.Errorf("revision %d of %s %s has no data", revisions[target].Revision, kind, name)
+		}
+		patchType = types.StrategicMergePatchType
+		patch, err = json.Marshal(data)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err = c.rolloutPatch(ctx, obj, patchType, patch); err != nil {
+		return nil, false, err
+	}
+	return &revisions[target], false, nil
+}
+
+func (c *Core) rolloutSetPaused(ctx context.Context, kind, namespace, name string, paused bool) (*unstructured.Unstructured, error) {
+	if kind != "Deployment" {
+		return nil, fmt.Errorf("pausing and resuming rollouts is only supported for Deployments, not %s", kind)
+	}
+	obj, err := c.rolloutGet(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if current, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); current == paused {
+		if paused {
+			return nil, fmt.Errorf("%s %s is already paused", kind, name)
+		}
+		return nil, fmt.Errorf("%s %s is not paused", kind, name)
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"paused": paused}})
+	if err != nil {
+		return nil, err
+	}
+	return c.rolloutPatch(ctx, obj, types.MergePatchType, patch)
+}
+
+func (c *Core) rolloutClient(kind, namespace string) (dynamic.ResourceInterface, error) {
+	gvr, err := c.resourceFor(&schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind})
+	if err != nil {
+		return nil, err
+	}
+	return c.DynamicClient().Resource(*gvr).Namespace(c.NamespaceOrDefault(namespace)), nil
+}
+
+func (c *Core) rolloutGet(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if !slices.Contains(RolloutKinds, kind) {
+		return nil, fmt.Errorf("unsupported kind %s, supported kinds are: %s", kind, strings.Join(RolloutKinds, ", "))
+	}
+	client, err := c.rolloutClient(kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return client.Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *Core) rolloutPatch(ctx context.Context, obj *unstructured.Unstructured, patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+	client, err := c.rolloutClient(obj.GetKind(), obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return client.Patch(ctx, obj.GetName(), patchType, patch, metav1.PatchOptions{})
+}
+
+// rolloutRevisions returns the revisions of the workload sorted by revision number along with the objects backing them
+// (ReplicaSets for Deployments and ControllerRevisions for StatefulSets and DaemonSets).
+func (c *Core) rolloutRevisions(ctx context.Context, obj *unstructured.Unstructured) ([]RolloutRevision, []*unstructured.Unstructured, error) {
+	selector, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, nil, fmt.Errorf("%s %s has no selector", obj.GetKind(), obj.GetName())
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(selector, labelSelector); err != nil {
+		return nil, nil, err
+	}
+	s, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceKind := "ControllerRevision"
+	if obj.GetKind() == "Deployment" {
+		sourceKind = "ReplicaSet"
+	}
+	client, err := c.rolloutClient(sourceKind, obj.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err := client.List(ctx, metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		return nil, nil, err
+	}
+	var sources []*unstructured.Unstructured
+	for i := range list.Items {
+		if controller := metav1.GetControllerOfNoCopy(&list.Items[i]); controller != nil && controller.UID == obj.GetUID() {
+			sources = append(sources, &list.Items[i])
+		}
+	}
+	revisions := make([]RolloutRevision, len(sources))
+	for i, source := range sources {
+		revisions[i] = RolloutRevision{
+			Name:        source.GetName(),
+			ChangeCause: source.GetAnnotations()[changeCauseAnnotation],
+			Created:     source.GetCreationTimestamp().UTC().Format(time.RFC3339),
+		}
+		var containers []any
+		if sourceKind == "ReplicaSet" {
+			revisions[i].Revision, _ = strconv.ParseInt(source.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+			containers, _, _ = unstructured.NestedSlice(source.Object, "spec", "template", "spec", "containers")
+		} else {
+			revisions[i].Revision, _, _ = unstructured.NestedInt64(source.Object, "revision")
+			containers, _, _ = unstructured.NestedSlice(source.Object, "data", "spec", "template", "spec", "containers")
+		}
+		for _, container := range containers {
+			if image, ok := container.(map[string]any)["image"].(string); ok {
+				revisions[i].Images = append(revisions[i].Images, image)
+			}
+		}
+	}
+	sort.Sort(rolloutRevisionsByRevision{revisions, sources})
+	// Mark the revision matching the current state of the workload
+	current := int64(-1)
+	switch obj.GetKind() {
+	case "Deployment":
+		current, _ = strconv.ParseInt(obj.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+	case "StatefulSet":
+		if updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision"); updateRevision != "" {
+			for _, revision := range revisions {
+				if revision.Name == updateRevision {
+					current = revision.Revision
+				}
+			}
+		}
+	}
+	if current < 0 && len(revisions) > 0 {
+		current = revisions[len(revisions)-1].Revision
+	}
+	for i := range revisions {
+		revisions[i].Current = revisions[i].Revision == current
+	}
+	return revisions, sources, nil
+}
+
+type rolloutRevisionsByRevision struct {
+	revisions []RolloutRevision
+	sources   []*unstructured.Unstructured
+}
+
+func (r rolloutRevisionsByRevision) Len() int { return len(r.revisions) }
+func (r rolloutRevisionsByRevision) Less(i, j int) bool {
+	return r.revisions[i].Revision < r.revisions[j].Revision
+}
+func (r rolloutRevisionsByRevision) Swap(i, j int) {
+	r.revisions[i], r.revisions[j] = r.revisions[j], r.revisions[i]
+	r.sources[i], r.sources[j] = r.sources[j], r.sources[i]
+}
+
+// deploymentRolloutStatus mirrors the kubectl rollout status logic for Deployments
+// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/polymorphichelpers/rollout_status.go#L59-L92
+func deploymentRolloutStatus(deployment *appsv1.Deployment, status *RolloutStatus) {
+	status.Paused = deployment.Spec.Paused
+	status.ObservedGeneration = deployment.Status.ObservedGeneration
+	status.Desired = ptrInt32OrDefault(deployment.Spec.Replicas, 1)
+	status.Updated = deployment.Status.UpdatedReplicas
+	status.Ready = deployment.Status.ReadyReplicas
+	status.Available = deployment.Status.AvailableReplicas
+	var progressDeadlineExceeded bool
+	for _, condition := range deployment.Status.Conditions {
+		status.Conditions = append(status.Conditions, compactCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			progressDeadlineExceeded = true
+		}
+	}
+	name := deployment.Name
+	switch {
+	case deployment.Generation > deployment.Status.ObservedGeneration:
+		status.Message = "Waiting for deployment spec update to be observed"
+	case progressDeadlineExceeded:
+		status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", name)
+	case deployment.Status.UpdatedReplicas < status.Desired:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated", name, deployment.Status.UpdatedReplicas, status.Desired)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination", name, deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available", name, deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", name)
+	}
+	if status.Paused && !status.Done {
+		status.Message += " (rollout is paused)"
+	}
+}
+
+// statefulSetRolloutStatus mirrors the kubectl rollout status logic for StatefulSets
+// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/polymorphichelpers/rollout_status.go#L126-L160
+func statefulSetRolloutStatus(sts *appsv1.StatefulSet, status *RolloutStatus) {
+	status.ObservedGeneration = sts.Status.ObservedGeneration
+	status.Desired = ptrInt32OrDefault(sts.Spec.Replicas, 1)
+	status.Updated = sts.Status.UpdatedReplicas
+	status.Ready = sts.Status.ReadyReplicas
+	status.Available = sts.Status.AvailableReplicas
+	for _, condition := range sts.Status.Conditions {
+		status.Conditions = append(status.Conditions, compactCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
+	}
+	switch {
+	case sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType:
+		status.Message = fmt.Sprintf("rollout status is only available for %s strategy type", appsv1.RollingUpdateStatefulSetStrategyType)
+	case sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration:
+		status.Message = "Waiting for statefulset spec update to be observed"
+	case sts.Status.ReadyReplicas < status.Desired:
+		status.Message = fmt.Sprintf("Waiting for %d pods to be ready", status.Desired-sts.Status.ReadyReplicas)
+	case sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil && *sts.Spec.UpdateStrategy.RollingUpdate.Partition > 0:
+		partitioned := status.Desired - *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+		if sts.Status.UpdatedReplicas < partitioned {
+			status.Message = fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated", sts.Status.UpdatedReplicas, partitioned)
+		} else {
+			status.Done = true
+			status.Message = fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", sts.Status.UpdatedReplicas)
+		}
+	case sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		status.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s", sts.Status.UpdatedReplicas, sts.Status.UpdateRevision)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", sts.Status.CurrentReplicas, sts.Status.CurrentRevision)
+	}
+}
+
+// daemonSetRolloutStatus mirrors the kubectl rollout status logic for DaemonSets
+// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/polymorphichelpers/rollout_status.go#L95-L123
+func daemonSetRolloutStatus(ds *appsv1.DaemonSet, status *RolloutStatus) {
+	status.ObservedGeneration = ds.Status.ObservedGeneration
+	status.Desired = ds.Status.DesiredNumberScheduled
+	status.Updated = ds.Status.UpdatedNumberScheduled
+	status.Ready = ds.Status.NumberReady
+	status.Available = ds.Status.NumberAvailable
+	for _, condition := range ds.Status.Conditions {
+		status.Conditions = append(status.Conditions, compactCondition(string(condition.Type), string(condition.Status), condition.Reason, condition.Message))
+	}
+	switch {
+	case ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType:
+		status.Message = fmt.Sprintf("rollout status is only available for %s strategy type", appsv1.RollingUpdateDaemonSetStrategyType)
+	case ds.Generation > ds.Status.ObservedGeneration:
+		status.Message = "Waiting for daemon set spec update to be observed"
+	case ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled:
+		status.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated", ds.Name, ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
+	case ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled:
+		status.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available", ds.Name, ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("daemon set %q successfully rolled out", ds.Name)
+	}
+}
+
+// compactCondition formats a status condition as Type=Status (Reason): Message
+func compactCondition(conditionType, conditionStatus, reason, message string) string {
+	ret := conditionType + "=" + conditionStatus
+	if reason != "" {
+		ret += " (" + reason + ")"
+	}
+	if message != "" {
+		ret += ": " + message
+	}
+	return ret
+}
+
+func ptrInt32OrDefault(v *int32, def int32) int32 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
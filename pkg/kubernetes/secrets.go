@@ -0,0 +1,30 @@
+package kubernetes
+
+import (
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// SecretRedacted replaces the sensitive values of the Secrets in the results of the tools
+const SecretRedacted = "<redacted>"
+
+// secretPublicKeys are the keys of the Secrets holding public certificates, the values of any other key may be
+// sensitive (private keys, passwords, tokens) and are redacted
+var secretPublicKeys = []string{v1.TLSCertKey, v1.ServiceAccountRootCAKey}
+
+// isSecretKeyRedacted returns true if the value of the Secret data (or stringData) key must be redacted
+func isSecretKeyRedacted(key string) bool {
+	return !slices.Contains(secretPublicKeys, key)
+}
+
+// redactSecretData removes the sensitive values of the Secret as soon as it's retrieved, the Secrets are retrieved
+// whole (the API can't return a subset of their keys) but only their public certificates are processed
+func redactSecretData(secret *v1.Secret) {
+	for key := range secret.Data {
+		if isSecretKeyRedacted(key) {
+			delete(secret.Data, key)
+		}
+	}
+	secret.StringData = nil
+}
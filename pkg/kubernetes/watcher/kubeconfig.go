@@ -16,8 +16,12 @@ const (
 	DefaultKubeconfigDebounceWindow = 100 * time.Millisecond
 )
 
+// Kubeconfig watches the kubeconfig files (or the directories of kubeconfig files) and triggers a debounced reload
+// when any of them changes.
 type Kubeconfig struct {
 	clientcmd.ClientConfig
+	// paths returns the files or directories to watch
+	paths          func() []string
 	debounceWindow time.Duration
 	debounceTimer  *time.Timer
 	mu             sync.Mutex
@@ -28,7 +32,20 @@ type Kubeconfig struct {
 
 var _ Watcher = (*Kubeconfig)(nil)
 
+// NewKubeconfig creates a watcher of the kubeconfig files loaded by the client config
 func NewKubeconfig(clientConfig clientcmd.ClientConfig) *Kubeconfig {
+	w := newKubeconfig(func() []string { return clientConfig.ConfigAccess().GetLoadingPrecedence() })
+	w.ClientConfig = clientConfig
+	return w
+}
+
+// NewKubeconfigPaths creates a watcher of the provided kubeconfig files or directories of kubeconfig files,
+// directories are watched for files added, removed, or modified
+func NewKubeconfigPaths(paths ...string) *Kubeconfig {
+	return newKubeconfig(func() []string { return paths })
+}
+
+func newKubeconfig(paths func() []string) *Kubeconfig {
 	debounceWindow := DefaultKubeconfigDebounceWindow
 
 	// Allow override via environment variable for testing
@@ -40,7 +57,7 @@ func NewKubeconfig(clientConfig clientcmd.ClientConfig) *Kubeconfig {
 	}
 
 	return &Kubeconfig{
-		ClientConfig:   clientConfig,
+		paths:          paths,
 		debounceWindow: debounceWindow,
 		stopCh:         make(chan struct{}),
 		stoppedCh:      make(chan struct{}),
@@ -59,16 +76,18 @@ func (w *Kubeconfig) Watch(onChange func() error) {
 	w.started = true
 	w.mu.Unlock()
 
-	kubeConfigFiles := w.ConfigAccess().GetLoadingPrecedence()
-	if len(kubeConfigFiles) == 0 {
+	kubeConfigPaths := w.paths()
+	if len(kubeConfigPaths) == 0 {
 		return
 	}
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return
 	}
-	for _, file := range kubeConfigFiles {
-		_ = watcher.Add(file)
+	for _, path := range kubeConfigPaths {
+		if err = watcher.Add(path); err != nil {
+			klog.V(2).Infof("Failed to watch kubeconfig %s: %v", path, err)
+		}
 	}
 
 	go func() {
@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -367,6 +368,27 @@ func (s *KubeconfigTestSuite) TestClose() {
 	})
 }
 
+func (s *KubeconfigTestSuite) TestNewKubeconfigPaths() {
+	s.Run("triggers onChange when a file is added to a watched directory", func() {
+		directory := s.T().TempDir()
+		watcher := NewKubeconfigPaths(directory)
+		s.T().Cleanup(watcher.Close)
+		s.Nil(watcher.ClientConfig)
+
+		var changed atomic.Bool
+		watcher.Watch(func() error {
+			changed.Store(true)
+			return nil
+		})
+		s.Eventually(func() bool {
+			return watcher.started
+		}, kubeconfigTestTimeout, kubeconfigEventuallyTick, "timeout waiting for watcher to be ready")
+
+		s.Require().NoError(clientcmd.WriteToFile(*test.KubeConfigFake(), filepath.Join(directory, "cluster")))
+		s.Eventually(changed.Load, kubeconfigTestTimeout, kubeconfigEventuallyTick, "timeout waiting for onChange callback")
+	})
+}
+
 func TestKubeconfig(t *testing.T) {
 	suite.Run(t, new(KubeconfigTestSuite))
 }
@@ -0,0 +1,158 @@
+package watcher
+
+import (
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultTargetsPollInterval is the default interval for polling the targets discovered from a cluster
+	DefaultTargetsPollInterval = 60 * time.Second
+	// DefaultTargetsDebounceWindow is the default debounce window for targets changes
+	DefaultTargetsDebounceWindow = 5 * time.Second
+)
+
+// Targets monitors the targets discovered by a provider (e.g. Cluster API clusters) by periodically
+// polling the list function and triggers a debounced reload when the list changes.
+type Targets struct {
+	list           func() ([]string, error)
+	pollInterval   time.Duration
+	debounceWindow time.Duration
+	lastKnownState []string
+	debounceTimer  *time.Timer
+	mu             sync.Mutex
+	stopCh         chan struct{}
+	stoppedCh      chan struct{}
+	started        bool
+}
+
+var _ Watcher = (*Targets)(nil)
+
+func NewTargets(list func() ([]string, error)) *Targets {
+	pollInterval := DefaultTargetsPollInterval
+	debounceWindow := DefaultTargetsDebounceWindow
+
+	// Allow override via environment variable for testing
+	if envInterval := os.Getenv("TARGETS_POLL_INTERVAL_MS"); envInterval != "" {
+		if ms, err := strconv.Atoi(envInterval); err == nil && ms > 0 {
+			pollInterval = time.Duration(ms) * time.Millisecond
+			klog.V(2).Infof("Using custom targets poll interval: %v", pollInterval)
+		}
+	}
+	if envDebounce := os.Getenv("TARGETS_DEBOUNCE_WINDOW_MS"); envDebounce != "" {
+		if ms, err := strconv.Atoi(envDebounce); err == nil && ms > 0 {
+			debounceWindow = time.Duration(ms) * time.Millisecond
+			klog.V(2).Infof("Using custom targets debounce window: %v", debounceWindow)
+		}
+	}
+
+	return &Targets{
+		list:           list,
+		pollInterval:   pollInterval,
+		debounceWindow: debounceWindow,
+		stopCh:         make(chan struct{}),
+		stoppedCh:      make(chan struct{}),
+	}
+}
+
+// Watch starts a background watcher that periodically polls the targets
+// and triggers a debounced reload when changes are detected.
+// It can only be called once per Targets instance.
+func (w *Targets) Watch(onChange func() error) {
+	w.mu.Lock()
+	if w.started {
+		w.mu.Unlock()
+		return
+	}
+	w.started = true
+	w.lastKnownState, _ = w.captureState()
+	w.mu.Unlock()
+
+	go func() {
+		defer close(w.stoppedCh)
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+
+		klog.V(2).Infof("Started targets watcher (poll interval: %v, debounce: %v)", w.pollInterval, w.debounceWindow)
+
+		for {
+			select {
+			case <-w.stopCh:
+				klog.V(2).Info("Stopping targets watcher")
+				return
+			case <-ticker.C:
+				current, err := w.captureState()
+				if err != nil {
+					// Keep the known targets if the cluster is temporarily unreachable
+					klog.V(3).Infof("Failed to poll targets: %v", err)
+					continue
+				}
+				w.mu.Lock()
+				klog.V(3).Infof("Polled targets: %d targets", len(current))
+				if !slices.Equal(current, w.lastKnownState) {
+					klog.V(2).Info("Targets changed, scheduling debounced reload")
+					w.lastKnownState = current
+					if w.debounceTimer != nil {
+						w.debounceTimer.Stop()
+					}
+					w.debounceTimer = time.AfterFunc(w.debounceWindow, func() {
+						klog.V(2).Info("Targets debounce window expired, triggering reload")
+						if err := onChange(); err != nil {
+							klog.Errorf("Failed to reload after targets change: %v", err)
+						}
+					})
+				}
+				w.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Close stops the targets watcher
+func (w *Targets) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.debounceTimer != nil {
+		w.debounceTimer.Stop()
+	}
+
+	if w.stopCh == nil || w.stoppedCh == nil {
+		return // Already closed
+	}
+
+	if !w.started {
+		return
+	}
+
+	select {
+	case <-w.stopCh:
+		// Already closed or stopped
+		return
+	default:
+		close(w.stopCh)
+		w.mu.Unlock()
+		<-w.stoppedCh
+		w.mu.Lock()
+		w.started = false
+		// Recreate channels for potential restart
+		w.stopCh = make(chan struct{})
+		w.stoppedCh = make(chan struct{})
+	}
+}
+
+func (w *Targets) captureState() ([]string, error) {
+	targets, err := w.list()
+	if err != nil {
+		return nil, err
+	}
+	targets = slices.Clone(targets)
+	sort.Strings(targets)
+	return targets, nil
+}
@@ -0,0 +1,107 @@
+package watcher
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TargetsTestSuite struct {
+	suite.Suite
+	mu      sync.Mutex
+	targets []string
+	err     error
+}
+
+func (s *TargetsTestSuite) SetupTest() {
+	s.T().Setenv("TARGETS_POLL_INTERVAL_MS", "10")
+	s.T().Setenv("TARGETS_DEBOUNCE_WINDOW_MS", "10")
+	s.targets = []string{"b", "a"}
+	s.err = nil
+}
+
+func (s *TargetsTestSuite) list() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.targets, s.err
+}
+
+func (s *TargetsTestSuite) set(targets []string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets, s.err = targets, err
+}
+
+func (s *TargetsTestSuite) TestNewTargets() {
+	s.T().Setenv("TARGETS_POLL_INTERVAL_MS", "")
+	s.T().Setenv("TARGETS_DEBOUNCE_WINDOW_MS", "")
+	watcher := NewTargets(s.list)
+	s.Run("initializes with default poll interval at 60s", func() {
+		s.Equal(60*time.Second, watcher.pollInterval)
+	})
+	s.Run("initializes with default debounce window at 5s", func() {
+		s.Equal(5*time.Second, watcher.debounceWindow)
+	})
+}
+
+func (s *TargetsTestSuite) TestWatch() {
+	s.Run("captures the sorted initial targets", func() {
+		watcher := NewTargets(s.list)
+		s.T().Cleanup(watcher.Close)
+		watcher.Watch(func() error { return nil })
+		watcher.mu.Lock()
+		defer watcher.mu.Unlock()
+		s.Equal([]string{"a", "b"}, watcher.lastKnownState)
+	})
+	s.Run("does not trigger onChange when the targets are unchanged or can't be listed", func() {
+		watcher := NewTargets(s.list)
+		s.T().Cleanup(watcher.Close)
+		var changes atomic.Int32
+		watcher.Watch(func() error {
+			changes.Add(1)
+			return nil
+		})
+		s.set([]string{"a", "b"}, nil)
+		time.Sleep(50 * time.Millisecond)
+		s.set(nil, errors.New("unreachable"))
+		time.Sleep(50 * time.Millisecond)
+		s.Equal(int32(0), changes.Load())
+	})
+	s.Run("triggers onChange when the targets change", func() {
+		s.set([]string{"a", "b"}, nil)
+		watcher := NewTargets(s.list)
+		s.T().Cleanup(watcher.Close)
+		var changes atomic.Int32
+		watcher.Watch(func() error {
+			changes.Add(1)
+			return nil
+		})
+		s.set([]string{"a", "b", "c"}, nil)
+		s.Eventually(func() bool {
+			return changes.Load() == 1
+		}, time.Second, time.Millisecond, "timeout waiting for onChange callback")
+	})
+}
+
+func (s *TargetsTestSuite) TestClose() {
+	s.Run("stops the watcher", func() {
+		watcher := NewTargets(s.list)
+		watcher.Watch(func() error { return nil })
+		watcher.Close()
+		watcher.mu.Lock()
+		defer watcher.mu.Unlock()
+		s.False(watcher.started)
+	})
+	s.Run("can be called without Watch", func() {
+		watcher := NewTargets(s.list)
+		s.NotPanics(watcher.Close)
+	})
+}
+
+func TestTargets(t *testing.T) {
+	suite.Run(t, new(TargetsTestSuite))
+}
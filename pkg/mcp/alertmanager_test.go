@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const alertmanagerProxyPath = "/api/v1/namespaces/monitoring/services/http:alertmanager:9093/proxy"
+
+type AlertmanagerSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// requests captured by the stub Alertmanager behind the service proxy
+	requests []*http.Request
+	bodies   []string
+}
+
+func (s *AlertmanagerSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requests = nil
+	s.bodies = nil
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discovery)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, alertmanagerProxyPath) {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, alertmanagerProxyPath) {
+		case "/api/v2/alerts":
+			_, _ = w.Write([]byte(`[{"fingerprint":"f1","labels":{"alertname":"KubePodCrashLooping","namespace":"default"},` +
+				`"annotations":{"summary":"Pod is crash looping"},"startsAt":"2025-01-01T00:00:00Z","endsAt":"2025-01-01T01:00:00Z",` +
+				`"status":{"state":"active","silencedBy":[],"inhibitedBy":[]},"receivers":[{"name":"default"}]}]`))
+		case "/api/v2/silences":
+			if r.Method == http.MethodPost {
+				_, _ = w.Write([]byte(`{"silenceID":"a1b2c3d4-0000-1111-2222-333344445555"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[` +
+				`{"id":"s1","matchers":[{"name":"alertname","value":"Watchdog","isRegex":false,"isEqual":true}],"startsAt":"2025-01-01T00:00:00Z","endsAt":"2025-01-01T02:00:00Z","createdBy":"ops","comment":"noise","status":{"state":"active"}},` +
+				`{"id":"s2","matchers":[{"name":"alertname","value":"Other","isRegex":false,"isEqual":true}],"startsAt":"2024-01-01T00:00:00Z","endsAt":"2024-01-01T02:00:00Z","createdBy":"ops","comment":"old","status":{"state":"expired"}}]`))
+		case "/api/v2/silence/s1":
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		toolsets = ["alertmanager"]
+		[toolset_configs.alertmanager]
+		namespace = "monitoring"
+		service = "alertmanager"
+		port = "9093"
+		scheme = "http"
+	`)))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *AlertmanagerSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *AlertmanagerSuite) lastRequest() *http.Request {
+	s.Require().NotEmpty(s.requests, "expected a request to Alertmanager")
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *AlertmanagerSuite) TestAlertsList() {
+	s.InitMcpClient()
+	s.Run("alertmanager_alerts_list()", func() {
+		toolResult, err := s.CallTool("alertmanager_alerts_list", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("queries Alertmanager through the service proxy", func() {
+			s.Equal(http.MethodGet, s.lastRequest().Method)
+			s.Equal(alertmanagerProxyPath+"/api/v2/alerts", s.lastRequest().URL.Path)
+		})
+		s.Run("returns only firing alerts by default", func() {
+			s.Equal("true", s.lastRequest().URL.Query().Get("active"))
+			s.Equal("false", s.lastRequest().URL.Query().Get("silenced"))
+			s.Equal("false", s.lastRequest().URL.Query().Get("inhibited"))
+		})
+		s.Run("returns structured alerts", func() {
+			var alerts []map[string]any
+			s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &alerts))
+			s.Require().Len(alerts, 1)
+			s.Equal("KubePodCrashLooping", alerts[0]["labels"].(map[string]any)["alertname"])
+		})
+	})
+	s.Run("alertmanager_alerts_list(matchers, silenced=true)", func() {
+		toolResult, err := s.CallTool("alertmanager_alerts_list", map[string]interface{}{
+			"matchers": []any{`namespace=~"prod-.*"`, `severity!="info"`},
+			"silenced": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal([]string{`namespace=~"prod-.*"`, `severity!="info"`}, s.lastRequest().URL.Query()["filter"])
+		s.Equal("true", s.lastRequest().URL.Query().Get("silenced"))
+	})
+	s.Run("alertmanager_alerts_list(matchers=invalid)", func() {
+		toolResult, _ := s.CallTool("alertmanager_alerts_list", map[string]interface{}{"matchers": []any{"not a matcher"}})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `invalid matcher "not a matcher"`)
+	})
+}
+
+func (s *AlertmanagerSuite) TestSilencesList() {
+	s.InitMcpClient()
+	s.Run("alertmanager_silences_list()", func() {
+		toolResult, err := s.CallTool("alertmanager_silences_list", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var silences []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &silences))
+		s.Len(silences, 2)
+	})
+	s.Run("alertmanager_silences_list(state=active)", func() {
+		toolResult, err := s.CallTool("alertmanager_silences_list", map[string]interface{}{"state": "active"})
+		s.Nilf(err, "call tool failed %v", err)
+		var silences []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &silences))
+		s.Require().Len(silences, 1)
+		s.Equal("s1", silences[0]["id"])
+	})
+}
+
+func (s *AlertmanagerSuite) TestSilenceCreate() {
+	s.InitMcpClient()
+	s.Run("alertmanager_silence_create(matchers, comment)", func() {
+		toolResult, err := s.CallTool("alertmanager_silence_create", map[string]interface{}{
+			"matchers": []any{`alertname="KubePodCrashLooping"`, `namespace=~"prod-.*"`},
+			"comment":  "maintenance",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("posts the silence", func() {
+			s.Equal(http.MethodPost, s.lastRequest().Method)
+			s.Equal(alertmanagerProxyPath+"/api/v2/silences", s.lastRequest().URL.Path)
+			var silence map[string]any
+			s.Require().NoError(json.Unmarshal([]byte(s.bodies[len(s.bodies)-1]), &silence))
+			s.Equal("maintenance", silence["comment"])
+			s.Equal("kubernetes-mcp-server", silence["createdBy"])
+			s.Equal([]any{
+				map[string]any{"name": "alertname", "value": "KubePodCrashLooping", "isRegex": false, "isEqual": true},
+				map[string]any{"name": "namespace", "value": "prod-.*", "isRegex": true, "isEqual": true},
+			}, silence["matchers"])
+			startsAt, _ := time.Parse(time.RFC3339, silence["startsAt"].(string))
+			endsAt, _ := time.Parse(time.RFC3339, silence["endsAt"].(string))
+			s.Equal(2*time.Hour, endsAt.Sub(startsAt).Round(time.Second), "expected default duration of 2h")
+		})
+		s.Run("returns the silence ID", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `"id":"a1b2c3d4-0000-1111-2222-333344445555"`)
+		})
+	})
+	s.Run("alertmanager_silence_create(matchers=[])", func() {
+		toolResult, _ := s.CallTool("alertmanager_silence_create", map[string]interface{}{"matchers": []any{}, "comment": "x"})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "matchers parameter required")
+	})
+	s.Run("alertmanager_silence_create(duration=invalid)", func() {
+		toolResult, _ := s.CallTool("alertmanager_silence_create", map[string]interface{}{
+			"matchers": []any{`alertname="X"`}, "comment": "x", "duration": "forever",
+		})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid duration: forever")
+	})
+}
+
+func (s *AlertmanagerSuite) TestSilenceExpire() {
+	s.InitMcpClient()
+	s.Run("alertmanager_silence_expire(id)", func() {
+		toolResult, err := s.CallTool("alertmanager_silence_expire", map[string]interface{}{"id": "s1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal(http.MethodDelete, s.lastRequest().Method)
+		s.Equal(alertmanagerProxyPath+"/api/v2/silence/s1", s.lastRequest().URL.Path)
+		s.Equal("Silence s1 expired", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("alertmanager_silence_expire(id=invalid)", func() {
+		toolResult, _ := s.CallTool("alertmanager_silence_expire", map[string]interface{}{"id": "../alerts"})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid silence id")
+	})
+}
+
+func (s *AlertmanagerSuite) TestReadOnly() {
+	s.Require().NoError(toml.Unmarshal([]byte(`read_only = true`), s.Cfg), "Expected to parse read only server config")
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	names := make([]string, 0, len(tools.Tools))
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	s.ElementsMatch([]string{"alertmanager_alerts_list", "alertmanager_silences_list"}, names)
+}
+
+func TestAlertmanager(t *testing.T) {
+	suite.Run(t, new(AlertmanagerSuite))
+}
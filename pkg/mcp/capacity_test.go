@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type CapacitySuite struct {
+	BaseMcpSuite
+	mockServer       *test.MockServer
+	discoveryHandler *test.DiscoveryClientHandler
+}
+
+func resourceList(cpu, memory string) v1.ResourceList {
+	list := v1.ResourceList{}
+	if cpu != "" {
+		list[v1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[v1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func (s *CapacitySuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.discoveryHandler = test.NewDiscoveryClientHandler()
+	s.discoveryHandler.APIResourceLists[0].APIResources = append(s.discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "resourcequotas", Kind: "ResourceQuota", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "limitranges", Kind: "LimitRange", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(s.discoveryHandler)
+	pod := func(namespace, name, node string, requests, limits v1.ResourceList) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: v1.PodSpec{NodeName: node, Containers: []v1.Container{
+				{Name: "app", Resources: v1.ResourceRequirements{Requests: requests, Limits: limits}},
+			}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+	}
+	podMetrics := func(namespace, name string, usage v1.ResourceList) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: usage}},
+		}
+	}
+	completed := pod("default", "completed", "node-1", resourceList("8", "64Gi"), nil)
+	completed.Status.Phase = v1.PodSucceeded
+	objects := map[string]runtime.Object{
+		"/api/v1/nodes": &v1.NodeList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NodeList"},
+			Items: []v1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Status: v1.NodeStatus{Allocatable: resourceList("4", "16Gi")}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Status: v1.NodeStatus{Allocatable: resourceList("2", "4Gi")}},
+			},
+		},
+		"/api/v1/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items: []v1.Pod{
+				pod("default", "web", "node-1", resourceList("2", "4Gi"), resourceList("4", "8Gi")),
+				pod("team-b", "api", "node-2", resourceList("1900m", "1Gi"), resourceList("3", "")),
+				pod("team-a", "batch", "node-1", nil, nil),
+				completed,
+			},
+		},
+		"/api/v1/namespaces/default/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items:    []v1.Pod{pod("default", "web", "node-1", resourceList("2", "4Gi"), resourceList("4", "8Gi"))},
+		},
+		"/api/v1/resourcequotas": &v1.ResourceQuotaList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuotaList"},
+			Items: []v1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "compute"},
+				Status: v1.ResourceQuotaStatus{
+					Hard: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourcePods: resource.MustParse("10")},
+					Used: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourcePods: resource.MustParse("1")},
+				},
+			}},
+		},
+		"/api/v1/namespaces/default/resourcequotas": &v1.ResourceQuotaList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuotaList"},
+		},
+		"/api/v1/limitranges": &v1.LimitRangeList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "LimitRangeList"},
+		},
+		"/api/v1/namespaces/default/limitranges": &v1.LimitRangeList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "LimitRangeList"},
+			Items: []v1.LimitRange{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "defaults"},
+				Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{
+					{Type: v1.LimitTypeContainer, DefaultRequest: resourceList("100m", "128Mi"), Max: resourceList("4", "")},
+				}},
+			}},
+		},
+		"/apis/metrics.k8s.io/v1beta1/pods": &metricsv1beta1.PodMetricsList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "metrics.k8s.io/v1beta1", Kind: "PodMetricsList"},
+			Items: []metricsv1beta1.PodMetrics{
+				podMetrics("default", "web", resourceList("100m", "512Mi")),
+				podMetrics("team-b", "api", resourceList("2", "900Mi")),
+				podMetrics("team-a", "batch", resourceList("50m", "64Mi")),
+			},
+		},
+		"/apis/metrics.k8s.io/v1beta1/namespaces/default/pods": &metricsv1beta1.PodMetricsList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "metrics.k8s.io/v1beta1", Kind: "PodMetricsList"},
+			Items:    []metricsv1beta1.PodMetrics{podMetrics("default", "web", resourceList("100m", "512Mi"))},
+		},
+		"/apis/metrics.k8s.io/v1beta1/nodes": &metricsv1beta1.NodeMetricsList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "metrics.k8s.io/v1beta1", Kind: "NodeMetricsList"},
+			Items: []metricsv1beta1.NodeMetrics{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Usage: resourceList("1", "4Gi")},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Usage: resourceList("1900m", "1Gi")},
+			},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *CapacitySuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CapacitySuite) WithMetricsServer() {
+	s.discoveryHandler.AddAPIResourceList(metav1.APIResourceList{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "nodes", Kind: "NodeMetrics", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "pods", Kind: "PodMetrics", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+}
+
+func (s *CapacitySuite) capacityReport(arguments map[string]interface{}) map[string]any {
+	result, err := s.CallTool("capacity_report", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	s.Require().NotNil(result.StructuredContent)
+	var report map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &report))
+	return report
+}
+
+// findings returns the findings of the decoded report as "severity kind namespace/name resource" strings
+func (s *CapacitySuite) findings(report map[string]any) []string {
+	var findings []string
+	for _, f := range report["findings"].([]any) {
+		finding := f.(map[string]any)
+		namespace, _ := finding["namespace"].(string)
+		resource, _ := finding["resource"].(string)
+		findings = append(findings, finding["severity"].(string)+" "+finding["kind"].(string)+" "+namespace+"/"+finding["name"].(string)+" "+resource)
+	}
+	return findings
+}
+
+func (s *CapacitySuite) TestCapacityReport() {
+	s.WithMetricsServer()
+	s.InitMcpClient()
+	report := s.capacityReport(map[string]interface{}{})
+	s.Run("returns metrics available", func() {
+		s.Equal(true, report["metricsAvailable"])
+	})
+	s.Run("returns node allocatable, requests, limits, and usage", func() {
+		nodes := report["nodes"].([]any)
+		s.Require().Len(nodes, 2)
+		node := nodes[1].(map[string]any)
+		s.Equal("node-2", node["name"])
+		s.Equal(float64(1), node["pods"])
+		s.Equal(map[string]any{"cpuMillicores": float64(2000), "memoryBytes": float64(4 * 1024 * 1024 * 1024)}, node["allocatable"])
+		s.Equal(map[string]any{"cpu": float64(95), "memory": float64(25)}, node["requestsPercent"])
+		s.Equal(map[string]any{"cpu": float64(150), "memory": float64(0)}, node["limitsPercent"])
+		s.Equal(map[string]any{"cpu": float64(95), "memory": float64(25)}, node["usagePercent"])
+	})
+	s.Run("excludes completed pods", func() {
+		node := report["nodes"].([]any)[0].(map[string]any)
+		s.Equal(float64(2), node["pods"])
+		s.Equal(map[string]any{"cpuMillicores": float64(2000), "memoryBytes": float64(4 * 1024 * 1024 * 1024)}, node["requests"])
+	})
+	s.Run("returns namespaces with quotas and usage", func() {
+		namespaces := report["namespaces"].([]any)
+		s.Require().Len(namespaces, 3)
+		teamA := namespaces[1].(map[string]any)
+		s.Equal("team-a", teamA["name"])
+		s.Equal(float64(1), teamA["containersWithoutRequests"])
+		s.Equal([]any{map[string]any{"name": "compute", "resources": []any{
+			map[string]any{"resource": "cpu", "hard": "2", "used": "2", "percent": float64(100)},
+			map[string]any{"resource": "pods", "hard": "10", "used": "1", "percent": float64(10)},
+		}}}, teamA["quotas"])
+		s.Equal(map[string]any{"cpuMillicores": float64(50), "memoryBytes": float64(64 * 1024 * 1024)}, teamA["usage"])
+	})
+	s.Run("returns findings sorted by severity", func() {
+		s.Equal([]string{
+			"critical Node /node-2 cpu",
+			"critical ResourceQuota team-a/compute cpu",
+			"warning Node /node-2 cpu",
+			"warning Namespace team-a/team-a ",
+			"warning Namespace team-b/team-b cpu",
+			"info Node /node-2 cpu",
+			"info Namespace default/default cpu",
+			"info Namespace default/default memory",
+		}, s.findings(report))
+	})
+}
+
+func (s *CapacitySuite) TestCapacityReportInNamespace() {
+	s.WithMetricsServer()
+	s.InitMcpClient()
+	report := s.capacityReport(map[string]interface{}{"namespace": "default"})
+	s.Run("omits nodes", func() {
+		s.Nil(report["nodes"])
+	})
+	s.Run("returns namespace with limit ranges", func() {
+		namespaces := report["namespaces"].([]any)
+		s.Require().Len(namespaces, 1)
+		s.Equal([]any{map[string]any{
+			"name":           "defaults",
+			"defaultRequest": map[string]any{"cpu": "100m", "memory": "128Mi"},
+			"max":            map[string]any{"cpu": "4"},
+		}}, namespaces[0].(map[string]any)["limitRanges"])
+	})
+	s.Run("returns over-provisioning findings", func() {
+		s.Equal([]string{"info Namespace default/default cpu", "info Namespace default/default memory"}, s.findings(report))
+	})
+}
+
+func (s *CapacitySuite) TestCapacityReportWithoutMetricsServer() {
+	s.InitMcpClient()
+	report := s.capacityReport(map[string]interface{}{})
+	s.Run("returns metrics not available", func() {
+		s.Equal(false, report["metricsAvailable"])
+	})
+	s.Run("omits usage", func() {
+		for _, node := range report["nodes"].([]any) {
+			s.Nil(node.(map[string]any)["usage"])
+		}
+		for _, namespace := range report["namespaces"].([]any) {
+			s.Nil(namespace.(map[string]any)["usage"])
+		}
+	})
+	s.Run("returns allocation findings", func() {
+		s.Equal([]string{
+			"critical ResourceQuota team-a/compute cpu",
+			"warning Node /node-2 cpu",
+			"warning Namespace team-a/team-a ",
+			"info Node /node-2 cpu",
+		}, s.findings(report))
+	})
+}
+
+func TestCapacity(t *testing.T) {
+	suite.Run(t, new(CapacitySuite))
+}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type CertificatesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	privateKey []byte
+}
+
+// generateCertificate returns a PEM encoded self-signed certificate valid until notAfter and its PEM encoded private key
+func generateCertificate(t *testing.T, commonName string, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func (s *CertificatesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists = append(discovery.APIResourceLists, metav1.APIResourceList{
+		GroupVersion: "admissionregistration.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "mutatingwebhookconfigurations", Kind: "MutatingWebhookConfiguration", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "validatingwebhookconfigurations", Kind: "ValidatingWebhookConfiguration", Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "cert-manager.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "selfsubjectaccessreviews", Kind: "SelfSubjectAccessReview", Verbs: metav1.Verbs{"create"}},
+		},
+	})
+	s.mockServer.Handle(discovery)
+	now := time.Now()
+	expiredCert, expiredKey := generateCertificate(s.T(), "expired.example.com", now.Add(-48*time.Hour))
+	validCert, _ := generateCertificate(s.T(), "webhook.example.com", now.Add(200*24*time.Hour))
+	s.privateKey = expiredKey
+	objects := map[string]runtime.Object{
+		"/api/v1/secrets": &v1.SecretList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "SecretList"},
+			Items: []v1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "expired-tls"}, Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: expiredCert, v1.TLSPrivateKeyKey: expiredKey}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "invalid-tls"}, Type: v1.SecretTypeTLS,
+					Data: map[string][]byte{v1.TLSCertKey: []byte("not a certificate"), v1.TLSPrivateKeyKey: expiredKey}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "opaque"}, Type: v1.SecretTypeOpaque,
+					Data: map[string][]byte{v1.TLSCertKey: validCert}},
+			},
+		},
+		"/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations": &admissionregistrationv1.MutatingWebhookConfigurationList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admissionregistration.k8s.io/v1", Kind: "MutatingWebhookConfigurationList"},
+		},
+		"/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations": &admissionregistrationv1.ValidatingWebhookConfigurationList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfigurationList"},
+			Items: []admissionregistrationv1.ValidatingWebhookConfiguration{{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+					Name: "validate.example.com", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: validCert},
+				}},
+			}},
+		},
+		"/apis/cert-manager.io/v1/certificates": &unstructured.UnstructuredList{
+			Object: map[string]any{"apiVersion": "cert-manager.io/v1", "kind": "CertificateList"},
+			Items: []unstructured.Unstructured{{Object: map[string]any{
+				"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+				"metadata": map[string]any{"namespace": "default", "name": "ingress"},
+				"spec": map[string]any{
+					"secretName": "ingress-tls", "commonName": "expiring.example.com", "dnsNames": []any{"expiring.example.com"},
+					"issuerRef": map[string]any{"kind": "ClusterIssuer", "name": "letsencrypt"},
+				},
+				"status": map[string]any{
+					"notAfter":   now.Add(10 * 24 * time.Hour).UTC().Format(time.RFC3339),
+					"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+				},
+			}}},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Allow listing resources in all namespaces
+		if req.URL.Path == "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			test.WriteObject(w, &authorizationv1.SelfSubjectAccessReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "authorization.k8s.io/v1", Kind: "SelfSubjectAccessReview"},
+				Status:   authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+			})
+			return
+		}
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *CertificatesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CertificatesSuite) certificatesCheck(arguments map[string]interface{}) (string, map[string]any) {
+	result, err := s.CallTool("certificates_check", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	s.Require().NotNil(result.StructuredContent)
+	text := result.Content[0].(*mcp.TextContent).Text
+	var report map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(text), &report))
+	return text, report
+}
+
+func (s *CertificatesSuite) TestCertificatesCheck() {
+	s.InitMcpClient()
+	text, report := s.certificatesCheck(map[string]interface{}{})
+	certificates := report["certificates"].([]any)
+	s.Run("returns summary", func() {
+		s.Equal(float64(4), report["total"])
+		s.Equal(float64(1), report["expired"])
+		s.Equal(float64(1), report["expiringSoon"])
+		s.Equal(float64(1), report["invalid"])
+	})
+	s.Run("returns certificates sorted by expiry", func() {
+		s.Require().Len(certificates, 4)
+		var names []string
+		for _, certificate := range certificates {
+			names = append(names, certificate.(map[string]any)["name"].(string))
+		}
+		s.Equal([]string{"expired-tls", "ingress", "policy", "invalid-tls"}, names)
+	})
+	s.Run("returns expired Secret certificate details", func() {
+		expired := certificates[0].(map[string]any)
+		s.Equal("Secret", expired["kind"])
+		s.Equal("tls.crt", expired["location"])
+		s.Equal("Expired", expired["status"])
+		s.Equal("CN=expired.example.com", expired["subject"])
+		s.Equal([]any{"expired.example.com"}, expired["dnsNames"])
+		s.Equal(float64(-2), expired["daysToExpiry"])
+	})
+	s.Run("returns cert-manager Certificate expiring soon", func() {
+		ingress := certificates[1].(map[string]any)
+		s.Equal("Certificate", ingress["kind"])
+		s.Equal("ingress-tls", ingress["location"])
+		s.Equal("ExpiringSoon", ingress["status"])
+		s.Equal("ClusterIssuer/letsencrypt", ingress["issuer"])
+		s.Equal(float64(9), ingress["daysToExpiry"])
+	})
+	s.Run("returns webhook caBundle certificate", func() {
+		policy := certificates[2].(map[string]any)
+		s.Equal("ValidatingWebhookConfiguration", policy["kind"])
+		s.Equal("validate.example.com", policy["location"])
+		s.Equal("Valid", policy["status"])
+	})
+	s.Run("returns invalid certificate", func() {
+		invalid := certificates[3].(map[string]any)
+		s.Equal("Invalid", invalid["status"])
+		s.Equal("no PEM encoded certificate found", invalid["message"])
+	})
+	s.Run("skips API server not served over TLS", func() {
+		s.Require().Len(report["skipped"], 1)
+		s.Contains(report["skipped"].([]any)[0], "apiserver: the API server")
+	})
+	s.Run("never returns private keys", func() {
+		s.NotContains(text, "PRIVATE KEY")
+		s.NotContains(text, string(s.privateKey[40:80]))
+	})
+}
+
+func (s *CertificatesSuite) TestCertificatesCheckArguments() {
+	s.InitMcpClient()
+	s.Run("sources=[webhooks]", func() {
+		_, report := s.certificatesCheck(map[string]interface{}{"sources": []any{"webhooks"}})
+		s.Equal(float64(1), report["total"])
+		s.Nil(report["skipped"])
+	})
+	s.Run("expiring_days=300 reports valid certificates as expiring", func() {
+		_, report := s.certificatesCheck(map[string]interface{}{"sources": []any{"webhooks"}, "expiring_days": 300})
+		s.Equal(float64(1), report["expiringSoon"])
+	})
+	s.Run("unsupported source returns error", func() {
+		result, err := s.CallTool("certificates_check", map[string]interface{}{"sources": []any{"configmaps"}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(result.IsError, "call tool should fail")
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, "unsupported certificate source: configmaps")
+	})
+}
+
+func TestCertificates(t *testing.T) {
+	suite.Run(t, new(CertificatesSuite))
+}
@@ -0,0 +1,344 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type ClusterHealthSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ClusterHealthSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists[1].APIResources = append(discovery.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "daemonsets", Kind: "DaemonSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists = append(discovery.APIResourceLists, metav1.APIResourceList{
+		GroupVersion: "policy/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "selfsubjectaccessreviews", Kind: "SelfSubjectAccessReview", Verbs: metav1.Verbs{"create"}},
+		},
+	})
+	s.mockServer.Handle(discovery)
+	ready := func(status v1.ConditionStatus) []v1.NodeCondition {
+		return []v1.NodeCondition{{Type: v1.NodeReady, Status: status, Message: "kubelet status"}}
+	}
+	objects := map[string]runtime.Object{
+		"/api/v1/namespaces": &v1.NamespaceList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NamespaceList"},
+			Items:    []v1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, {ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}},
+		},
+		"/api/v1/namespaces/default": &v1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		},
+		"/api/v1/nodes": &v1.NodeList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NodeList"},
+			Items: []v1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Status: v1.NodeStatus{Conditions: ready(v1.ConditionTrue)}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Status: v1.NodeStatus{Conditions: ready(v1.ConditionFalse)}},
+			},
+		},
+		"/api/v1/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items: []v1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "running"}, Status: v1.PodStatus{Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: true}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "crashing"}, Status: v1.PodStatus{Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 7,
+						State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off"}}}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "completed"}, Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+			},
+		},
+		"/apis/apps/v1/deployments": &appsv1.DeploymentList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"},
+			Items: []appsv1.Deployment{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "degraded"}, Status: appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 2, UnavailableReplicas: 1},
+					Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "degraded"}}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ready"}, Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1},
+					Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ready"}}}},
+			},
+		},
+		"/apis/apps/v1/statefulsets": &appsv1.StatefulSetList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSetList"},
+			Items: []appsv1.StatefulSet{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"}, Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))}},
+			},
+		},
+		"/apis/apps/v1/daemonsets": &appsv1.DaemonSetList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSetList"},
+		},
+		"/apis/policy/v1/poddisruptionbudgets": &policyv1.PodDisruptionBudgetList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "policy/v1", Kind: "PodDisruptionBudgetList"},
+			Items: []policyv1.PodDisruptionBudget{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ready"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ready"}}},
+			}},
+		},
+		"/api/v1/persistentvolumeclaims": &v1.PersistentVolumeClaimList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaimList"},
+			Items: []v1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"}, Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "logs"}, Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound}},
+			},
+		},
+		"/api/v1/namespaces/default/events": &v1.EventList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"},
+			Items: []v1.Event{
+				{InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "crashing"}, Type: v1.EventTypeWarning, Reason: "BackOff",
+					Message: "Back-off restarting failed container", Count: 4, LastTimestamp: metav1.NewTime(time.Now())},
+				{InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "running"}, Type: v1.EventTypeNormal, Reason: "Started",
+					Message: "Started container", LastTimestamp: metav1.NewTime(time.Now())},
+				{InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "old"}, Type: v1.EventTypeWarning, Reason: "BackOff",
+					Message: "Old event", LastTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+			},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Allow listing resources in all namespaces
+		if req.URL.Path == "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			test.WriteObject(w, &authorizationv1.SelfSubjectAccessReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "authorization.k8s.io/v1", Kind: "SelfSubjectAccessReview"},
+				Status:   authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+			})
+			return
+		}
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ClusterHealthSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ClusterHealthSuite) clusterHealth(arguments map[string]interface{}) map[string]any {
+	result, err := s.CallTool("cluster_health", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	s.Require().NotNil(result.StructuredContent)
+	var health map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &health))
+	return health
+}
+
+// category returns the category with the provided name from the decoded health report
+func (s *ClusterHealthSuite) category(health map[string]any, name string) map[string]any {
+	for _, category := range health["categories"].([]any) {
+		if category.(map[string]any)["name"] == name {
+			return category.(map[string]any)
+		}
+	}
+	s.Failf("category not found", "category %s not found in %v", name, health["categories"])
+	return nil
+}
+
+func (s *ClusterHealthSuite) TestClusterHealth() {
+	s.InitMcpClient()
+	health := s.clusterHealth(map[string]interface{}{})
+	s.Run("returns overall critical status", func() {
+		s.Equal("critical", health["status"])
+		s.Equal(map[string]any{"critical": float64(3), "warning": float64(3)}, health["severities"])
+		s.Equal(false, health["namespaceScoped"])
+		s.Equal(float64(2), health["totalNamespaces"])
+	})
+	s.Run("returns categories in order", func() {
+		var names []string
+		for _, category := range health["categories"].([]any) {
+			names = append(names, category.(map[string]any)["name"].(string))
+		}
+		s.Equal([]string{"Nodes", "Pods", "Deployments", "StatefulSets", "DaemonSets", "PersistentVolumeClaims"}, names)
+	})
+	s.Run("returns critical finding for not ready node", func() {
+		nodes := s.category(health, "Nodes")
+		s.Equal(float64(2), nodes["total"])
+		s.Equal(float64(50), nodes["score"])
+		s.Equal([]any{map[string]any{
+			"severity": "critical", "kind": "Node", "name": "node-2", "status": "NotReady", "issues": []any{"Not ready: kubelet status"},
+		}}, nodes["findings"])
+	})
+	s.Run("returns pod findings with severities", func() {
+		pods := s.category(health, "Pods")
+		s.Equal(float64(4), pods["total"])
+		s.Equal(float64(62), pods["score"])
+		findings := pods["findings"].([]any)
+		s.Require().Len(findings, 2)
+		s.Equal("pending", findings[0].(map[string]any)["name"])
+		s.Equal("warning", findings[0].(map[string]any)["severity"])
+		s.Equal("crashing", findings[1].(map[string]any)["name"])
+		s.Equal("critical", findings[1].(map[string]any)["severity"])
+		s.Equal([]any{"Container waiting: CrashLoopBackOff - back-off", "High restart count: 7"}, findings[1].(map[string]any)["issues"])
+	})
+	s.Run("returns workload findings", func() {
+		deployments := s.category(health, "Deployments")
+		s.Equal(float64(75), deployments["score"])
+		s.Equal("Ready: 2/3", deployments["findings"].([]any)[0].(map[string]any)["status"])
+		statefulSets := s.category(health, "StatefulSets")
+		s.Equal(float64(0), statefulSets["score"])
+		s.Equal("critical", statefulSets["findings"].([]any)[0].(map[string]any)["severity"])
+		daemonSets := s.category(health, "DaemonSets")
+		s.Equal(float64(0), daemonSets["total"])
+		s.Equal(float64(100), daemonSets["score"])
+	})
+	s.Run("returns recent warning events", func() {
+		events := health["events"].(map[string]any)
+		s.Equal(float64(1), events["warnings"])
+		s.Require().Len(events["items"], 1)
+		s.Equal("crashing", events["items"].([]any)[0].(map[string]any)["name"])
+	})
+	s.Run("returns average score", func() {
+		// (50 + 62 + 75 + 0 + 100 + 75) / 6
+		s.Equal(float64(60), health["score"])
+	})
+}
+
+func (s *ClusterHealthSuite) TestClusterHealthWithoutEvents() {
+	s.InitMcpClient()
+	health := s.clusterHealth(map[string]interface{}{"check_events": false})
+	s.NotContains(health, "events")
+}
+
+func (s *ClusterHealthSuite) TestClusterHealthMissingNamespace() {
+	s.InitMcpClient()
+	health := s.clusterHealth(map[string]interface{}{"namespace": "missing"})
+	s.Equal("Namespace 'missing' not found or not accessible. Showing cluster-wide information instead.", health["namespaceWarning"])
+	s.Equal("missing", health["targetNamespace"])
+	s.Equal(false, health["namespaceScoped"])
+}
+
+func (s *ClusterHealthSuite) TestClusterHealthDeclaredRules() {
+	kubeConfig := s.Cfg.KubeConfig
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		[[toolset_configs.core.health_rules]]
+		name = "deployment-without-pdb"
+		category = "Deployments"
+		api_version = "apps/v1"
+		kind = "Deployment"
+		expression = """
+		  !related.pdbs.exists(p, p.metadata.namespace == object.metadata.namespace &&
+		    p.spec.selector.matchLabels == object.spec.selector.matchLabels)"""
+		message = "No PodDisruptionBudget selects the Deployment"
+		[toolset_configs.core.health_rules.related]
+		pdbs = { api_version = "policy/v1", kind = "PodDisruptionBudget" }
+
+		[[toolset_configs.core.health_rules]]
+		name = "completed-pods"
+		category = "Pods"
+		api_version = "v1"
+		kind = "Pod"
+		expression = "object.status.phase == 'Succeeded'"
+		message_expression = "'Pod ' + object.metadata.name + ' completed and can be cleaned up'"
+
+		[[toolset_configs.core.health_rules]]
+		name = "certificate-expiring"
+		severity = "critical"
+		api_version = "cert-manager.io/v1"
+		kind = "Certificate"
+		expression = "timestamp(object.status.notAfter) - now < duration('336h')"
+
+		[[toolset_configs.core.health_rules]]
+		name = "statefulsets"
+		category = "StatefulSets"
+		api_version = "apps/v1"
+		kind = "StatefulSet"
+		expression = "false"
+	`)))
+	s.Cfg.KubeConfig = kubeConfig
+	s.InitMcpClient()
+	health := s.clusterHealth(map[string]interface{}{"check_events": false})
+	s.Run("merges declared rule findings in existing category", func() {
+		deployments := s.category(health, "Deployments")
+		s.Equal(float64(2), deployments["total"])
+		findings := deployments["findings"].([]any)
+		s.Require().Len(findings, 2)
+		s.Equal(map[string]any{
+			"severity": "warning", "kind": "Deployment", "namespace": "default", "name": "degraded",
+			"issues": []any{"No PodDisruptionBudget selects the Deployment"},
+		}, findings[1])
+	})
+	s.Run("evaluates message expression", func() {
+		findings := s.category(health, "Pods")["findings"].([]any)
+		s.Require().Len(findings, 3)
+		s.Equal([]any{"Pod completed completed and can be cleaned up"}, findings[2].(map[string]any)["issues"])
+	})
+	s.Run("declared rule replaces registered rule with the same name", func() {
+		statefulSets := s.category(health, "StatefulSets")
+		s.Equal(float64(1), statefulSets["total"])
+		s.Empty(statefulSets["findings"])
+		s.Equal(float64(100), statefulSets["score"])
+	})
+	s.Run("skips rules for kinds not available in the cluster", func() {
+		for _, category := range health["categories"].([]any) {
+			s.NotEqual("certificate-expiring", category.(map[string]any)["name"])
+		}
+	})
+}
+
+func (s *ClusterHealthSuite) TestClusterHealthDeclaredRuleCostLimit() {
+	kubeConfig := s.Cfg.KubeConfig
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		[[toolset_configs.core.health_rules]]
+		name = "expensive"
+		api_version = "v1"
+		kind = "Pod"
+		expression = """
+		  [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(a, [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(b,
+		    [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(c, [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(d,
+		      [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(e, [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(f,
+		        [0, 1, 2, 3, 4, 5, 6, 7, 8, 9].all(g, true)))))))"""
+	`)))
+	s.Cfg.KubeConfig = kubeConfig
+	s.InitMcpClient()
+	health := s.clusterHealth(map[string]interface{}{"check_events": false})
+	s.Run("reports rule exceeding the cost limit as rule error", func() {
+		s.Require().Len(health["ruleErrors"], 1)
+		ruleError := health["ruleErrors"].([]any)[0].(map[string]any)
+		s.Equal("expensive", ruleError["rule"])
+		s.Contains(ruleError["error"], "actual cost limit exceeded")
+	})
+	s.Run("doesn't report findings for rule exceeding the cost limit", func() {
+		for _, category := range health["categories"].([]any) {
+			s.NotEqual("expensive", category.(map[string]any)["name"])
+		}
+	})
+}
+
+func TestClusterHealth(t *testing.T) {
+	suite.Run(t, new(ClusterHealthSuite))
+}
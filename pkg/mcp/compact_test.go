@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type CompactSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *CompactSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	pod := v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "web",
+			UID:             "0b5c1b1e-7e5a-4c3a-9d8e-1f2a3b4c5d6e",
+			ResourceVersion: "1337",
+			Labels:          map[string]string{"app": "web"},
+			Annotations:     map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"v1","kind":"Pod"}`},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "nginx", TerminationMessagePath: "/dev/termination-log"}}},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "main", Ready: true, ImageID: "docker.io/library/nginx@sha256:0123456789abcdef"}},
+		},
+	}
+	objects := map[string]runtime.Object{
+		"/api/v1/namespaces/default/pods/web": &pod,
+		"/api/v1/namespaces/default/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items:    []v1.Pod{pod},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *CompactSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CompactSuite) callTool(name string, arguments map[string]interface{}) (string, []unstructured.Unstructured) {
+	toolResult, err := s.CallTool(name, arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	out := toolResult.Content[0].(*mcp.TextContent).Text
+	var decoded []unstructured.Unstructured
+	if err = yaml.Unmarshal([]byte(out), &decoded); err != nil {
+		var single unstructured.Unstructured
+		s.Require().NoErrorf(yaml.Unmarshal([]byte(out), &single.Object), "invalid tool result content %s", out)
+		decoded = []unstructured.Unstructured{single}
+	}
+	return out, decoded
+}
+
+func (s *CompactSuite) TestCompact() {
+	s.InitMcpClient()
+	for name, arguments := range map[string]map[string]interface{}{
+		"pods_get":       {"namespace": "default", "name": "web"},
+		"resources_get":  {"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "web"},
+		"resources_list": {"apiVersion": "v1", "kind": "Pod", "namespace": "default", "output": "yaml"},
+	} {
+		s.Run(name+" removes noise fields", func() {
+			out, decoded := s.callTool(name, arguments)
+			s.Require().Len(decoded, 1)
+			pod := decoded[0]
+			s.Equal("web", pod.GetName())
+			s.Equal(map[string]string{"app": "web"}, pod.GetLabels())
+			s.Empty(pod.GetUID(), "uid should be removed")
+			s.Empty(pod.GetResourceVersion(), "resourceVersion should be removed")
+			s.Nil(pod.GetAnnotations(), "last-applied-configuration annotation should be removed")
+			s.NotContains(out, "managedFields")
+			s.NotContains(out, "imageID")
+			s.NotContains(out, "terminationMessagePath")
+			s.Contains(out, "phase: Running")
+		})
+		s.Run(name+"(verbose=true) returns the full object", func() {
+			verboseArguments := map[string]interface{}{"verbose": true}
+			for k, v := range arguments {
+				verboseArguments[k] = v
+			}
+			out, decoded := s.callTool(name, verboseArguments)
+			s.Require().Len(decoded, 1)
+			s.Equal("1337", decoded[0].GetResourceVersion())
+			s.Contains(decoded[0].GetAnnotations(), "kubectl.kubernetes.io/last-applied-configuration")
+			s.Contains(out, "imageID: docker.io/library/nginx@sha256:0123456789abcdef")
+			s.NotContains(out, "managedFields", "managedFields are always removed")
+		})
+	}
+}
+
+func (s *CompactSuite) TestCompactPruneFieldsConfigured() {
+	kubeConfig := s.Cfg.KubeConfig
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		[toolset_configs.core]
+		compact_prune_fields = [ "status" ]
+	`)))
+	s.Cfg.KubeConfig = kubeConfig
+	s.InitMcpClient()
+	s.Run("pods_get removes the configured fields only", func() {
+		out, decoded := s.callTool("pods_get", map[string]interface{}{"namespace": "default", "name": "web"})
+		s.Require().Len(decoded, 1)
+		s.NotContains(out, "phase: Running")
+		s.Equal("1337", decoded[0].GetResourceVersion())
+	})
+}
+
+func TestCompact(t *testing.T) {
+	suite.Run(t, new(CompactSuite))
+}
@@ -0,0 +1,216 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// TargetsParameterName is the parameter of the multi-target tools selecting the targets to run the tool in
+const TargetsParameterName = "targets"
+
+// AllTargets is the targets parameter value selecting all the targets of the provider
+const AllTargets = "*"
+
+const (
+	defaultFanOutConcurrency = 5
+	defaultFanOutTimeout     = 30 * time.Second
+)
+
+func (c *Configuration) FanOutConcurrency() int {
+	if c.StaticConfig.FanOutConcurrency > 0 {
+		return c.StaticConfig.FanOutConcurrency
+	}
+	return defaultFanOutConcurrency
+}
+
+func (c *Configuration) FanOutTimeout() time.Duration {
+	if c.StaticConfig.FanOutTimeoutSeconds > 0 {
+		return time.Duration(c.StaticConfig.FanOutTimeoutSeconds) * time.Second
+	}
+	return defaultFanOutTimeout
+}
+
+// WithTargetsParameter adds the targets parameter to the input schema of the multi-target tools
+func WithTargetsParameter(targetParameterName string, targets []string) ToolMutator {
+	return func(tool api.ServerTool) api.ServerTool {
+		if !tool.IsMultiTarget() || !tool.IsClusterAware() || len(targets) <= 1 {
+			return tool
+		}
+		if tool.Tool.InputSchema == nil {
+			tool.Tool.InputSchema = &jsonschema.Schema{Type: "object"}
+		}
+		if tool.Tool.InputSchema.Properties == nil {
+			tool.Tool.InputSchema.Properties = make(map[string]*jsonschema.Schema)
+		}
+		tool.Tool.InputSchema.Properties[TargetsParameterName] = &jsonschema.Schema{
+			Type: "array",
+			Description: fmt.Sprintf("Optional list of %ss to run the tool in concurrently, or [\"%s\"] for all the %ss (takes precedence over %s). "+
+				"The results are merged with a Target column (or the %s annotation) and the %ss that failed are reported after the results",
+				targetParameterName, AllTargets, targetParameterName, targetParameterName, output.TargetAnnotation, targetParameterName),
+			Items: &jsonschema.Schema{Type: "string"},
+		}
+		return tool
+	}
+}
+
+// isFanOut returns true if the tool call requests to run a multi-target tool in several targets
+func isFanOut(tool api.ServerTool, request *ToolCallRequest) bool {
+	if !tool.IsMultiTarget() {
+		return false
+	}
+	_, ok := request.GetArguments()[TargetsParameterName]
+	return ok
+}
+
+// resolveTargets returns the targets selected by the targets parameter
+func (s *Server) resolveTargets(ctx context.Context, request *ToolCallRequest) ([]string, error) {
+	values, ok := request.GetArguments()[TargetsParameterName].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of strings", TargetsParameterName)
+	}
+	targets := make([]string, 0, len(values))
+	for _, value := range values {
+		target, ok := value.(string)
+		if !ok || target == "" {
+			return nil, fmt.Errorf("%s must be an array of non-empty strings", TargetsParameterName)
+		}
+		if target == AllTargets {
+			return s.p.GetTargets(ctx)
+		}
+		selected, err := s.expandTarget(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range selected {
+			if !slices.Contains(targets, t) {
+				targets = append(targets, t)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%s must not be empty", TargetsParameterName)
+	}
+	return targets, nil
+}
+
+// collectingOutput captures the object printed by a tool handler so that the results of several targets can be merged
+type collectingOutput struct {
+	output.Output
+	obj runtime.Unstructured
+}
+
+func (c *collectingOutput) PrintObj(obj runtime.Unstructured) (string, error) {
+	c.obj = obj
+	return "", nil
+}
+
+// fanOut runs the multi-target tool in the requested targets with a bounded concurrency and a timeout per target,
+// and merges their results. The targets that fail are reported after the merged results.
+func (s *Server) fanOut(ctx context.Context, tool api.ServerTool, request *ToolCallRequest) *api.ToolCallResult {
+	// The pages of the targets can't be merged, their continue tokens would be lost
+	for _, pagination := range []string{"limit", "continue"} {
+		if _, ok := request.GetArguments()[pagination]; ok {
+			return api.NewToolCallResult("", fmt.Errorf("failed to run %s, %s is not supported with %s", tool.Tool.Name, pagination, TargetsParameterName))
+		}
+	}
+	targets, err := s.resolveTargets(ctx, request)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run %s, %w", tool.Tool.Name, err))
+	}
+	listOutput := s.configuration.ListOutput()
+	if spec, ok := request.GetArguments()["output"].(string); ok && spec != "" {
+		if listOutput, err = output.FromSpec(spec); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to run %s, %w", tool.Tool.Name, err))
+		}
+	}
+	// The handler of each target prints with the collectingOutput, the output and target arguments are not needed
+	arguments := maps.Clone(request.GetArguments())
+	delete(arguments, TargetsParameterName)
+	delete(arguments, s.p.GetTargetParameterName())
+	delete(arguments, "output")
+
+	objs := make([]runtime.Unstructured, len(targets))
+	errs := make([]error, len(targets))
+	g := errgroup.Group{}
+	g.SetLimit(s.configuration.FanOutConcurrency())
+	for i, target := range targets {
+		g.Go(func() error {
+			objs[i], errs[i] = s.callTarget(ctx, tool, &ToolCallRequest{Name: request.Name, arguments: arguments}, target, listOutput)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var succeeded []string
+	var results []runtime.Unstructured
+	var failed []string
+	for i, target := range targets {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", target, errs[i]))
+			continue
+		}
+		succeeded = append(succeeded, target)
+		results = append(results, objs[i])
+	}
+	if len(succeeded) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run %s in all %ss:\n%s", tool.Tool.Name, s.p.GetTargetParameterName(), strings.Join(failed, "\n")))
+	}
+	merged, err := output.MergeTargets(succeeded, results)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to merge %s results: %w", tool.Tool.Name, err))
+	}
+	ret, err := listOutput.PrintObj(merged)
+	if err != nil || len(failed) == 0 {
+		return api.NewToolCallResult(ret, err)
+	}
+	ret = strings.TrimSuffix(ret, "\n") + fmt.Sprintf("\n# Failed %ss (%d/%d):\n", s.p.GetTargetParameterName(), len(failed), len(targets))
+	for _, f := range failed {
+		ret += "# - " + f + "\n"
+	}
+	return api.NewToolCallResult(ret, nil)
+}
+
+// callTarget runs the tool handler in the target and returns the object printed by the handler
+func (s *Server) callTarget(ctx context.Context, tool api.ServerTool, request *ToolCallRequest, target string, listOutput output.Output) (runtime.Unstructured, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.configuration.FanOutTimeout())
+	defer cancel()
+	ctx, err := s.targetContext(ctx, tool, target)
+	if err != nil {
+		return nil, err
+	}
+	k, err := s.p.GetDerivedKubernetes(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	collector := &collectingOutput{Output: listOutput}
+	result, err := tool.Handler(api.ToolHandlerParams{
+		Context:                ctx,
+		ExtendedConfigProvider: s.configuration,
+		KubernetesClient:       k,
+		ToolCallRequest:        request,
+		ListOutput:             collector,
+		Targets:                targetClients{s: s, tool: tool},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if collector.obj == nil {
+		return nil, errors.New("no result")
+	}
+	return collector.obj, nil
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type FanOutSuite struct {
+	BaseMcpSuite
+	mockServers map[string]*test.MockServer
+}
+
+// fanOutTestServer returns a mock server with a single pod named after the cluster
+func fanOutTestServer(cluster string) *test.MockServer {
+	mockServer := test.NewMockServer()
+	mockServer.Handle(test.NewDiscoveryClientHandler())
+	pod := v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-" + cluster},
+	}
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/pods" && req.URL.Path != "/api/v1/namespaces/default/pods" {
+			return
+		}
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			raw, _ := json.Marshal(&pod)
+			test.WriteObject(w, &metav1.Table{
+				TypeMeta:          metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+				ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}},
+				Rows:              []metav1.TableRow{{Cells: []interface{}{pod.Name}, Object: runtime.RawExtension{Raw: raw}}},
+			})
+			return
+		}
+		test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{pod}})
+	}))
+	return mockServer
+}
+
+func (s *FanOutSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServers = map[string]*test.MockServer{"fake": fanOutTestServer("prod"), "staging": fanOutTestServer("staging")}
+	kubeconfig := s.mockServers["fake"].Kubeconfig()
+	staging := s.mockServers["staging"].Kubeconfig()
+	kubeconfig.Clusters["staging"] = staging.Clusters["fake"]
+	kubeconfig.AuthInfos["staging"] = staging.AuthInfos["fake"]
+	kubeconfig.Contexts["staging"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	// Context pointing to an unreachable cluster
+	kubeconfig.Clusters["unreachable"] = &clientcmdapi.Cluster{Server: "http://127.0.0.1:1"}
+	kubeconfig.Contexts["unreachable"] = &clientcmdapi.Context{Cluster: "unreachable", AuthInfo: "fake"}
+	s.Cfg.KubeConfig = test.KubeconfigFile(s.T(), kubeconfig)
+	s.Cfg.FanOutTimeoutSeconds = 5
+}
+
+func (s *FanOutSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	for _, mockServer := range s.mockServers {
+		mockServer.Close()
+	}
+}
+
+func (s *FanOutSuite) TestFanOut() {
+	s.InitMcpClient()
+	s.Run("pods_list(targets) merges the results with a target column", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"fake-context", "staging"}, "output": "csv"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("NAMESPACE,TARGET,APIVERSION,KIND,NAME\n"+
+			"default,fake-context,v1,Pod,pod-prod\n"+
+			"default,staging,v1,Pod,pod-staging\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_list_in_namespace(targets) with yaml output annotates the items with their target", func() {
+		toolResult, err := s.CallTool("pods_list_in_namespace", map[string]interface{}{
+			"namespace": "default", "targets": []string{"staging", "fake-context"}, "output": "json",
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		var decoded []unstructured.Unstructured
+		s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 2)
+		s.Equal("pod-staging", decoded[0].GetName())
+		s.Equal("staging", decoded[0].GetAnnotations()["kubernetes-mcp-server/target"])
+		s.Equal("pod-prod", decoded[1].GetName())
+		s.Equal("fake-context", decoded[1].GetAnnotations()["kubernetes-mcp-server/target"])
+	})
+	s.Run("resources_list(targets=[*]) reports the failed targets", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "targets": []string{"*"}, "output": "name",
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		out := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(out, "pod/pod-prod\n")
+		s.Contains(out, "pod/pod-staging\n")
+		s.Contains(out, "# Failed contexts (1/3):\n# - unreachable: ")
+	})
+	s.Run("pods_list(targets) fails if all the targets fail", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"unreachable"}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to run pods_list in all contexts:\nunreachable: ")
+	})
+	s.Run("pods_list(targets=[]) returns error", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to run pods_list, targets must not be empty")
+	})
+	s.Run("pods_list(targets, continue) returns error", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"*"}, "continue": "token"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "continue is not supported with targets")
+	})
+	s.Run("pods_list(targets, limit) returns error", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"*"}, "limit": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "limit is not supported with targets")
+	})
+}
+
+func (s *FanOutSuite) TestTargetsParameter() {
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	for _, tool := range tools.Tools {
+		properties := tool.InputSchema.(map[string]any)["properties"].(map[string]any)
+		switch tool.Name {
+		case "pods_list", "pods_list_in_namespace", "resources_list", "namespaces_list":
+			s.Contains(properties, "targets", "expected %s to have the targets parameter", tool.Name)
+		default:
+			s.NotContains(properties, "targets", "expected %s not to have the targets parameter", tool.Name)
+		}
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	suite.Run(t, new(FanOutSuite))
+}
@@ -39,8 +39,23 @@ func ServerToolToGoSdkTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolH
 		if err != nil {
 			return nil, fmt.Errorf("%v for tool %s", err, tool.Tool.Name)
 		}
+		// run the tool in each of the targets specified in the request and merge the results
+		if isFanOut(tool, toolCallRequest) {
+			result := s.fanOut(ctx, tool, toolCallRequest)
+			if result.Error != nil {
+				mcplog.HandleK8sError(ctx, result.Error, tool.Tool.Name)
+			}
+			return NewStructuredResult(result.Content, result.StructuredContent, result.Error), nil
+		}
 		// get the correct derived Kubernetes client for the target specified in the request
-		cluster := toolCallRequest.GetString(s.p.GetTargetParameterName(), s.p.GetDefaultTarget())
+		cluster, err := s.resolveTarget(ctx, toolCallRequest.GetString(s.p.GetTargetParameterName(), s.p.GetDefaultTarget()))
+		if err != nil {
+			return nil, err
+		}
+		ctx, err = s.targetContext(ctx, tool, cluster)
+		if err != nil {
+			return NewStructuredResult("", nil, err), nil
+		}
 		k, err := s.p.GetDerivedKubernetes(ctx, cluster)
 		if err != nil {
 			return nil, err
@@ -52,6 +67,7 @@ func ServerToolToGoSdkTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolH
 			KubernetesClient:       k,
 			ToolCallRequest:        toolCallRequest,
 			ListOutput:             s.configuration.ListOutput(),
+			Targets:                targetClients{s: s, tool: tool},
 		})
 		if err != nil {
 			return nil, err
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ListOutputSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func listOutputTestTable(objects ...metav1.Object) *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "IP", Type: "string", Priority: 1},
+		},
+	}
+	for _, o := range objects {
+		raw, _ := json.Marshal(&metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadata"},
+			ObjectMeta: metav1.ObjectMeta{Name: o.GetName(), Namespace: o.GetNamespace()},
+		})
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []interface{}{o.GetName(), "Running|Ready", "10.0.0.1"},
+			Object: runtime.RawExtension{Raw: raw},
+		})
+	}
+	return table
+}
+
+func (s *ListOutputSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	pods := []v1.Pod{
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+		},
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"},
+			Spec:       v1.PodSpec{NodeName: "node-2"},
+		},
+	}
+	namespaces := []v1.Namespace{
+		{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}, ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}, ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+	objects := map[string]runtime.Object{
+		"/api/v1/pods":                    &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: pods},
+		"/api/v1/namespaces/default/pods": &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: pods},
+		"/api/v1/namespaces":              &v1.NamespaceList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NamespaceList"}, Items: namespaces},
+	}
+	tables := map[string]runtime.Object{
+		"/api/v1/pods":                    listOutputTestTable(&pods[0], &pods[1]),
+		"/api/v1/namespaces/default/pods": listOutputTestTable(&pods[0], &pods[1]),
+		"/api/v1/namespaces":              listOutputTestTable(&namespaces[0], &namespaces[1]),
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			if table, ok := tables[req.URL.Path]; ok {
+				test.WriteObject(w, table)
+			}
+			return
+		}
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ListOutputSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ListOutputSuite) callList(name string, arguments map[string]interface{}) string {
+	toolResult, err := s.CallTool(name, arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	return toolResult.Content[0].(*mcp.TextContent).Text
+}
+
+func (s *ListOutputSuite) TestListOutput() {
+	s.InitMcpClient()
+	s.Run("pods_list(output=name) returns the resource names", func() {
+		out := s.callList("pods_list", map[string]interface{}{"output": "name"})
+		s.Equal("pod/web\npod/db\n", out)
+	})
+	s.Run("pods_list(output=json) returns a json array", func() {
+		out := s.callList("pods_list", map[string]interface{}{"output": "json"})
+		var decoded []map[string]any
+		s.Require().NoErrorf(json.Unmarshal([]byte(out), &decoded), "invalid json %s", out)
+		s.Len(decoded, 2)
+		s.NotContains(out, "\n", "json output should be compact")
+	})
+	s.Run("pods_list(output=csv) returns the default columns of the server-side table", func() {
+		out := s.callList("pods_list", map[string]interface{}{"output": "csv"})
+		s.Equal("NAMESPACE,APIVERSION,KIND,NAME,STATUS\n"+
+			"default,v1,Pod,web,Running|Ready\n"+
+			"default,v1,Pod,db,Running|Ready\n", out)
+	})
+	s.Run("pods_list_in_namespace(output=custom-columns) returns the requested columns", func() {
+		out := s.callList("pods_list_in_namespace", map[string]interface{}{
+			"namespace": "default", "output": "custom-columns=NAME:.metadata.name,NODE:.spec.nodeName",
+		})
+		s.Regexp("NAME\\s+NODE\n", out)
+		s.Regexp("web\\s+node-1\n", out)
+		s.Regexp("db\\s+node-2\n", out)
+	})
+	s.Run("resources_list(output=jsonpath) returns the matching fields", func() {
+		out := s.callList("resources_list", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "output": "jsonpath={.items[*].spec.nodeName}",
+		})
+		s.Equal("node-1 node-2", out)
+	})
+	s.Run("namespaces_list(output=markdown-table) returns a markdown table with escaped cells", func() {
+		out := s.callList("namespaces_list", map[string]interface{}{"output": "markdown-table"})
+		s.Equal("| APIVERSION | KIND | NAME | STATUS |\n"+
+			"|---|---|---|---|\n"+
+			"| v1 | Namespace | default | Running\\|Ready |\n"+
+			"| v1 | Namespace | kube-system | Running\\|Ready |\n", out)
+	})
+	s.Run("namespaces_list(output=invalid) returns error", func() {
+		toolResult, err := s.CallTool("namespaces_list", map[string]interface{}{"output": "xml"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to list namespaces, invalid output: xml")
+	})
+	s.Run("resources_list(output=jsonpath) with invalid template returns error", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "output": "jsonpath={.items[",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to list resources, invalid jsonpath template")
+	})
+}
+
+func TestListOutput(t *testing.T) {
+	suite.Run(t, new(ListOutputSuite))
+}
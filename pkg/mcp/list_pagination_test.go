@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ListPaginationSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	requests   []string
+}
+
+func (s *ListPaginationSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requests = nil
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-3"}},
+	}
+	// Serves the pods page by page, the continue token is the index of the next pod
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/pods" && req.URL.Path != "/api/v1/namespaces/default/pods" && req.URL.Path != "/api/v1/namespaces" {
+			return
+		}
+		s.requests = append(s.requests, req.URL.Query().Encode())
+		start, _ := strconv.Atoi(req.URL.Query().Get("continue"))
+		end := len(pods)
+		if limit, _ := strconv.Atoi(req.URL.Query().Get("limit")); limit > 0 && start+limit < end {
+			end = start + limit
+		}
+		listMeta := metav1.ListMeta{}
+		if end < len(pods) {
+			listMeta.Continue = strconv.Itoa(end)
+			listMeta.RemainingItemCount = ptr.To(int64(len(pods) - end))
+		}
+		if req.URL.Path == "/api/v1/namespaces" {
+			list := &v1.NamespaceList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NamespaceList"}, ListMeta: listMeta}
+			for _, pod := range pods[start:end] {
+				list.Items = append(list.Items, v1.Namespace{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}, ObjectMeta: metav1.ObjectMeta{Name: "ns-" + pod.Name}})
+			}
+			test.WriteObject(w, list)
+			return
+		}
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			table := &metav1.Table{
+				TypeMeta:          metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+				ListMeta:          listMeta,
+				ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}},
+			}
+			for _, pod := range pods[start:end] {
+				table.Rows = append(table.Rows, metav1.TableRow{Cells: []interface{}{pod.Name}})
+			}
+			test.WriteObject(w, table)
+			return
+		}
+		list := &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, ListMeta: listMeta}
+		for _, pod := range pods[start:end] {
+			pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+			list.Items = append(list.Items, pod)
+		}
+		test.WriteObject(w, list)
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ListPaginationSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ListPaginationSuite) callList(name string, arguments map[string]interface{}) string {
+	toolResult, err := s.CallTool(name, arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	return toolResult.Content[0].(*mcp.TextContent).Text
+}
+
+func (s *ListPaginationSuite) TestListPagination() {
+	s.InitMcpClient()
+	s.Run("pods_list(limit=2) returns the first page and the continue token", func() {
+		out := s.callList("pods_list", map[string]interface{}{"limit": 2, "output": "name"})
+		s.Equal("pod/pod-1\npod/pod-2\n# More results available (1 remaining items), call again with continue: 2\n", out)
+		s.Contains(s.requests[len(s.requests)-1], "limit=2")
+	})
+	s.Run("pods_list(limit=2, continue) returns the last page without continue token", func() {
+		out := s.callList("pods_list", map[string]interface{}{"limit": 2, "continue": "2", "output": "name"})
+		s.Equal("pod/pod-3\n", out)
+		s.Contains(s.requests[len(s.requests)-1], "continue=2")
+	})
+	s.Run("pods_list_in_namespace(limit=1) with table output returns the continue token", func() {
+		out := s.callList("pods_list_in_namespace", map[string]interface{}{"namespace": "default", "limit": 1, "output": "csv"})
+		s.Equal("APIVERSION,KIND,NAME\nv1,Pod,pod-1\n# More results available (2 remaining items), call again with continue: 1\n", out)
+		s.Contains(s.requests[len(s.requests)-1], "limit=1")
+	})
+	s.Run("resources_list(limit=1, continue) threads the options", func() {
+		out := s.callList("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "limit": 1, "continue": "1", "output": "name"})
+		s.Equal("pod/pod-2\n# More results available (1 remaining items), call again with continue: 2\n", out)
+	})
+	s.Run("namespaces_list(limit=3) returns all the items without continue token", func() {
+		out := s.callList("namespaces_list", map[string]interface{}{"limit": 3, "output": "name"})
+		s.Equal("namespace/ns-pod-1\nnamespace/ns-pod-2\nnamespace/ns-pod-3\n", out)
+	})
+	s.Run("pods_list without limit doesn't paginate", func() {
+		out := s.callList("pods_list", map[string]interface{}{"output": "name"})
+		s.Equal("pod/pod-1\npod/pod-2\npod/pod-3\n", out)
+		s.NotContains(s.requests[len(s.requests)-1], "limit")
+	})
+	s.Run("pods_list(limit=0) returns error", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"limit": 0})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to list pods in all namespaces, limit must be greater than 0")
+	})
+}
+
+func TestListPagination(t *testing.T) {
+	suite.Run(t, new(ListPaginationSuite))
+}
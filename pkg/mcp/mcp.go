@@ -66,11 +66,52 @@ type Server struct {
 	configuration  *Configuration
 	server         *mcp.Server
 	enabledTools   []string
+	toolsets       map[string]api.Toolset // toolset of each enabled tool
 	enabledPrompts []string
 	p              internalk8s.Provider
 	metrics        *metrics.Metrics // Metrics collection system
 }
 
+// targetClients exposes the derived clients of the provider targets to the tool handlers
+type targetClients struct {
+	s    *Server
+	tool api.ServerTool
+}
+
+var _ api.KubernetesClientProvider = targetClients{}
+
+func (t targetClients) GetDefaultTarget() string {
+	return t.s.p.GetDefaultTarget()
+}
+
+func (t targetClients) GetTargetParameterName() string {
+	return t.s.p.GetTargetParameterName()
+}
+
+func (t targetClients) GetKubernetesClient(ctx context.Context, target string) (api.KubernetesClient, error) {
+	k, err := t.s.p.GetDerivedKubernetes(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (t targetClients) GetTargetClient(ctx context.Context, value string) (*api.TargetClient, error) {
+	target, err := t.s.resolveTarget(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = t.s.targetOverridesContext(ctx, t.tool, target)
+	if err != nil {
+		return nil, err
+	}
+	k, err := t.s.p.GetDerivedKubernetes(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TargetClient{Context: ctx, KubernetesClient: k, Target: target}, nil
+}
+
 func NewServer(configuration Configuration, targetProvider internalk8s.Provider) (*Server, error) {
 	s := &Server{
 		configuration: &configuration,
@@ -99,13 +140,17 @@ func NewServer(configuration Configuration, targetProvider internalk8s.Provider)
 		ServiceName:    version.BinaryName,
 		ServiceVersion: version.Version,
 		Telemetry:      &configuration.Telemetry,
+		DerivedClientCacheStats: func() metrics.CacheStats {
+			stats := internalk8s.GetDerivedClientCacheStats()
+			return metrics.CacheStats{Hits: stats.Hits, Misses: stats.Misses, Evictions: stats.Evictions}
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 	s.metrics = metricsInstance
 
-	s.server.AddReceivingMiddleware(sessionInjectionMiddleware)
+	s.server.AddReceivingMiddleware(sessionInjectionMiddleware(configuration.Stateless))
 	s.server.AddReceivingMiddleware(traceContextPropagationMiddleware)
 	s.server.AddReceivingMiddleware(tracingMiddleware(version.BinaryName + "/mcp"))
 	s.server.AddReceivingMiddleware(authHeaderPropagationMiddleware)
@@ -113,6 +158,7 @@ func NewServer(configuration Configuration, targetProvider internalk8s.Provider)
 	s.server.AddReceivingMiddleware(toolCallLoggingMiddleware)
 	s.server.AddReceivingMiddleware(s.metricsMiddleware())
 
+	s.configureSessionLimits()
 	err = s.reloadToolsets()
 	if err != nil {
 		return nil, err
@@ -122,6 +168,14 @@ func NewServer(configuration Configuration, targetProvider internalk8s.Provider)
 	return s, nil
 }
 
+// configureSessionLimits applies the configured limits to the exec sessions and port forwards registries
+func (s *Server) configureSessionLimits() {
+	internalk8s.ExecSessions.Configure(
+		time.Duration(s.configuration.ExecSessionIdleTimeoutSeconds)*time.Second, s.configuration.ExecSessionsMax)
+	internalk8s.PortForwards.Configure(
+		time.Duration(s.configuration.PortForwardTTLSeconds)*time.Second, s.configuration.PortForwardsMax)
+}
+
 func (s *Server) reloadToolsets() error {
 	ctx := context.Background()
 
@@ -134,7 +188,7 @@ func (s *Server) reloadToolsets() error {
 	// s.server.SetTools(tools...)
 
 	// Collect applicable items
-	applicableTools := s.collectApplicableTools(targets)
+	applicableTools, toolsets := s.collectApplicableTools(targets)
 	applicablePrompts := s.collectApplicablePrompts()
 
 	// Read the previous state with read lock - don't hold lock while calling external code
@@ -170,6 +224,7 @@ func (s *Server) reloadToolsets() error {
 	// Only hold write lock for the final assignment
 	s.mu.Lock()
 	s.enabledTools = newTools
+	s.toolsets = toolsets
 	s.enabledPrompts = newPrompts
 	s.mu.Unlock()
 
@@ -213,27 +268,44 @@ func reloadItems[T any](
 	return enabled, nil
 }
 
-// collectApplicableTools returns tools after applying filtering and mutation
-func (s *Server) collectApplicableTools(targets []string) []api.ServerTool {
+// collectApplicableTools returns tools after applying filtering and mutation, and the toolset of each tool
+func (s *Server) collectApplicableTools(targets []string) ([]api.ServerTool, map[string]api.Toolset) {
 	filter := CompositeFilter(
-		s.configuration.isToolApplicable,
 		ShouldIncludeTargetListTool(s.p.GetTargetParameterName(), targets),
+		ShouldIncludeCrossTargetTool(targets),
 	)
 	mutator := ComposeMutators(
 		WithTargetParameter(s.p.GetDefaultTarget(), s.p.GetTargetParameterName(), targets),
-		WithTargetListTool(s.p.GetDefaultTarget(), s.p.GetTargetParameterName(), targets),
+		WithTargetsParameter(s.p.GetTargetParameterName(), targets),
+		WithTargetSelectors(s.p.GetTargetParameterName(), s.configuration.Targets),
+		WithTargetListTool(s.p.GetDefaultTarget(), s.p.GetTargetParameterName(), targets, s.configuration.Targets),
 	)
 
 	tools := make([]api.ServerTool, 0)
-	for _, toolset := range s.configuration.Toolsets() {
+	toolsets := make(map[string]api.Toolset)
+	for _, toolset := range s.configuration.allToolsets() {
 		for _, tool := range toolset.GetTools(s.p) {
 			tool = mutator(tool)
-			if filter(tool) {
-				tools = append(tools, tool)
+			if !filter(tool) {
+				continue
+			}
+			// Tools that don't run in a target, or when no target overrides the configuration, follow the global configuration
+			if !tool.IsClusterAware() || !s.configuration.HasTargetOverrides() {
+				if s.configuration.isToolsetEnabled(toolset) && s.configuration.isToolApplicable(tool) {
+					tools = append(tools, tool)
+					toolsets[tool.Tool.Name] = toolset
+				}
+				continue
+			}
+			deniedTargets := s.configuration.deniedTargets(toolset, tool, targets)
+			if len(deniedTargets) == len(targets) {
+				continue
 			}
+			tools = append(tools, withDeniedTargets(tool, s.p.GetTargetParameterName(), deniedTargets))
+			toolsets[tool.Tool.Name] = toolset
 		}
 	}
-	return tools
+	return tools, toolsets
 }
 
 // collectApplicablePrompts returns prompts after merging toolset and config prompts
@@ -353,6 +425,7 @@ func (s *Server) ReloadConfiguration(newConfig *config.StaticConfig) error {
 	// Clear cached values so they get recomputed
 	s.configuration.listOutput = nil
 	s.configuration.toolsets = nil
+	s.configureSessionLimits()
 
 	// Reload the Kubernetes provider (this will also rebuild tools)
 	if err := s.reloadToolsets(); err != nil {
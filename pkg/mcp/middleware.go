@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/mcplog"
 	"github.com/containers/kubernetes-mcp-server/pkg/telemetry"
@@ -18,16 +19,24 @@ import (
 	"k8s.io/klog/v2"
 )
 
-// sessionInjectionMiddleware injects the MCP session into the context for logging support.
+// sessionInjectionMiddleware injects the MCP session into the context for logging support
+// and for tools that keep per-session state.
+// In stateless mode the session only lasts for the request, so it's not provided to the tools keeping per-session
+// state, which can't scope their state to the client.
 // This middleware should be added first so all subsequent middleware and handlers have access.
-func sessionInjectionMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
-	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
-		if session := req.GetSession(); session != nil {
-			if serverSession, ok := session.(*mcp.ServerSession); ok {
-				ctx = context.WithValue(ctx, mcplog.MCPSessionContextKey, serverSession)
+func sessionInjectionMiddleware(stateless bool) func(mcp.MethodHandler) mcp.MethodHandler {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if session := req.GetSession(); session != nil {
+				if serverSession, ok := session.(*mcp.ServerSession); ok {
+					ctx = context.WithValue(ctx, mcplog.MCPSessionContextKey, serverSession)
+					if !stateless {
+						ctx = api.WithSession(ctx, serverSession)
+					}
+				}
 			}
+			return next(ctx, method, req)
 		}
-		return next(ctx, method, req)
 	}
 }
 
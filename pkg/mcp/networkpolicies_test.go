@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type NetworkPoliciesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func networkPolicyTestPod(namespace, name, ip string, labels map[string]string, ports ...v1.ContainerPort) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "image", Ports: ports}}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: ip},
+	}
+}
+
+func (s *NetworkPoliciesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists = append(discovery.APIResourceLists, metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "networkpolicies", Kind: "NetworkPolicy", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	s.mockServer.Handle(discovery)
+	web := networkPolicyTestPod("default", "web", "10.0.0.1", map[string]string{"app": "web"}, v1.ContainerPort{Name: "http", ContainerPort: 8080})
+	db := networkPolicyTestPod("default", "db", "10.0.0.2", map[string]string{"app": "db"}, v1.ContainerPort{Name: "postgres", ContainerPort: 5432})
+	client := networkPolicyTestPod("default", "client", "10.0.0.3", map[string]string{"app": "client"})
+	prometheus := networkPolicyTestPod("monitoring", "prometheus", "10.0.1.1", map[string]string{"app": "prometheus"})
+	grafana := networkPolicyTestPod("monitoring", "grafana", "10.0.1.2", map[string]string{"app": "grafana"})
+	hostNetwork := networkPolicyTestPod("kube-system", "node-exporter", "192.168.0.1", nil)
+	hostNetwork.Spec.HostNetwork = true
+	namespaces := map[string]*v1.Namespace{}
+	for _, ns := range []string{"default", "monitoring"} {
+		namespaces[ns] = &v1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: ns, Labels: map[string]string{"kubernetes.io/metadata.name": ns}},
+		}
+	}
+	policies := []networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "default-deny-ingress"},
+			Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "allow-web"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+						{
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+							PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromString("http"))}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db-ingress"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From:  []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/24", Except: []string{"10.0.0.3/32"}}}},
+					Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(5000)), EndPort: ptr.To(int32(6000))}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-egress"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To:    []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}}},
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(v1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(5432))}},
+				}},
+			},
+		},
+	}
+	objects := map[string]runtime.Object{
+		"/api/v1/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items:    []v1.Pod{web, db, client, prometheus, grafana, hostNetwork},
+		},
+		"/apis/networking.k8s.io/v1/networkpolicies": &networkingv1.NetworkPolicyList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicyList"},
+			Items:    policies,
+		},
+		"/apis/networking.k8s.io/v1/namespaces/default/networkpolicies": &networkingv1.NetworkPolicyList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicyList"},
+			Items:    policies,
+		},
+		"/apis/networking.k8s.io/v1/namespaces/monitoring/networkpolicies": &networkingv1.NetworkPolicyList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicyList"},
+		},
+		"/api/v1/namespaces/default":    namespaces["default"],
+		"/api/v1/namespaces/monitoring": namespaces["monitoring"],
+	}
+	for _, pod := range []v1.Pod{web, db, client, prometheus} {
+		pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+		objects["/api/v1/namespaces/"+pod.Namespace+"/pods/"+pod.Name] = &pod
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *NetworkPoliciesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NetworkPoliciesSuite) networkPolicyCheck(arguments map[string]interface{}) map[string]any {
+	result, err := s.CallTool("networkpolicy_check", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	var check map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &check))
+	return check
+}
+
+func (s *NetworkPoliciesSuite) TestNetworkPolicyCheck() {
+	s.InitMcpClient()
+	s.Run("networkpolicy_check(client -> web:8080) is allowed by the ingress rule with a named port", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{"source_pod": "client", "destination_pod": "web", "port": "8080"})
+		s.Equal(true, check["allowed"])
+		s.Equal(false, check["egress"].(map[string]any)["isolated"])
+		s.Equal([]any{"default-deny-ingress", "allow-web"}, check["ingress"].(map[string]any)["selectingPolicies"])
+		s.Equal([]any{"allow-web"}, check["ingress"].(map[string]any)["allowedBy"])
+	})
+	s.Run("networkpolicy_check(monitoring/prometheus -> web:http) is allowed by the namespace and pod selector", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{
+			"source_namespace": "monitoring", "source_pod": "prometheus", "destination_namespace": "default", "destination_pod": "web", "port": "http",
+		})
+		s.Equal(true, check["allowed"])
+	})
+	s.Run("networkpolicy_check(client -> db:5432) is denied by the ipBlock except", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{"source_pod": "client", "destination_pod": "db", "port": "5432"})
+		s.Equal(false, check["allowed"])
+		s.Equal(true, check["egress"].(map[string]any)["allowed"])
+		s.Nil(check["ingress"].(map[string]any)["allowedBy"])
+		s.Contains(check["reason"], "denied by the ingress policies of the destination")
+	})
+	s.Run("networkpolicy_check(web -> db:postgres) is allowed by the egress and ingress rules", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{"source_pod": "web", "destination_pod": "db", "port": "postgres"})
+		s.Equal(true, check["allowed"])
+		s.Equal([]any{"web-egress"}, check["egress"].(map[string]any)["allowedBy"])
+		s.Equal([]any{"db-ingress"}, check["ingress"].(map[string]any)["allowedBy"])
+	})
+	s.Run("networkpolicy_check(web -> db:5432/UDP) is denied by the protocol", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{"source_pod": "web", "destination_pod": "db", "port": "5432", "protocol": "UDP"})
+		s.Equal(false, check["allowed"])
+		s.Contains(check["reason"], "denied by both")
+	})
+	s.Run("networkpolicy_check(web -> monitoring/prometheus:9090) is denied by the egress policies", func() {
+		check := s.networkPolicyCheck(map[string]interface{}{
+			"source_pod": "web", "destination_namespace": "monitoring", "destination_pod": "prometheus", "port": "9090",
+		})
+		s.Equal(false, check["allowed"])
+		s.Equal(false, check["ingress"].(map[string]any)["isolated"])
+		s.Contains(check["reason"], "denied by the egress policies of the source")
+	})
+	s.Run("networkpolicy_check(port=unknown) returns error", func() {
+		result, err := s.CallTool("networkpolicy_check", map[string]interface{}{"source_pod": "client", "destination_pod": "web", "port": "metrics"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(result.IsError)
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, "port metrics/TCP is not a port number nor a container port name of pod default/web")
+	})
+}
+
+func (s *NetworkPoliciesSuite) TestNetworkPolicyCoverage() {
+	s.InitMcpClient()
+	result, err := s.CallTool("networkpolicy_coverage", map[string]interface{}{})
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	var report map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &report))
+	s.Run("counts the pods and skips host network pods", func() {
+		s.Equal(float64(5), report["totalPods"])
+		s.Equal(float64(3), report["ingressIsolated"])
+		s.Equal(float64(1), report["egressIsolated"])
+		s.Equal(float64(1), report["skippedHostNetwork"])
+	})
+	s.Run("lists the pods not selected by any policy", func() {
+		s.Equal([]any{
+			map[string]any{"namespace": "monitoring", "name": "grafana", "labels": map[string]any{"app": "grafana"}},
+			map[string]any{"namespace": "monitoring", "name": "prometheus", "labels": map[string]any{"app": "prometheus"}},
+		}, report["uncoveredPods"])
+	})
+	s.Run("summarizes the namespaces", func() {
+		s.Equal([]any{
+			map[string]any{"namespace": "default", "policies": float64(4), "pods": float64(3), "ingressIsolated": float64(3), "egressIsolated": float64(1),
+				"uncovered": float64(0), "defaultDenyIngress": true, "defaultDenyEgress": false},
+			map[string]any{"namespace": "monitoring", "policies": float64(0), "pods": float64(2), "ingressIsolated": float64(0), "egressIsolated": float64(0),
+				"uncovered": float64(2), "defaultDenyIngress": false, "defaultDenyEgress": false},
+		}, report["namespaces"])
+	})
+}
+
+func TestNetworkPolicies(t *testing.T) {
+	suite.Run(t, new(NetworkPoliciesSuite))
+}
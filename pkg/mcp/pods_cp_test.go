@@ -0,0 +1,314 @@
+package mcp
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type PodsCpSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	files      map[string][]byte
+}
+
+func (s *PodsCpSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.files = map[string][]byte{
+		"/etc/app/config.yaml": []byte("key: value\n"),
+		"/tmp/heap.bin":        {0xff, 0xfe, 0x00, 0x01},
+		"/tmp/large.txt":       bytes.Repeat([]byte("a"), 2048),
+		"/etc/app/users.txt":   []byte("owner: jane.doe@example.com\n"),
+	}
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-cp/exec" {
+			return
+		}
+		command := req.URL.Query()["command"]
+		var stdin, stdout, stderr bytes.Buffer
+		opts := &test.StreamOptions{Stdout: &stdout, Stderr: &stderr}
+		if req.URL.Query().Get("stdin") == "true" {
+			opts.Stdin = &stdin
+		}
+		ctx, err := test.CreateHTTPStreams(w, req, opts)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		defer func(conn io.Closer) { _ = conn.Close() }(ctx.Closer)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch strings.Join(command[:2], " ") {
+		case "tar cf": // tar cf - -C dir -- base
+			dir, base := command[4], command[6]
+			tw := tar.NewWriter(ctx.StdoutStream)
+			if dir == "/tmp/" && base == "dir" {
+				_ = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: base + "/", Mode: 0755})
+			} else if content, ok := s.files[dir+base]; ok {
+				_ = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: base, Mode: 0644, Size: int64(len(content))})
+				_, _ = tw.Write(content)
+			}
+			_ = tw.Close()
+		case "tar xmf": // tar xmf - -C dir
+			dir := command[4]
+			tr := tar.NewReader(ctx.StdinStream)
+			for header, err := tr.Next(); err == nil; header, err = tr.Next() {
+				s.files[dir+header.Name], _ = io.ReadAll(tr)
+			}
+		}
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-cp" {
+			return
+		}
+		test.WriteObject(w, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-to-cp",
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "container-to-cp"}}},
+		})
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *PodsCpSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsCpSuite) TestPodsCpFrom() {
+	s.InitMcpClient()
+	s.Run("pods_cp_from(name=pod-to-cp, path=/etc/app/config.yaml)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/etc/app/config.yaml",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns file content", func() {
+			s.Equal("key: value\n", result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/heap.bin, encoding=base64)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/heap.bin",
+			"encoding":  "base64",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns base64 encoded file content", func() {
+			s.Equal(base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00, 0x01}), result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	for _, encoding := range []string{"text", "base64"} {
+		s.Run("pods_cp_from(name=pod-to-cp, path=/etc/app/users.txt, encoding="+encoding+") masks PII", func() {
+			result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+				"namespace": "default",
+				"name":      "pod-to-cp",
+				"path":      "/etc/app/users.txt",
+				"encoding":  encoding,
+			})
+			s.Run("no error", func() {
+				s.Nilf(err, "call tool failed %v", err)
+				s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+			})
+			s.Run("returns masked file content", func() {
+				content := result.Content[0].(*mcp.TextContent).Text
+				if encoding == "base64" {
+					decoded, decodeErr := base64.StdEncoding.DecodeString(content)
+					s.Require().NoError(decodeErr)
+					content = string(decoded)
+				}
+				s.Equal("owner: "+strings.Repeat("*", len("jane.doe@example.com"))+"\n", content)
+			})
+		})
+	}
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/heap.bin) binary file as text", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/heap.bin",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes binary content", func() {
+			s.Equal("failed to copy file /tmp/heap.bin from pod pod-to-cp in namespace default: file contains binary data, use the base64 encoding",
+				result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/large.txt, max_bytes=1024)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/large.txt",
+			"max_bytes": 1024,
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes size limit", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "file /tmp/large.txt size (2048 bytes) exceeds the maximum allowed size (1024 bytes)")
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/dir)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/dir",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes directory", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "/tmp/dir is a directory, only regular files can be copied")
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/missing)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/missing",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes missing file", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "file /tmp/missing not found")
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes invalid path", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "invalid file path \"/tmp/\", a path to a file is required")
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=--to-command=sh)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "--to-command=sh",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes invalid path", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "invalid file path \"--to-command=sh\", paths starting with - are not supported")
+		})
+	})
+	s.Run("pods_cp_from(name=pod-to-cp, path=/tmp/--checkpoint-action=exec=sh)", func() {
+		result, err := s.CallTool("pods_cp_from", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/--checkpoint-action=exec=sh",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes invalid path", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "paths starting with - are not supported")
+		})
+	})
+}
+
+func (s *PodsCpSuite) TestPodsCpTo() {
+	s.InitMcpClient()
+	s.Run("pods_cp_to(name=pod-to-cp, path=/tmp/script.sh, content=text)", func() {
+		result, err := s.CallTool("pods_cp_to", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/script.sh",
+			"content":   "#!/bin/sh\necho hello\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns copied bytes", func() {
+			s.Equal("Copied 21 bytes to /tmp/script.sh in pod pod-to-cp in namespace default", result.Content[0].(*mcp.TextContent).Text)
+		})
+		s.Run("writes file in container", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Equal("#!/bin/sh\necho hello\n", string(s.files["/tmp/script.sh"]))
+		})
+	})
+	s.Run("pods_cp_to(name=pod-to-cp, path=/tmp/data.bin, encoding=base64)", func() {
+		result, err := s.CallTool("pods_cp_to", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/data.bin",
+			"content":   base64.StdEncoding.EncodeToString([]byte{0x00, 0x01, 0x02}),
+			"encoding":  "base64",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("writes decoded file in container", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Equal([]byte{0x00, 0x01, 0x02}, s.files["/tmp/data.bin"])
+		})
+	})
+	s.Run("pods_cp_to(name=pod-to-cp, path=/tmp/data.bin, encoding=base64) with invalid content", func() {
+		result, err := s.CallTool("pods_cp_to", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-cp",
+			"path":      "/tmp/data.bin",
+			"content":   "not base64!",
+			"encoding":  "base64",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes invalid content", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "failed to copy file to pod, invalid base64 content")
+		})
+	})
+}
+
+func TestPodsCp(t *testing.T) {
+	suite.Run(t, new(PodsCpSuite))
+}
@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type PodsDebugSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	pods       map[string]*v1.Pod
+}
+
+func (s *PodsDebugSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.pods = map[string]*v1.Pod{
+		"pod-to-debug": {
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-to-debug"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "distroless"}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+	}
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/exec") {
+			return
+		}
+		var stdout, stderr strings.Builder
+		ctx, err := test.CreateHTTPStreams(w, req, &test.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		defer func(conn io.Closer) { _ = conn.Close() }(ctx.Closer)
+		_, _ = io.WriteString(ctx.StdoutStream, "container:"+req.URL.Query().Get("container")+" command:"+strings.Join(req.URL.Query()["command"], " ")+"\n")
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case req.URL.Path == "/api/v1/nodes/node-1":
+			test.WriteObject(w, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.Method == http.MethodPost:
+			pod := decodePod(req)
+			s.pods[pod.Name] = pod
+			w.WriteHeader(http.StatusCreated)
+			test.WriteObject(w, pod)
+		case strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/") && strings.HasSuffix(req.URL.Path, "/ephemeralcontainers"):
+			pod := decodePod(req)
+			s.pods[pod.Name].Spec.EphemeralContainers = pod.Spec.EphemeralContainers
+			test.WriteObject(w, s.pods[pod.Name])
+		case strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/") && req.Method == http.MethodGet:
+			pod, ok := s.pods[strings.TrimPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/")]
+			if !ok {
+				return
+			}
+			// Containers are running as soon as they're added
+			pod.Status.Phase = v1.PodRunning
+			pod.Status.ContainerStatuses = nil
+			for _, c := range pod.Spec.Containers {
+				pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{Name: c.Name, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}})
+			}
+			pod.Status.EphemeralContainerStatuses = nil
+			for _, c := range pod.Spec.EphemeralContainers {
+				pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses, v1.ContainerStatus{Name: c.Name, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}})
+			}
+			test.WriteObject(w, pod)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *PodsDebugSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsDebugSuite) TestPodsDebug() {
+	s.InitMcpClient()
+	s.Run("pods_debug(name=pod-to-debug, target=app, command=[ps aux])", func() {
+		result, err := s.CallTool("pods_debug", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-debug",
+			"target":    "app",
+			"command":   []interface{}{"ps", "aux"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("adds ephemeral container", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Require().Len(s.pods["pod-to-debug"].Spec.EphemeralContainers, 1)
+			ephemeralContainer := s.pods["pod-to-debug"].Spec.EphemeralContainers[0]
+			s.Equal("busybox:latest", ephemeralContainer.Image)
+			s.Equal("app", ephemeralContainer.TargetContainerName)
+			s.True(ephemeralContainer.Stdin)
+		})
+		s.Run("returns debug container and command output", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Regexp(`# Ephemeral container debugger-\S+ \(busybox:latest\) is running in pod pod-to-debug in namespace default`, text)
+			s.Regexp(`## ps aux\ncontainer:debugger-\S+ command:ps aux\n`, text)
+		})
+	})
+	s.Run("pods_debug(name=pod-to-debug, image=nicolaka/netshoot)", func() {
+		result, err := s.CallTool("pods_debug", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-debug",
+			"image":     "nicolaka/netshoot",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("adds ephemeral container with image", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Require().Len(s.pods["pod-to-debug"].Spec.EphemeralContainers, 2)
+			s.Equal("nicolaka/netshoot", s.pods["pod-to-debug"].Spec.EphemeralContainers[1].Image)
+		})
+		s.Run("does not execute command", func() {
+			s.NotContains(result.Content[0].(*mcp.TextContent).Text, "command:")
+		})
+	})
+	s.Run("pods_debug(name=pod-to-debug, target=missing)", func() {
+		result, err := s.CallTool("pods_debug", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-debug",
+			"target":    "missing",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes missing target", func() {
+			s.Equal("failed to debug pod pod-to-debug in namespace default: target container missing not found in pod pod-to-debug",
+				result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+}
+
+func (s *PodsDebugSuite) TestNodesDebug() {
+	s.InitMcpClient()
+	result, err := s.CallTool("nodes_debug", map[string]interface{}{
+		"name":    "node-1",
+		"command": []interface{}{"chroot", "/host", "uptime"},
+	})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+	})
+	s.Run("creates privileged host namespace pod", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var debugPod *v1.Pod
+		for name, pod := range s.pods {
+			if strings.HasPrefix(name, "node-debugger-node-1-") {
+				debugPod = pod
+			}
+		}
+		s.Require().NotNil(debugPod, "expected node debug pod to be created")
+		s.Equal("node-1", debugPod.Spec.NodeName)
+		s.True(debugPod.Spec.HostPID)
+		s.True(debugPod.Spec.HostNetwork)
+		s.True(debugPod.Spec.HostIPC)
+		s.True(*debugPod.Spec.Containers[0].SecurityContext.Privileged)
+		s.Equal("/host", debugPod.Spec.Containers[0].VolumeMounts[0].MountPath)
+		s.Equal("/", debugPod.Spec.Volumes[0].HostPath.Path)
+	})
+	s.Run("returns debug pod and command output", func() {
+		text := result.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`# Debug pod node-debugger-node-1-\S+ \(busybox:latest\) is running on node node-1 in namespace default`, text)
+		s.Contains(text, "## chroot /host uptime\ncontainer:debugger command:chroot /host uptime\n")
+	})
+}
+
+func (s *PodsDebugSuite) TestDebugDisableDestructive() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		disable_destructive = true
+	`), s.Cfg), "Expected to parse disable destructive config")
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	for _, tool := range tools.Tools {
+		s.NotContains([]string{"pods_debug", "nodes_debug"}, tool.Name, "debug tools should not be available in disable_destructive mode")
+	}
+}
+
+// decodePod decodes the Pod in the request body, client-go sends built-in types as protobuf
+func decodePod(req *http.Request) *v1.Pod {
+	pod := &v1.Pod{}
+	body, _ := io.ReadAll(req.Body)
+	_, _, _ = scheme.Codecs.UniversalDeserializer().Decode(body, nil, pod)
+	return pod
+}
+
+func TestPodsDebug(t *testing.T) {
+	suite.Run(t, new(PodsDebugSuite))
+}
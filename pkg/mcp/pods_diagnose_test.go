@@ -0,0 +1,309 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type PodsDiagnoseSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsDiagnoseSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	finishedAt := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	pod := func(name string, status v1.PodStatus, spec v1.PodSpec) *v1.Pod {
+		if len(spec.Containers) == 0 {
+			spec.Containers = []v1.Container{{Name: "app", Image: "example.com/app:1.0"}}
+		}
+		return &v1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"pod-template-hash": "abc"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app-abc", Controller: ptr.To(true)}}},
+			Spec:   spec,
+			Status: status,
+		}
+	}
+	event := func(name, involved, eventType, reason, message string) v1.Event {
+		return v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject: v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: involved, FieldPath: "spec.containers{app}"},
+			Type:           eventType, Reason: reason, Message: message, Count: 3,
+		}
+	}
+	objects := map[string]runtime.Object{
+		"/api/v1/namespaces/default/pods/crashing": pod("crashing", v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         5,
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, FinishedAt: finishedAt}},
+			}},
+		}, v1.PodSpec{NodeName: "node-1"}),
+		"/api/v1/namespaces/default/pods/oom": pod("oom", v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         2,
+				State:                v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: finishedAt}},
+			}},
+		}, v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "example.com/app:1.0", Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+		}}}}),
+		"/api/v1/namespaces/default/pods/oom-unlimited": pod("oom-unlimited", v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         1,
+				State:                v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: finishedAt}},
+			}},
+		}, v1.PodSpec{NodeName: "node-2"}),
+		"/api/v1/namespaces/default/pods/image": pod("image", v1.PodStatus{
+			Phase: v1.PodPending,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:  "app",
+				State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image \"example.com/app:1.0\""}},
+			}},
+		}, v1.PodSpec{}),
+		"/api/v1/namespaces/default/pods/pending": pod("pending", v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{{
+				Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable",
+				Message: "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: infra}.",
+			}},
+		}, v1.PodSpec{Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+		}}}}),
+		"/api/v1/namespaces/default/pods/unready": pod("unready", v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+		}, v1.PodSpec{}),
+		"/api/v1/namespaces/default/pods/healthy": pod("healthy", v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+		}, v1.PodSpec{NodeName: "node-2"}),
+		"/api/v1/namespaces/default/persistentvolumeclaims/data": &v1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"},
+			Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("fast")},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+		"/api/v1/nodes/node-1": &v1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, Reason: "KubeletNotReady", Message: "PLEG is not healthy"},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+			}},
+		},
+		"/api/v1/nodes/node-2": &v1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}},
+		},
+		"/api/v1/namespaces/default/events": &v1.EventList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"},
+			Items: []v1.Event{
+				event("e1", "image", "Warning", "Failed", "Failed to pull image \"example.com/app:1.0\": not found"),
+				event("e2", "pending", "Warning", "FailedScheduling", "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: infra}."),
+				event("e3", "unready", "Warning", "Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503"),
+				event("e4", "healthy", "Normal", "Started", "Started container app"),
+			},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/crashing/log":
+			if req.URL.Query().Get("previous") == "true" {
+				_, _ = w.Write([]byte("starting app\npanic: failed to connect to database user@example.com\n"))
+			}
+			return
+		case "/api/v1/namespaces/default/pods/oom/log":
+			return
+		}
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *PodsDiagnoseSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsDiagnoseSuite) diagnose(name string) *kubernetes.PodDiagnosis {
+	result, err := s.CallTool("pods_diagnose", map[string]interface{}{"namespace": "default", "name": name})
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	s.Require().NotNil(result.StructuredContent)
+	diagnosis := &kubernetes.PodDiagnosis{}
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), diagnosis))
+	return diagnosis
+}
+
+func (s *PodsDiagnoseSuite) suggestedTools(diagnosis *kubernetes.PodDiagnosis) []string {
+	var tools []string
+	for _, suggested := range diagnosis.SuggestedTools {
+		tools = append(tools, suggested.Tool)
+	}
+	return tools
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseCrashLoopBackOff() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("crashing")
+	s.Run("returns CrashLoopBackOff verdict", func() {
+		s.Equal(kubernetes.PodFailureCrashLoopBackOff, diagnosis.Verdict)
+		s.Equal("Container app keeps crashing (last exit code 1, Error) (1 more finding(s))", diagnosis.Summary)
+	})
+	s.Run("returns termination evidence", func() {
+		s.Require().NotEmpty(diagnosis.Findings)
+		s.Equal("app", diagnosis.Findings[0].Container)
+		s.Contains(diagnosis.Findings[0].Evidence, "Last termination: Error (exit code 1) at 2026-01-01T10:00:00Z")
+		s.Contains(diagnosis.Findings[0].Evidence, "Restart count: 5")
+	})
+	s.Run("returns previous logs with PII masked", func() {
+		logs := diagnosis.Findings[0].Evidence[len(diagnosis.Findings[0].Evidence)-1]
+		s.Contains(logs, "Previous logs (last lines):\nstarting app\npanic: failed to connect to database")
+		s.NotContains(logs, "user@example.com")
+	})
+	s.Run("returns unhealthy node finding", func() {
+		s.Require().Len(diagnosis.Findings, 2)
+		s.Equal(kubernetes.PodFailureNodeNotReady, diagnosis.Findings[1].Category)
+		s.Equal([]string{"Node condition Ready=False (KubeletNotReady): PLEG is not healthy"}, diagnosis.Findings[1].Evidence)
+	})
+	s.Run("suggests logs, debug, and owner tools", func() {
+		s.Equal([]string{"pods_log", "pods_debug", "resources_describe"}, s.suggestedTools(diagnosis))
+		s.Equal(map[string]any{"namespace": "default", "name": "crashing", "container": "app", "previous": true}, diagnosis.SuggestedTools[0].Arguments)
+		s.Equal(map[string]any{"apiVersion": "apps/v1", "kind": "ReplicaSet", "namespace": "default", "name": "app-abc"}, diagnosis.SuggestedTools[2].Arguments)
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseOOMKilled() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("oom")
+	s.Run("returns OOMKilled verdict", func() {
+		s.Equal(kubernetes.PodFailureOOMKilled, diagnosis.Verdict)
+		s.Require().Len(diagnosis.Findings, 1)
+		s.Contains(diagnosis.Findings[0].Evidence, "Memory limit: 128Mi")
+		s.Contains(diagnosis.Findings[0].Evidence, "Last termination: OOMKilled (exit code 137) at 2026-01-01T10:00:00Z")
+	})
+	s.Run("returns memory limit summary", func() {
+		s.Equal("Container app was killed because it exceeded its memory limit (OOMKilled)", diagnosis.Summary)
+	})
+	s.Run("suggests top, logs, and owner tools", func() {
+		s.Equal([]string{"pods_top", "pods_log", "resources_describe"}, s.suggestedTools(diagnosis))
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseOOMKilledWithoutMemoryLimit() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("oom-unlimited")
+	s.Run("returns OOMKilled verdict", func() {
+		s.Equal(kubernetes.PodFailureOOMKilled, diagnosis.Verdict)
+		s.Require().Len(diagnosis.Findings, 1)
+		s.Contains(diagnosis.Findings[0].Evidence, "Memory limit: none")
+	})
+	s.Run("returns node memory pressure summary", func() {
+		s.Equal("Container app has no memory limit and was killed by the node under memory pressure (OOMKilled)", diagnosis.Summary)
+	})
+	s.Run("suggests pod and node top tools", func() {
+		s.Equal([]string{"pods_top", "nodes_top", "pods_log", "resources_describe"}, s.suggestedTools(diagnosis))
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseImagePullBackOff() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("image")
+	s.Run("returns ImagePullBackOff verdict", func() {
+		s.Equal(kubernetes.PodFailureImagePull, diagnosis.Verdict)
+		s.Equal("Image example.com/app:1.0 of container app can't be pulled (ImagePullBackOff)", diagnosis.Summary)
+	})
+	s.Run("returns image and event evidence", func() {
+		s.Contains(diagnosis.Findings[0].Evidence, "Image: example.com/app:1.0")
+		s.Contains(diagnosis.Findings[0].Evidence, "Event Warning Failed (x3): Failed to pull image \"example.com/app:1.0\": not found")
+	})
+	s.Run("suggests rollout history of the owning Deployment", func() {
+		s.Equal([]string{"events_list", "resources_describe", "rollout_history"}, s.suggestedTools(diagnosis))
+		s.Equal(map[string]any{"kind": "Deployment", "namespace": "default", "name": "app"}, diagnosis.SuggestedTools[2].Arguments)
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnosePending() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("pending")
+	s.Run("returns unbound PersistentVolumeClaim verdict", func() {
+		s.Equal(kubernetes.PodFailurePVCNotBound, diagnosis.Verdict)
+		s.Equal([]string{"PersistentVolumeClaim data phase: Pending", "Storage class: fast"}, diagnosis.Findings[0].Evidence)
+	})
+	s.Run("returns unschedulable finding caused by taints", func() {
+		s.Require().Len(diagnosis.Findings, 2)
+		s.Equal(kubernetes.PodFailureUnschedulable, diagnosis.Findings[1].Category)
+		s.Equal("The Pod can't be scheduled due to untolerated node taints", diagnosis.Findings[1].Message)
+		s.Len(diagnosis.Findings[1].Evidence, 2)
+	})
+	s.Run("suggests storage tools", func() {
+		s.Equal([]string{"resources_list", "events_list"}, s.suggestedTools(diagnosis))
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseProbeFailure() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("unready")
+	s.Run("returns probe failure verdict", func() {
+		s.Equal(kubernetes.PodFailureProbe, diagnosis.Verdict)
+		s.Equal("app", diagnosis.Findings[0].Container)
+		s.Equal("The readiness probe(s) are failing for container app, the Pod is removed from the Service endpoints while the readiness probe fails", diagnosis.Summary)
+	})
+	s.Run("suggests logs and port forward tools", func() {
+		s.Equal([]string{"pods_log", "pods_port_forward", "resources_describe"}, s.suggestedTools(diagnosis))
+	})
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseHealthy() {
+	s.InitMcpClient()
+	diagnosis := s.diagnose("healthy")
+	s.Equal(kubernetes.PodDiagnosisHealthy, diagnosis.Verdict)
+	s.Empty(diagnosis.Findings)
+	s.Empty(diagnosis.SuggestedTools)
+}
+
+func (s *PodsDiagnoseSuite) TestPodsDiagnoseNotFound() {
+	s.InitMcpClient()
+	result, err := s.CallTool("pods_diagnose", map[string]interface{}{"namespace": "default", "name": "missing"})
+	s.Nilf(err, "call tool should not return error object")
+	s.Truef(result.IsError, "call tool should fail")
+	s.Contains(result.Content[0].(*mcp.TextContent).Text, "failed to diagnose pod missing in namespace default")
+}
+
+func TestPodsDiagnose(t *testing.T) {
+	suite.Run(t, new(PodsDiagnoseSuite))
+}
@@ -0,0 +1,294 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type PodsExecSessionSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsExecSessionSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec/exec" {
+			return
+		}
+		var stdin, stdout, stderr bytes.Buffer
+		ctx, err := test.CreateHTTPStreams(w, req, &test.StreamOptions{
+			Stdin:  &stdin,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		defer func(conn io.Closer) { _ = conn.Close() }(ctx.Closer)
+		// Echo every stdin line until stdin is closed
+		scanner := bufio.NewScanner(ctx.StdinStream)
+		for scanner.Scan() {
+			_, _ = io.WriteString(ctx.StdoutStream, "echo:"+scanner.Text()+"\n")
+		}
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec" {
+			return
+		}
+		test.WriteObject(w, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-to-exec",
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "container-to-exec"}}},
+		})
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *PodsExecSessionSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsExecSessionSuite) startSession() string {
+	result, err := s.CallTool("pods_exec_start", map[string]interface{}{
+		"namespace": "default",
+		"name":      "pod-to-exec",
+		"command":   []interface{}{"cat"},
+	})
+	s.Require().NoError(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	matches := regexp.MustCompile(`Exec session (exec-\S+) started`).FindStringSubmatch(result.Content[0].(*mcp.TextContent).Text)
+	s.Require().Len(matches, 2, "unexpected result %v", result.Content[0].(*mcp.TextContent).Text)
+	return matches[1]
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSession() {
+	s.InitMcpClient()
+	sessionID := s.startSession()
+	s.Run("pods_exec_write(session_id, input=hello)", func() {
+		result, err := s.CallTool("pods_exec_write", map[string]interface{}{
+			"session_id": sessionID,
+			"input":      "hello\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns written bytes", func() {
+			s.Equal("Wrote 6 bytes to exec session "+sessionID, result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("pods_exec_read(session_id, wait=5)", func() {
+		result, err := s.CallTool("pods_exec_read", map[string]interface{}{
+			"session_id": sessionID,
+			"wait":       5,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns command output", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# Exec session "+sessionID+" is running\n")
+			s.Contains(text, "## stdout\necho:hello\n")
+		})
+	})
+	s.Run("pods_exec_read(session_id, wait=0) with no new output", func() {
+		result, err := s.CallTool("pods_exec_read", map[string]interface{}{
+			"session_id": sessionID,
+			"wait":       0,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns no new output", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "# No new output\n")
+		})
+	})
+	s.Run("pods_exec_write(session_id, input=bye, close_stdin=true)", func() {
+		result, err := s.CallTool("pods_exec_write", map[string]interface{}{
+			"session_id":  sessionID,
+			"input":       "bye\n",
+			"close_stdin": true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns closed stdin", func() {
+			s.Equal("Wrote 4 bytes to exec session "+sessionID+" and closed its stdin", result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("pods_exec_read(session_id) after stdin is closed", func() {
+		s.Eventually(func() bool {
+			result, err := s.CallTool("pods_exec_read", map[string]interface{}{
+				"session_id": sessionID,
+				"wait":       1,
+			})
+			return err == nil && !result.IsError &&
+				regexp.MustCompile(`# Exec session \S+ has finished`).MatchString(result.Content[0].(*mcp.TextContent).Text)
+		}, 5*time.Second, 100*time.Millisecond, "expected exec session to finish")
+	})
+	s.Run("pods_exec_close(session_id)", func() {
+		result, err := s.CallTool("pods_exec_close", map[string]interface{}{
+			"session_id": sessionID,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns session closed", func() {
+			s.Equal("Exec session "+sessionID+" closed", result.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("pods_exec_read(session_id) after close", func() {
+		result, err := s.CallTool("pods_exec_read", map[string]interface{}{
+			"session_id": sessionID,
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes missing session", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "exec session not found: "+sessionID)
+		})
+	})
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSessionMaxSessions() {
+	s.InitMcpClient()
+	for i := 0; i < internalk8s.DefaultExecSessionsMax; i++ {
+		s.startSession()
+	}
+	s.Run("pods_exec_start exceeding the maximum number of sessions", func() {
+		result, err := s.CallTool("pods_exec_start", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-exec",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+		})
+		s.Run("describes limit", func() {
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "maximum number of exec sessions (5) reached")
+		})
+	})
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSessionConfiguredMaxSessions() {
+	s.Cfg.ExecSessionsMax = 1
+	s.InitMcpClient()
+	s.startSession()
+	s.Run("pods_exec_start exceeding the configured maximum number of sessions", func() {
+		result, err := s.CallTool("pods_exec_start", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-exec",
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(result.IsError, "call tool should fail")
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, "maximum number of exec sessions (1) reached")
+	})
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSessionConcurrentMaxSessions() {
+	s.Cfg.ExecSessionsMax = 1
+	s.InitMcpClient()
+	const calls = 5
+	results := make(chan *mcp.CallToolResult, calls)
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := s.CallTool("pods_exec_start", map[string]interface{}{
+				"namespace": "default",
+				"name":      "pod-to-exec",
+				"command":   []interface{}{"cat"},
+			})
+			if err == nil {
+				results <- result
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+	s.Run("concurrent pods_exec_start calls don't exceed the maximum number of sessions", func() {
+		started, rejected := 0, 0
+		for result := range results {
+			if result.IsError {
+				s.Contains(result.Content[0].(*mcp.TextContent).Text, "maximum number of exec sessions (1) reached")
+				rejected++
+			} else {
+				started++
+			}
+		}
+		s.Equal(1, started)
+		s.Equal(calls-1, rejected)
+	})
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSessionStateless() {
+	s.Cfg.Stateless = true
+	s.InitMcpClient()
+	s.Run("pods_exec_start in stateless mode", func() {
+		result, err := s.CallTool("pods_exec_start", map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod-to-exec",
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(result.IsError, "call tool should fail")
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, internalk8s.ErrMcpSessionRequired.Error())
+	})
+	s.Run("pods_exec_read in stateless mode", func() {
+		result, err := s.CallTool("pods_exec_read", map[string]interface{}{"session_id": "exec-of-another-client"})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(result.IsError, "call tool should fail")
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, internalk8s.ErrMcpSessionRequired.Error())
+	})
+}
+
+func (s *PodsExecSessionSuite) TestPodsExecSessionMissingArguments() {
+	s.InitMcpClient()
+	for _, tool := range []string{"pods_exec_write", "pods_exec_read", "pods_exec_close"} {
+		s.Run(tool+"(session_id=nil)", func() {
+			result, err := s.CallTool(tool, map[string]interface{}{})
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(result.IsError, "call tool should fail")
+			s.Contains(result.Content[0].(*mcp.TextContent).Text, "session_id parameter required")
+		})
+	}
+	s.Run("pods_exec_start(name=nil)", func() {
+		result, err := s.CallTool("pods_exec_start", map[string]interface{}{})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(result.IsError, "call tool should fail")
+		s.Equal("failed to start exec session in pod, name parameter required", result.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func TestPodsExecSession(t *testing.T) {
+	suite.Run(t, new(PodsExecSessionSuite))
+}
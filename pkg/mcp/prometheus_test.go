@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// requests captured by the stub Prometheus server
+	requests []*http.Request
+}
+
+func (s *PrometheusSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requests = nil
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/query":
+			s.requests = append(s.requests, r)
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"web"},"value":[1700000000,"2"]}]}}`))
+		case "/api/v1/query_range":
+			s.requests = append(s.requests, r)
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"pod":"web"},"values":[[1700000000,"0.25"],[1700000060,"0.5"]]}]}}`))
+		}
+	}))
+	tokenFile := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenFile, []byte("token-xyz"), 0600))
+	s.Cfg = test.Must(config.ReadToml([]byte(fmt.Sprintf(`
+		toolsets = ["prometheus"]
+		[toolset_configs.prometheus]
+		url = "%s"
+		bearer_token_file = "%s"
+	`, s.mockServer.Config().Host, filepath.ToSlash(tokenFile)))))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *PrometheusSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PrometheusSuite) lastQuery() url.Values {
+	s.Require().NotEmpty(s.requests, "expected a request to Prometheus")
+	return s.requests[len(s.requests)-1].URL.Query()
+}
+
+func (s *PrometheusSuite) TestQuery() {
+	s.InitMcpClient()
+	s.Run("prometheus_query(query=up)", func() {
+		toolResult, err := s.CallTool("prometheus_query", map[string]interface{}{"query": "up"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("queries Prometheus", func() {
+			s.Equal("/api/v1/query", s.requests[len(s.requests)-1].URL.Path)
+			s.Equal("up", s.lastQuery().Get("query"))
+			s.False(s.lastQuery().Has("time"))
+		})
+		s.Run("authenticates with the configured bearer token", func() {
+			s.Equal("Bearer token-xyz", s.requests[len(s.requests)-1].Header.Get("Authorization"))
+		})
+		s.Run("returns structured result", func() {
+			var result map[string]any
+			s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &result))
+			s.Equal("vector", result["resultType"])
+			s.Len(result["result"], 1)
+		})
+	})
+	s.Run("prometheus_query(query=up, time=1700000000)", func() {
+		_, _ = s.CallTool("prometheus_query", map[string]interface{}{"query": "up", "time": "1700000000"})
+		s.Equal("2023-11-14T22:13:20Z", s.lastQuery().Get("time"))
+	})
+	s.Run("prometheus_query(time=invalid)", func() {
+		toolResult, _ := s.CallTool("prometheus_query", map[string]interface{}{"query": "up", "time": "yesterday"})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "yesterday is not an RFC3339 or Unix timestamp")
+	})
+}
+
+func (s *PrometheusSuite) TestQueryRange() {
+	s.InitMcpClient()
+	s.Run("prometheus_query_range(query) with defaults", func() {
+		toolResult, err := s.CallTool("prometheus_query_range", map[string]interface{}{"query": "up"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("/api/v1/query_range", s.requests[len(s.requests)-1].URL.Path)
+		start, _ := time.Parse(time.RFC3339, s.lastQuery().Get("start"))
+		end, _ := time.Parse(time.RFC3339, s.lastQuery().Get("end"))
+		s.Equal(time.Hour, end.Sub(start), "expected default duration of 1h")
+		s.Equal("30s", s.lastQuery().Get("step"))
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `"resultType":"matrix"`)
+	})
+	s.Run("prometheus_query_range(query, start, end, step)", func() {
+		_, _ = s.CallTool("prometheus_query_range", map[string]interface{}{
+			"query": "up", "start": "2025-01-01T00:00:00Z", "end": "2025-01-02T00:00:00Z", "step": "5m",
+		})
+		s.Equal("2025-01-01T00:00:00Z", s.lastQuery().Get("start"))
+		s.Equal("2025-01-02T00:00:00Z", s.lastQuery().Get("end"))
+		s.Equal("5m0s", s.lastQuery().Get("step"))
+	})
+	s.Run("prometheus_query_range(start after end)", func() {
+		toolResult, _ := s.CallTool("prometheus_query_range", map[string]interface{}{
+			"query": "up", "start": "2025-01-02T00:00:00Z", "end": "2025-01-01T00:00:00Z",
+		})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "must be before end")
+	})
+}
+
+func (s *PrometheusSuite) TestPodsHelpers() {
+	s.InitMcpClient()
+	s.Run("prometheus_pods_usage(namespace, pod, resource=memory)", func() {
+		toolResult, err := s.CallTool("prometheus_pods_usage", map[string]interface{}{
+			"namespace": "default", "pod": "web-.*", "resource": "memory", "duration": "6h",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal(`sum by (namespace, pod) (container_memory_working_set_bytes{namespace="default",pod=~"web-.*",container!="",container!="POD"})`,
+			s.lastQuery().Get("query"))
+		s.Equal("3m0s", s.lastQuery().Get("step"))
+	})
+	s.Run("prometheus_pods_usage(namespace) quotes label values", func() {
+		_, _ = s.CallTool("prometheus_pods_usage", map[string]interface{}{"namespace": `default"} or vector(1) #`})
+		s.Contains(s.lastQuery().Get("query"), `namespace="default\"} or vector(1) #"`)
+	})
+	s.Run("prometheus_pods_restarts(namespace, window)", func() {
+		toolResult, err := s.CallTool("prometheus_pods_restarts", map[string]interface{}{"namespace": "default", "window": "1d"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal(`sum by (namespace, pod, container) (increase(kube_pod_container_status_restarts_total{namespace="default"}[1d]))`,
+			s.lastQuery().Get("query"))
+	})
+	s.Run("prometheus_pods_restarts(window=invalid)", func() {
+		toolResult, _ := s.CallTool("prometheus_pods_restarts", map[string]interface{}{"namespace": "default", "window": "1h] or up[1h"})
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid window")
+	})
+}
+
+func TestPrometheus(t *testing.T) {
+	suite.Run(t, new(PrometheusSuite))
+}
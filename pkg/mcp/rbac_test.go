@@ -0,0 +1,248 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type RBACSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// impersonatedUser is the Impersonate-User header of the last SelfSubjectAccessReview
+	impersonatedUser   string
+	impersonatedGroups []string
+}
+
+func (s *RBACSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.impersonatedUser, s.impersonatedGroups = "", nil
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists = append(discovery.APIResourceLists, metav1.APIResourceList{
+		GroupVersion: "rbac.authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "clusterroles", Kind: "ClusterRole", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "clusterrolebindings", Kind: "ClusterRoleBinding", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "roles", Kind: "Role", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "rolebindings", Kind: "RoleBinding", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "selfsubjectaccessreviews", Kind: "SelfSubjectAccessReview", Verbs: metav1.Verbs{"create"}},
+		},
+	})
+	s.mockServer.Handle(discovery)
+	roleBindings := &rbacv1.RoleBindingList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBindingList"},
+		Items: []rbacv1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deployer"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "deployer"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "developers-edit"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "developers"}, {Kind: rbacv1.UserKind, Name: "alice"}},
+			},
+		},
+	}
+	objects := map[string]runtime.Object{
+		"/apis/rbac.authorization.k8s.io/v1/clusterroles": &rbacv1.ClusterRoleList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleList"},
+			Items: []rbacv1.ClusterRole{
+				{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}, Rules: []rbacv1.PolicyRule{
+					{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+				}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "edit"}, Rules: []rbacv1.PolicyRule{
+					{Verbs: []string{"get", "list", "create", "update", "delete"}, APIGroups: []string{""}, Resources: []string{"pods", "secrets"}},
+					{Verbs: []string{"get", "list", "update"}, APIGroups: []string{"apps"}, Resources: []string{"deployments", "deployments/scale"}},
+				}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "pod-reader"}, Rules: []rbacv1.PolicyRule{
+					{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}},
+				}},
+			},
+		},
+		"/apis/rbac.authorization.k8s.io/v1/clusterrolebindings": &rbacv1.ClusterRoleBindingList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBindingList"},
+			Items: []rbacv1.ClusterRoleBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-admins"},
+					RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+					Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "admin"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "monitoring-pod-reader"},
+					RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "pod-reader"},
+					Subjects: []rbacv1.Subject{
+						{Kind: rbacv1.ServiceAccountKind, Namespace: "monitoring", Name: "prometheus"},
+						{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:default"},
+					},
+				},
+			},
+		},
+		"/apis/rbac.authorization.k8s.io/v1/namespaces/default/roles": &rbacv1.RoleList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleList"},
+			Items: []rbacv1.Role{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deployer"}, Rules: []rbacv1.PolicyRule{
+					{Verbs: []string{"update", "patch"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web"}},
+				}},
+			},
+		},
+		"/apis/rbac.authorization.k8s.io/v1/namespaces/default/rolebindings": roleBindings,
+		"/apis/rbac.authorization.k8s.io/v1/roles": &rbacv1.RoleList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleList"},
+		},
+		"/apis/rbac.authorization.k8s.io/v1/rolebindings": &rbacv1.RoleBindingList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBindingList"},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			body, _ := io.ReadAll(req.Body)
+			obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), body)
+			review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+			if err != nil || !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.impersonatedUser, s.impersonatedGroups = req.Header.Get("Impersonate-User"), req.Header.Values("Impersonate-Group")
+			attributes := review.Spec.ResourceAttributes
+			review.TypeMeta = metav1.TypeMeta{APIVersion: "authorization.k8s.io/v1", Kind: "SelfSubjectAccessReview"}
+			// The current identity can get the Pods, the impersonated ServiceAccounts can't
+			if attributes != nil && attributes.Verb == "get" && attributes.Resource == "pods" && s.impersonatedUser == "" {
+				review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: "RBAC: allowed by ClusterRoleBinding \"pod-reader\""}
+			} else {
+				review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: false, Reason: "no RBAC policy matched"}
+			}
+			test.WriteObject(w, review)
+			return
+		}
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *RBACSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *RBACSuite) callStructured(name string, arguments map[string]interface{}) map[string]any {
+	result, err := s.CallTool(name, arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	var ret map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &ret))
+	return ret
+}
+
+func (s *RBACSuite) TestRBACCanI() {
+	s.InitMcpClient()
+	s.Run("rbac_can_i(verb=get, resource=pods, namespace=default)", func() {
+		result := s.callStructured("rbac_can_i", map[string]interface{}{"verb": "get", "resource": "pods", "namespace": "default"})
+		s.Equal(true, result["allowed"])
+		s.Equal("RBAC: allowed by ClusterRoleBinding \"pod-reader\"", result["reason"])
+		s.Empty(s.impersonatedUser)
+	})
+	s.Run("rbac_can_i(verb=delete, resource=pods)", func() {
+		result := s.callStructured("rbac_can_i", map[string]interface{}{"verb": "delete", "resource": "pods"})
+		s.Equal(false, result["allowed"])
+	})
+	s.Run("rbac_can_i(service_account=ci) impersonates the ServiceAccount", func() {
+		result := s.callStructured("rbac_can_i", map[string]interface{}{"verb": "get", "resource": "pods", "service_account": "ci"})
+		s.Equal(false, result["allowed"])
+		s.Equal("system:serviceaccount:default:ci", result["user"])
+		s.Equal("system:serviceaccount:default:ci", s.impersonatedUser)
+		s.Equal([]string{"system:serviceaccounts", "system:serviceaccounts:default", "system:authenticated"}, s.impersonatedGroups)
+	})
+}
+
+func (s *RBACSuite) TestRBACWhoCan() {
+	s.InitMcpClient()
+	subjectNames := func(result map[string]any) []string {
+		var names []string
+		for _, subject := range result["subjects"].([]any) {
+			names = append(names, subject.(map[string]any)["kind"].(string)+"/"+subject.(map[string]any)["name"].(string))
+		}
+		return names
+	}
+	s.Run("rbac_who_can(verb=get, resource=pods, namespace=default)", func() {
+		result := s.callStructured("rbac_who_can", map[string]interface{}{"verb": "get", "resource": "pods", "namespace": "default"})
+		s.Equal([]string{"Group/developers", "Group/system:serviceaccounts:default", "ServiceAccount/prometheus", "User/admin", "User/alice"}, subjectNames(result))
+		s.Contains(result["subjects"], map[string]any{
+			"kind": "User", "name": "alice", "binding": "RoleBinding/developers-edit", "bindingNamespace": "default", "role": "ClusterRole/edit",
+		})
+		s.NotEmpty(result["notes"])
+	})
+	s.Run("rbac_who_can(verb=get, resource=pods) cluster-wide only evaluates ClusterRoleBindings", func() {
+		result := s.callStructured("rbac_who_can", map[string]interface{}{"verb": "get", "resource": "pods"})
+		s.Equal([]string{"Group/system:serviceaccounts:default", "ServiceAccount/prometheus", "User/admin"}, subjectNames(result))
+	})
+	s.Run("rbac_who_can(subresource=log)", func() {
+		result := s.callStructured("rbac_who_can", map[string]interface{}{"verb": "get", "resource": "pods", "subresource": "log", "namespace": "default"})
+		s.Equal([]string{"Group/system:serviceaccounts:default", "ServiceAccount/prometheus", "User/admin"}, subjectNames(result))
+	})
+	s.Run("rbac_who_can(resource names)", func() {
+		result := s.callStructured("rbac_who_can", map[string]interface{}{
+			"verb": "patch", "resource": "deployments", "api_group": "apps", "name": "web", "namespace": "default",
+		})
+		s.Equal([]string{"ServiceAccount/ci", "User/admin"}, subjectNames(result))
+		s.Equal("default", result["subjects"].([]any)[0].(map[string]any)["namespace"], "ServiceAccount namespace defaults to the binding namespace")
+		result = s.callStructured("rbac_who_can", map[string]interface{}{
+			"verb": "patch", "resource": "deployments", "api_group": "apps", "name": "api", "namespace": "default",
+		})
+		s.Equal([]string{"User/admin"}, subjectNames(result))
+	})
+}
+
+func (s *RBACSuite) TestRBACSubjectPermissions() {
+	s.InitMcpClient()
+	bindings := func(result map[string]any) []string {
+		var names []string
+		for _, permission := range result["permissions"].([]any) {
+			names = append(names, permission.(map[string]any)["binding"].(string))
+		}
+		return names
+	}
+	s.Run("rbac_subject_permissions(kind=ServiceAccount, name=ci)", func() {
+		result := s.callStructured("rbac_subject_permissions", map[string]interface{}{"kind": "ServiceAccount", "name": "ci", "namespace": "default"})
+		s.Equal("default", result["namespace"])
+		s.Equal([]string{"ClusterRoleBinding/monitoring-pod-reader", "RoleBinding/deployer"}, bindings(result))
+		s.Equal("system:serviceaccounts:default", result["permissions"].([]any)[0].(map[string]any)["via"])
+		s.Equal([]any{map[string]any{"verbs": []any{"update", "patch"}, "apiGroups": []any{"apps"}, "resources": []any{"deployments"}, "resourceNames": []any{"web"}}},
+			result["permissions"].([]any)[1].(map[string]any)["rules"])
+	})
+	s.Run("rbac_subject_permissions(kind=User, name=alice)", func() {
+		result := s.callStructured("rbac_subject_permissions", map[string]interface{}{"kind": "User", "name": "alice", "namespace": "default"})
+		s.Equal([]string{"RoleBinding/developers-edit"}, bindings(result))
+		s.NotEmpty(result["notes"])
+	})
+	s.Run("rbac_subject_permissions(kind=invalid)", func() {
+		result, err := s.CallTool("rbac_subject_permissions", map[string]interface{}{"kind": "Pod", "name": "web"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(result.IsError)
+		s.Contains(result.Content[0].(*mcp.TextContent).Text, "unsupported kind: Pod")
+	})
+}
+
+func TestRBAC(t *testing.T) {
+	suite.Run(t, new(RBACSuite))
+}
@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type ResourcesDescribeSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesDescribeSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "autoscaling/v2",
+		APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "endpoints", Kind: "Endpoints", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists[1].APIResources = append(discovery.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	labels := map[string]string{"app": "web"}
+	controller := func(apiVersion, kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: ptr.To(true)}}
+	}
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "uid-web", Labels: labels},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1)), Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	replicaSet := appsv1.ReplicaSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc", UID: "uid-web-abc", Labels: labels, OwnerReferences: controller("apps/v1", "Deployment", "web")},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(1)), Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status:     appsv1.ReplicaSetStatus{ReadyReplicas: 1},
+	}
+	pod := v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc-1", Labels: labels, OwnerReferences: controller("apps/v1", "ReplicaSet", "web-abc")},
+		Spec: v1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []v1.Container{{Name: "web", Image: "nginx"}},
+			Volumes:    []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{{Name: "web", Ready: true, RestartCount: 2}}},
+	}
+	unrelatedPod := v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", Labels: labels},
+	}
+	event := func(name, kind, involved, reason string, minute int) v1.Event {
+		return v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject: v1.ObjectReference{APIVersion: "v1", Kind: kind, Name: involved},
+			Type:           "Normal", Reason: reason, Message: reason + " " + involved,
+			FirstTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 9, minute, 0, 0, time.UTC)),
+		}
+	}
+	objects := map[string]runtime.Object{
+		"/apis/apps/v1/namespaces/default/deployments/web":     deployment,
+		"/apis/apps/v1/namespaces/default/replicasets/web-abc": &replicaSet,
+		"/apis/apps/v1/namespaces/default/replicasets": &appsv1.ReplicaSetList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSetList"},
+			Items:    []appsv1.ReplicaSet{replicaSet},
+		},
+		"/api/v1/namespaces/default/pods/web-abc-1": &pod,
+		"/api/v1/namespaces/default/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items:    []v1.Pod{pod, unrelatedPod},
+		},
+		"/api/v1/namespaces/default/services/web": &v1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       v1.ServiceSpec{Selector: labels},
+		},
+		"/api/v1/namespaces/default/endpoints/web": &v1.Endpoints{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Endpoints"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Subsets: []v1.EndpointSubset{{
+				Addresses:         []v1.EndpointAddress{{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-abc-1"}}},
+				NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.2"}},
+				Ports:             []v1.EndpointPort{{Port: 8080, Protocol: v1.ProtocolTCP}},
+			}},
+		},
+		"/api/v1/namespaces/default/persistentvolumeclaims/web-data": &v1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-data"},
+			Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("standard")},
+			Status: v1.PersistentVolumeClaimStatus{
+				Phase:    v1.ClaimBound,
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+		"/apis/autoscaling/v2/namespaces/default/horizontalpodautoscalers": &autoscalingv2.HorizontalPodAutoscalerList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscalerList"},
+			Items: []autoscalingv2.HorizontalPodAutoscaler{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+					MinReplicas:    ptr.To(int32(1)),
+					MaxReplicas:    5,
+				},
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 1, DesiredReplicas: 1},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unrelated"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "other"},
+				},
+			}},
+		},
+		"/api/v1/namespaces/default/events": &v1.EventList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"},
+			Items: []v1.Event{
+				event("e2", "Pod", "web-abc-1", "Started", 30),
+				event("e3", "Pod", "other", "Started", 45),
+				event("e1", "Deployment", "web", "ScalingReplicaSet", 5),
+			},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ResourcesDescribeSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesDescribeSuite) describe(arguments map[string]interface{}) *kubernetes.DescribeResult {
+	result, err := s.CallTool("resources_describe", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(result.IsError, "call tool failed: %v", result.Content)
+	description := &kubernetes.DescribeResult{}
+	s.Require().NoError(yaml.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), description))
+	return description
+}
+
+func (s *ResourcesDescribeSuite) TestResourcesDescribeDeployment() {
+	s.InitMcpClient()
+	description := s.describe(map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "web"})
+	s.Run("returns resource status", func() {
+		s.Equal(kubernetes.DescribedObject{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", Status: "ready 1/1, updated 1, available 1"}, description.Resource)
+	})
+	s.Run("returns controlled ReplicaSets and Pods", func() {
+		s.Equal([]kubernetes.DescribedObject{
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "web-abc", Status: "ready 1/1"},
+			{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "web-abc-1", Status: "Running, ready 1/1, restarts 2, node node-1"},
+		}, description.Children)
+	})
+	s.Run("returns mounted PersistentVolumeClaims", func() {
+		s.Equal([]kubernetes.DescribedObject{
+			{APIVersion: "v1", Kind: "PersistentVolumeClaim", Namespace: "default", Name: "web-data", Status: "Bound, 1Gi, storageClass standard"},
+		}, description.PersistentVolumeClaims)
+	})
+	s.Run("returns targeting HorizontalPodAutoscalers", func() {
+		s.Require().Len(description.HorizontalPodAutoscalers, 1)
+		s.Equal("web", description.HorizontalPodAutoscalers[0].Name)
+		s.Equal("replicas 1 (desired 1, min 1, max 5)", description.HorizontalPodAutoscalers[0].Status)
+	})
+	s.Run("returns events of related objects only sorted by time", func() {
+		s.Require().Len(description.Events, 2)
+		s.Contains(description.Events[0], "Normal ScalingReplicaSet Deployment/web: ScalingReplicaSet web")
+		s.Contains(description.Events[1], "Normal Started Pod/web-abc-1: Started web-abc-1")
+	})
+}
+
+func (s *ResourcesDescribeSuite) TestResourcesDescribePod() {
+	s.InitMcpClient()
+	description := s.describe(map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "web-abc-1"})
+	s.Run("returns owner chain", func() {
+		s.Equal([]kubernetes.DescribedObject{
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "web-abc", Status: "ready 1/1"},
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", Status: "ready 1/1, updated 1, available 1"},
+		}, description.Owners)
+	})
+	s.Run("returns HorizontalPodAutoscalers targeting owners", func() {
+		s.Len(description.HorizontalPodAutoscalers, 1)
+	})
+	s.Run("returns mounted PersistentVolumeClaims", func() {
+		s.Len(description.PersistentVolumeClaims, 1)
+	})
+}
+
+func (s *ResourcesDescribeSuite) TestResourcesDescribeService() {
+	s.InitMcpClient()
+	description := s.describe(map[string]interface{}{"apiVersion": "v1", "kind": "Service", "namespace": "default", "name": "web"})
+	s.Run("returns selected Pods", func() {
+		s.Require().Len(description.Pods, 2)
+		s.Equal("other", description.Pods[0].Name)
+		s.Equal("web-abc-1", description.Pods[1].Name)
+	})
+	s.Run("returns endpoints", func() {
+		s.Equal([]string{"10.0.0.1 8080/TCP (Pod/web-abc-1) ready", "10.0.0.2 8080/TCP not ready"}, description.Endpoints)
+	})
+	s.Run("returns events of the selected Pods", func() {
+		s.Require().Len(description.Events, 2)
+		s.Contains(description.Events[0], "Normal Started Pod/web-abc-1: Started web-abc-1")
+		s.Contains(description.Events[1], "Normal Started Pod/other: Started other")
+	})
+}
+
+func (s *ResourcesDescribeSuite) TestResourcesDescribeNotFound() {
+	s.InitMcpClient()
+	result, err := s.CallTool("resources_describe", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "missing"})
+	s.Nilf(err, "call tool should not return error object")
+	s.Truef(result.IsError, "call tool should fail")
+}
+
+func TestResourcesDescribe(t *testing.T) {
+	suite.Run(t, new(ResourcesDescribeSuite))
+}
@@ -0,0 +1,232 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type ResourcesDiffSuite struct {
+	BaseMcpSuite
+	mockServers []*test.MockServer
+}
+
+// resourcesDiffTestServer returns a mock server with the provided deployments in the default namespace
+func resourcesDiffTestServer(deployments ...appsv1.Deployment) *test.MockServer {
+	mockServer := test.NewMockServer()
+	mockServer.Handle(test.NewDiscoveryClientHandler())
+	objects := map[string]runtime.Object{
+		"/apis/apps/v1/namespaces/default/deployments": &appsv1.DeploymentList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"},
+			Items:    deployments,
+		},
+	}
+	for i := range deployments {
+		objects["/apis/apps/v1/namespaces/default/deployments/"+deployments[i].Name] = &deployments[i]
+	}
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		} else if strings.HasPrefix(req.URL.Path, "/apis/apps/v1/namespaces/default/deployments/") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&metav1.Status{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound,
+			})
+		}
+	}))
+	return mockServer
+}
+
+// resourcesDiffTestDeployment returns a deployment with the provided image and owner, and server-populated fields unique to the cluster
+func resourcesDiffTestDeployment(cluster, name, image, owner string, replicas int32) appsv1.Deployment {
+	return appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            name,
+			UID:             types.UID("uid-" + cluster),
+			ResourceVersion: cluster,
+			Generation:      int64(len(cluster)),
+			Annotations:     map[string]string{"deployment.kubernetes.io/revision": cluster, "owner": owner},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "sidecar", Image: "envoy"},
+				{Name: "main", Image: image},
+			}}},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: int64(len(cluster)), Replicas: replicas},
+	}
+}
+
+func (s *ResourcesDiffSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	prod := resourcesDiffTestServer(
+		resourcesDiffTestDeployment("prod", "api", "api:1.0", "team@example.com", 3),
+		resourcesDiffTestDeployment("prod", "web", "nginx:1.27", "prod@example.com", 2),
+		resourcesDiffTestDeployment("prod", "legacy", "legacy:0.1", "team@example.com", 1),
+	)
+	staging := resourcesDiffTestServer(
+		resourcesDiffTestDeployment("staging", "api", "api:1.0", "team@example.com", 3),
+		resourcesDiffTestDeployment("staging", "web", "nginx:1.28", "staging@example.com", 1),
+		resourcesDiffTestDeployment("staging", "worker", "worker:2.0", "team@example.com", 1),
+	)
+	s.mockServers = []*test.MockServer{prod, staging}
+	kubeconfig := prod.Kubeconfig()
+	stagingKubeconfig := staging.Kubeconfig()
+	kubeconfig.Clusters["staging"] = stagingKubeconfig.Clusters["fake"]
+	kubeconfig.AuthInfos["staging"] = stagingKubeconfig.AuthInfos["fake"]
+	kubeconfig.Contexts["staging"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	s.Cfg.KubeConfig = test.KubeconfigFile(s.T(), kubeconfig)
+}
+
+func (s *ResourcesDiffSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	for _, mockServer := range s.mockServers {
+		mockServer.Close()
+	}
+}
+
+func (s *ResourcesDiffSuite) callDiff(arguments map[string]interface{}) *kubernetes.ResourcesDiff {
+	toolResult, err := s.CallTool("resources_diff", arguments)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	var diff kubernetes.ResourcesDiff
+	s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &diff))
+	return &diff
+}
+
+func (s *ResourcesDiffSuite) TestResourcesDiff() {
+	s.InitMcpClient()
+	s.Run("resources_diff(name) with identical resource ignores server-populated fields", func() {
+		diff := s.callDiff(map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "api", "destination": "staging",
+		})
+		s.Equal("fake-context", diff.Source)
+		s.Equal("staging", diff.Destination)
+		s.Equal(kubernetes.ResourcesDiffSummary{Identical: 1}, diff.Summary)
+		s.Require().Len(diff.Resources, 1)
+		s.Equal(kubernetes.DiffStatusIdentical, diff.Resources[0].Status)
+		s.Empty(diff.Resources[0].Differences)
+	})
+	s.Run("resources_diff(name) with changed resource returns the differing fields with the PII masked", func() {
+		diff := s.callDiff(map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "source": "fake-context", "destination": "staging",
+		})
+		s.Equal(kubernetes.ResourcesDiffSummary{Changed: 1}, diff.Summary)
+		s.Require().Len(diff.Resources, 1)
+		s.Equal("web", diff.Resources[0].Name)
+		s.Equal(kubernetes.DiffStatusChanged, diff.Resources[0].Status)
+		s.Equal([]kubernetes.ResourceFieldDiff{
+			{Path: "metadata.annotations.owner", Source: "****************", Destination: "*******************"},
+			{Path: "spec.replicas", Source: float64(2), Destination: float64(1)},
+			{Path: "spec.template.spec.containers[name=main].image", Source: "nginx:1.27", Destination: "nginx:1.28"},
+		}, diff.Resources[0].Differences)
+	})
+	s.Run("resources_diff(name) with missing resource reports it", func() {
+		diff := s.callDiff(map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "worker", "destination": "staging",
+		})
+		s.Equal(kubernetes.ResourcesDiffSummary{OnlyInDestination: 1}, diff.Summary)
+		s.Require().Len(diff.Resources, 1)
+		s.Equal(kubernetes.DiffStatusOnlyInDestination, diff.Resources[0].Status)
+	})
+	s.Run("resources_diff without name compares all the resources in the namespace", func() {
+		diff := s.callDiff(map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "destination": "staging",
+		})
+		s.Equal("default", diff.Namespace)
+		s.Equal(kubernetes.ResourcesDiffSummary{Identical: 1, Changed: 1, OnlyInSource: 1, OnlyInDestination: 1}, diff.Summary)
+		statuses := map[string]string{}
+		for _, resource := range diff.Resources {
+			statuses[resource.Name] = resource.Status
+		}
+		s.Equal(map[string]string{
+			"api":    kubernetes.DiffStatusIdentical,
+			"web":    kubernetes.DiffStatusChanged,
+			"legacy": kubernetes.DiffStatusOnlyInSource,
+			"worker": kubernetes.DiffStatusOnlyInDestination,
+		}, statuses)
+	})
+	s.Run("resources_diff(destination=invalid) returns error", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "destination": "invalid",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to diff resources, invalid destination invalid")
+	})
+	s.Run("resources_diff without destination returns error", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to diff resources, destination parameter required")
+	})
+}
+
+func (s *ResourcesDiffSuite) TestResourcesDiffTargetConfiguration() {
+	kubeconfig := test.Must(clientcmd.LoadFromFile(s.Cfg.KubeConfig))
+	kubeconfig.Contexts["staging-restricted"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		[targets.fake-context]
+		environment = "prod"
+		[targets.staging]
+		environment = "staging"
+		[targets.staging-restricted]
+		denied_resources = [{group = "apps", version = "v1", kind = "Deployment"}]
+	`)))
+	s.Cfg.KubeConfig = test.KubeconfigFile(s.T(), kubeconfig)
+	s.InitMcpClient()
+	s.Run("resources_diff(destination) with a resource denied in the destination returns error", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "name": "api", "destination": "staging-restricted",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to diff resources: destination: ")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=Deployment")
+	})
+	s.Run("resources_diff(source) with a resource denied in the source returns error", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "name": "api", "source": "staging-restricted", "destination": "fake-context",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to diff resources: source: ")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=Deployment")
+	})
+	s.Run("resources_diff(source, destination) with selectors compares the matching targets", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "name": "api", "source": "env=staging", "destination": "env=prod",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		var diff kubernetes.ResourcesDiff
+		s.Require().NoError(json.Unmarshal([]byte(toolResult.Content[0].(*mcp.TextContent).Text), &diff))
+		s.Equal("staging", diff.Source)
+		s.Equal("fake-context", diff.Destination)
+		s.Equal(kubernetes.ResourcesDiffSummary{Identical: 1}, diff.Summary)
+	})
+}
+
+func TestResourcesDiff(t *testing.T) {
+	suite.Run(t, new(ResourcesDiffSuite))
+}
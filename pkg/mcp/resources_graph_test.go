@@ -0,0 +1,275 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type ResourcesGraphSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesGraphSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "ingresses", Kind: "Ingress", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discovery.APIResourceLists[0].APIResources = append(discovery.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "endpoints", Kind: "Endpoints", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discovery.APIResourceLists[1].APIResources = append(discovery.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	controller := func(apiVersion, kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+	labels := map[string]string{"app": "web"}
+	objects := map[string]runtime.Object{
+		"/apis/apps/v1/namespaces/default/deployments": &appsv1.DeploymentList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"},
+			Items: []appsv1.Deployment{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}},
+			},
+		},
+		"/apis/apps/v1/namespaces/default/deployments/web": &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		},
+		"/apis/apps/v1/namespaces/default/replicasets": &appsv1.ReplicaSetList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSetList"},
+			Items: []appsv1.ReplicaSet{{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default", Name: "web-abc", OwnerReferences: controller("apps/v1", "Deployment", "web"),
+			}}, {ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default", Name: "other-abc", OwnerReferences: controller("apps/v1", "Deployment", "other"),
+			}}},
+		},
+		"/api/v1/namespaces/default/pods": &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			Items: []v1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc-1", Labels: labels, OwnerReferences: controller("apps/v1", "ReplicaSet", "web-abc")},
+				Spec: v1.PodSpec{
+					ServiceAccountName: "web-sa",
+					Volumes: []v1.Volume{
+						{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}}}},
+						{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+					},
+					Containers: []v1.Container{{Name: "web", Env: []v1.EnvVar{{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "web-credentials"}, Key: "password"},
+					}}}}},
+				},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", OwnerReferences: controller("apps/v1", "ReplicaSet", "other-abc")},
+				Spec: v1.PodSpec{
+					// Shares the ServiceAccount with the unrelated web Deployment
+					ServiceAccountName: "web-sa",
+					Volumes:            []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "other-config"}}}}},
+				},
+			}},
+		},
+		"/api/v1/namespaces/default/services": &v1.ServiceList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceList"},
+			Items: []v1.Service{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}, Spec: v1.ServiceSpec{Selector: labels}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-headless"}, Spec: v1.ServiceSpec{Selector: labels}},
+			},
+		},
+		"/api/v1/namespaces/default/endpoints": &v1.EndpointsList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EndpointsList"},
+			Items: []v1.Endpoints{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Subsets: []v1.EndpointSubset{{Addresses: []v1.EndpointAddress{
+					{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-abc-1"}},
+				}}},
+			}},
+		},
+		"/apis/networking.k8s.io/v1/namespaces/default/ingresses": &networkingv1.IngressList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "IngressList"},
+			Items: []networkingv1.Ingress{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+						Path:    "/",
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}},
+					}}},
+				}}}},
+			}},
+		},
+		"/api/v1/namespaces/default/configmaps/web-config": &v1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-config"},
+		},
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if obj, ok := objects[req.URL.Path]; ok {
+			test.WriteObject(w, obj)
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ResourcesGraphSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesGraphSuite) TestResourcesGraphNamespace() {
+	s.InitMcpClient()
+	result, err := s.CallTool("resources_graph", map[string]interface{}{"namespace": "default"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+	})
+	graph := &kubernetes.ResourceGraph{}
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), graph))
+	s.Run("returns structured content", func() {
+		s.NotNil(result.StructuredContent)
+	})
+	s.Run("returns all nodes", func() {
+		var ids []string
+		for _, node := range graph.Nodes {
+			ids = append(ids, node.ID)
+		}
+		s.Equal([]string{
+			"ConfigMap/other-config", "ConfigMap/web-config", "Deployment/other", "Deployment/web", "Endpoints/web", "Ingress/web",
+			"PersistentVolumeClaim/web-data", "Pod/other", "Pod/web-abc-1", "ReplicaSet/other-abc", "ReplicaSet/web-abc", "Secret/web-credentials",
+			"Service/web", "Service/web-headless", "ServiceAccount/web-sa",
+		}, ids)
+	})
+	s.Run("marks referenced nodes", func() {
+		for _, node := range graph.Nodes {
+			s.Equalf(node.Kind == "ConfigMap" || node.Kind == "Secret" || node.Kind == "PersistentVolumeClaim" || node.Kind == "ServiceAccount",
+				node.Referenced, "unexpected referenced value for %s", node.ID)
+		}
+	})
+	s.Run("returns all edges", func() {
+		s.ElementsMatch([]kubernetes.GraphEdge{
+			{From: "Deployment/web", To: "ReplicaSet/web-abc", Relation: "owns"},
+			{From: "ReplicaSet/web-abc", To: "Pod/web-abc-1", Relation: "owns"},
+			{From: "Service/web", To: "Endpoints/web", Relation: "endpoints"},
+			{From: "Endpoints/web", To: "Pod/web-abc-1", Relation: "targets"},
+			{From: "Service/web-headless", To: "Pod/web-abc-1", Relation: "selects"},
+			{From: "Ingress/web", To: "Service/web", Relation: "routes"},
+			{From: "Pod/web-abc-1", To: "ConfigMap/web-config", Relation: "mounts"},
+			{From: "Pod/web-abc-1", To: "PersistentVolumeClaim/web-data", Relation: "mounts"},
+			{From: "Pod/web-abc-1", To: "Secret/web-credentials", Relation: "references"},
+			{From: "Pod/web-abc-1", To: "ServiceAccount/web-sa", Relation: "uses"},
+			{From: "Deployment/other", To: "ReplicaSet/other-abc", Relation: "owns"},
+			{From: "ReplicaSet/other-abc", To: "Pod/other", Relation: "owns"},
+			{From: "Pod/other", To: "ConfigMap/other-config", Relation: "mounts"},
+			{From: "Pod/other", To: "ServiceAccount/web-sa", Relation: "uses"},
+		}, graph.Edges)
+	})
+}
+
+func (s *ResourcesGraphSuite) TestResourcesGraphRoot() {
+	s.InitMcpClient()
+	result, err := s.CallTool("resources_graph", map[string]interface{}{
+		"namespace": "default", "apiVersion": "v1", "kind": "ConfigMap", "name": "web-config",
+	})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+	})
+	graph := &kubernetes.ResourceGraph{}
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), graph))
+	s.Run("returns root", func() {
+		s.Equal("ConfigMap/web-config", graph.Root)
+	})
+	s.Run("returns only connected nodes", func() {
+		for _, node := range graph.Nodes {
+			s.NotContains([]string{"Pod/other", "ConfigMap/other-config", "ReplicaSet/other-abc", "Deployment/other"}, node.ID)
+		}
+		s.Len(graph.Nodes, 11)
+	})
+	s.Run("root is no longer a referenced node", func() {
+		for _, node := range graph.Nodes {
+			if node.ID == "ConfigMap/web-config" {
+				s.False(node.Referenced)
+			}
+		}
+	})
+}
+
+func (s *ResourcesGraphSuite) TestResourcesGraphRootSharedLeaf() {
+	s.InitMcpClient()
+	result, err := s.CallTool("resources_graph", map[string]interface{}{
+		"namespace": "default", "apiVersion": "apps/v1", "kind": "Deployment", "name": "web",
+	})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+	})
+	graph := &kubernetes.ResourceGraph{}
+	s.Require().NoError(json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), graph))
+	s.Run("includes the ServiceAccount shared with the unrelated Deployment", func() {
+		s.Contains(graph.Edges, kubernetes.GraphEdge{From: "Pod/web-abc-1", To: "ServiceAccount/web-sa", Relation: "uses"})
+	})
+	s.Run("doesn't follow the shared ServiceAccount to the unrelated Deployment", func() {
+		var ids []string
+		for _, node := range graph.Nodes {
+			ids = append(ids, node.ID)
+		}
+		s.Equal([]string{
+			"ConfigMap/web-config", "Deployment/web", "Endpoints/web", "Ingress/web", "PersistentVolumeClaim/web-data",
+			"Pod/web-abc-1", "ReplicaSet/web-abc", "Secret/web-credentials", "Service/web", "Service/web-headless",
+			"ServiceAccount/web-sa",
+		}, ids)
+		s.NotContains(graph.Edges, kubernetes.GraphEdge{From: "Pod/other", To: "ServiceAccount/web-sa", Relation: "uses"})
+	})
+}
+
+func (s *ResourcesGraphSuite) TestResourcesGraphFormats() {
+	s.InitMcpClient()
+	s.Run("format=mermaid", func() {
+		result, err := s.CallTool("resources_graph", map[string]interface{}{"namespace": "default", "format": "mermaid"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		text := result.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "flowchart LR\n")
+		s.Regexp(`n\d+\["Deployment/web"\]`, text)
+		s.Regexp(`n\d+\("ConfigMap/web-config"\)`, text)
+		s.Regexp(`n\d+ -->\|owns\| n\d+`, text)
+		s.NotNil(result.StructuredContent)
+	})
+	s.Run("format=dot", func() {
+		result, err := s.CallTool("resources_graph", map[string]interface{}{"namespace": "default", "format": "dot"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		text := result.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "digraph \"default\" {\n")
+		s.Contains(text, "  \"Ingress/web\" -> \"Service/web\" [label=\"routes\"];\n")
+		s.Contains(text, "  \"Secret/web-credentials\" [shape=box, style=dashed];\n")
+	})
+	s.Run("format=invalid returns error", func() {
+		result, err := s.CallTool("resources_graph", map[string]interface{}{"namespace": "default", "format": "invalid"})
+		s.Truef(err != nil || result.IsError, "call tool should fail")
+	})
+}
+
+func TestResourcesGraph(t *testing.T) {
+	suite.Run(t, new(ResourcesGraphSuite))
+}
@@ -0,0 +1,299 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type RolloutSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	patches    map[string]string
+}
+
+func (s *RolloutSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.patches = map[string]string{}
+	s.mockServer = test.NewMockServer()
+	discovery := test.NewDiscoveryClientHandler()
+	discovery.APIResourceLists[1].APIResources = append(discovery.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}},
+		metav1.APIResource{Name: "daemonsets", Kind: "DaemonSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}},
+		metav1.APIResource{Name: "controllerrevisions", Kind: "ControllerRevision", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	s.mockServer.Handle(discovery)
+	labels := map[string]string{"app": "web"}
+	template := func(image string) v1.PodTemplateSpec {
+		return v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: image}}},
+		}
+	}
+	replicaSet := func(revision, image string) appsv1.ReplicaSet {
+		rsTemplate := template(image)
+		rsTemplate.Labels = map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "hash-" + revision}
+		return appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default", Name: "web-hash-" + revision, Labels: rsTemplate.Labels,
+				Annotations:     map[string]string{"deployment.kubernetes.io/revision": revision, "kubernetes.io/change-cause": "set image " + image},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "web-uid", Controller: ptr.To(true)}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: rsTemplate},
+		}
+	}
+	controllerRevision := func(revision int64, image string) appsv1.ControllerRevision {
+		data, _ := json.Marshal(map[string]any{"spec": map[string]any{"template": template(image)}})
+		return appsv1.ControllerRevision{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ControllerRevision"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default", Name: "db-" + image[len(image)-3:], Labels: labels,
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: "db-uid", Controller: ptr.To(true)}},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: revision,
+		}
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if req.Method == http.MethodPatch {
+			body, _ := io.ReadAll(req.Body)
+			s.patches[req.URL.Path] = req.Header.Get("Content-Type") + " " + string(body)
+		}
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/default/deployments/web":
+			test.WriteObject(w, &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default", Name: "web", UID: "web-uid", Generation: 2,
+					Annotations: map[string]string{"deployment.kubernetes.io/revision": "2"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(3)),
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: template("nginx:2.0"),
+				},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2,
+					Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "ReplicaSetUpdated"}},
+				},
+			})
+		case "/apis/apps/v1/namespaces/default/replicasets":
+			test.WriteObject(w, &appsv1.ReplicaSetList{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSetList"},
+				Items:    []appsv1.ReplicaSet{replicaSet("2", "nginx:2.0"), replicaSet("1", "nginx:1.0")},
+			})
+		case "/apis/apps/v1/namespaces/default/statefulsets/db":
+			test.WriteObject(w, &appsv1.StatefulSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db", UID: "db-uid", Generation: 1},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:       ptr.To(int32(1)),
+					Selector:       &metav1.LabelSelector{MatchLabels: labels},
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+				},
+				Status: appsv1.StatefulSetStatus{
+					ObservedGeneration: 1, Replicas: 1, ReadyReplicas: 1, CurrentReplicas: 1, UpdatedReplicas: 1,
+					CurrentRevision: "db-2.0", UpdateRevision: "db-2.0",
+				},
+			})
+		case "/apis/apps/v1/namespaces/default/controllerrevisions":
+			test.WriteObject(w, &appsv1.ControllerRevisionList{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ControllerRevisionList"},
+				Items:    []appsv1.ControllerRevision{controllerRevision(1, "postgres:1.0"), controllerRevision(2, "postgres:2.0")},
+			})
+		}
+	}))
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *RolloutSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *RolloutSuite) TestRolloutStatus() {
+	s.InitMcpClient()
+	s.Run("rollout_status(kind=Deployment, name=web)", func() {
+		result, err := s.CallTool("rollout_status", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns progress", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "done: false\n")
+			s.Regexp(`message: 'Waiting for deployment "web" rollout to finish: 2 out of 3 new replicas\s+have been updated'`, text)
+			s.Contains(text, "- Progressing=True (ReplicaSetUpdated)\n")
+		})
+	})
+	s.Run("rollout_status(kind=StatefulSet, name=db)", func() {
+		result, err := s.CallTool("rollout_status", map[string]interface{}{"kind": "StatefulSet", "namespace": "default", "name": "db"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns complete rollout", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "done: true\n")
+			s.Contains(text, "message: statefulset rolling update complete 1 pods at revision db-2.0\n")
+		})
+	})
+	s.Run("rollout_status(kind=ReplicaSet) returns error", func() {
+		result, _ := s.CallTool("rollout_status", map[string]interface{}{"kind": "ReplicaSet", "namespace": "default", "name": "web-hash-1"})
+		s.Truef(result.IsError, "call tool should fail")
+		s.Equal("failed to get rollout status of ReplicaSet web-hash-1: unsupported kind ReplicaSet, supported kinds are: Deployment, StatefulSet, DaemonSet",
+			result.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *RolloutSuite) TestRolloutHistory() {
+	s.InitMcpClient()
+	s.Run("rollout_history(kind=Deployment, name=web)", func() {
+		result, err := s.CallTool("rollout_history", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns sorted ReplicaSet revisions", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Regexp(`(?s)changeCause: set image nginx:1.0\n.*revision: 1\n.*revision: 2\n`, text)
+			s.Regexp(`(?s)- changeCause: set image nginx:2.0\n  created: \S+\n  current: true\n  images:\n  - nginx:2.0`, text)
+		})
+	})
+	s.Run("rollout_history(kind=StatefulSet, name=db)", func() {
+		result, err := s.CallTool("rollout_history", map[string]interface{}{"kind": "StatefulSet", "namespace": "default", "name": "db"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("returns ControllerRevision revisions", func() {
+			text := result.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "- postgres:1.0\n  name: db-1.0\n  revision: 1\n")
+			s.Contains(text, "current: true\n  images:\n  - postgres:2.0\n  name: db-2.0\n  revision: 2\n")
+		})
+	})
+}
+
+func (s *RolloutSuite) TestRolloutRestart() {
+	s.InitMcpClient()
+	result, err := s.CallTool("rollout_restart", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+	})
+	s.Run("patches restartedAt annotation", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		patch := s.patches["/apis/apps/v1/namespaces/default/deployments/web"]
+		s.True(strings.HasPrefix(patch, string(types.MergePatchType)+" "), "expected merge patch, got %s", patch)
+		s.Contains(patch, `"kubectl.kubernetes.io/restartedAt"`)
+	})
+}
+
+func (s *RolloutSuite) TestRolloutUndo() {
+	s.InitMcpClient()
+	s.Run("rollout_undo(kind=Deployment, name=web) rolls back to previous revision", func() {
+		result, err := s.CallTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("reports revision", func() {
+			s.Equal("Deployment web rolled back to revision 1, use rollout_status to follow the progress", result.Content[0].(*mcp.TextContent).Text)
+		})
+		s.Run("replaces template without pod-template-hash", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			patch := s.patches["/apis/apps/v1/namespaces/default/deployments/web"]
+			s.True(strings.HasPrefix(patch, string(types.JSONPatchType)+" "), "expected JSON patch, got %s", patch)
+			s.Contains(patch, `"path":"/spec/template"`)
+			s.Contains(patch, `"image":"nginx:1.0"`)
+			s.NotContains(patch, appsv1.DefaultDeploymentUniqueLabelKey)
+		})
+	})
+	s.Run("rollout_undo(kind=Deployment, name=web, toRevision=2) skips current revision", func() {
+		result, err := s.CallTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web", "toRevision": 2})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("Deployment web is already at revision 2, skipped rollback", result.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("rollout_undo(kind=Deployment, name=web, toRevision=5) returns error", func() {
+		result, _ := s.CallTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web", "toRevision": 5})
+		s.Truef(result.IsError, "call tool should fail")
+		s.Equal("failed to undo rollout of Deployment web: revision 5 not found for Deployment web", result.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("rollout_undo(kind=StatefulSet, name=db) applies ControllerRevision data", func() {
+		result, err := s.CallTool("rollout_undo", map[string]interface{}{"kind": "StatefulSet", "namespace": "default", "name": "db"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		})
+		s.Run("applies strategic merge patch", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			patch := s.patches["/apis/apps/v1/namespaces/default/statefulsets/db"]
+			s.True(strings.HasPrefix(patch, string(types.StrategicMergePatchType)+" "), "expected strategic merge patch, got %s", patch)
+			s.Contains(patch, `"image":"postgres:1.0"`)
+		})
+	})
+}
+
+func (s *RolloutSuite) TestRolloutPauseResume() {
+	s.InitMcpClient()
+	s.Run("rollout_pause(name=web)", func() {
+		result, err := s.CallTool("rollout_pause", map[string]interface{}{"namespace": "default", "name": "web"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(result.IsError, "call tool failed: %v", result.Content)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.Equal(string(types.MergePatchType)+` {"spec":{"paused":true}}`, s.patches["/apis/apps/v1/namespaces/default/deployments/web"])
+	})
+	s.Run("rollout_resume(name=web) returns error when not paused", func() {
+		result, _ := s.CallTool("rollout_resume", map[string]interface{}{"namespace": "default", "name": "web"})
+		s.Truef(result.IsError, "call tool should fail")
+		s.Equal("failed to resume rollout of Deployment web: Deployment web is not paused", result.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *RolloutSuite) TestRolloutDisableDestructive() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		disable_destructive = true
+	`), s.Cfg), "Expected to parse disable destructive config")
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	s.Contains(names, "rollout_status")
+	s.Contains(names, "rollout_history")
+	for _, name := range []string{"rollout_restart", "rollout_undo", "rollout_pause", "rollout_resume"} {
+		s.NotContains(names, name, "destructive rollout tools should not be available in disable_destructive mode")
+	}
+}
+
+func TestRollout(t *testing.T) {
+	suite.Run(t, new(RolloutSuite))
+}
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// forTarget returns the configuration for the target, with the target overrides applied
+func (c *Configuration) forTarget(target string) *Configuration {
+	staticConfig := c.StaticConfig.ForTarget(target)
+	if staticConfig == c.StaticConfig {
+		return c
+	}
+	return &Configuration{StaticConfig: staticConfig, listOutput: c.listOutput}
+}
+
+func (c *Configuration) isToolsetEnabled(toolset api.Toolset) bool {
+	return slices.ContainsFunc(c.Toolsets(), func(t api.Toolset) bool {
+		return t.GetName() == toolset.GetName()
+	})
+}
+
+// allToolsets returns the toolsets enabled globally or in any of the targets
+func (c *Configuration) allToolsets() []api.Toolset {
+	toolsets := slices.Clone(c.Toolsets())
+	for _, target := range slices.Sorted(maps.Keys(c.Targets)) {
+		for _, toolset := range c.forTarget(target).Toolsets() {
+			if !slices.ContainsFunc(toolsets, func(t api.Toolset) bool { return t.GetName() == toolset.GetName() }) {
+				toolsets = append(toolsets, toolset)
+			}
+		}
+	}
+	return toolsets
+}
+
+// deniedTargets returns the targets in which the tool of the toolset is not available because of their configuration
+func (c *Configuration) deniedTargets(toolset api.Toolset, tool api.ServerTool, targets []string) []string {
+	denied := make([]string, 0)
+	for _, target := range targets {
+		targetConfiguration := c.forTarget(target)
+		if !targetConfiguration.isToolsetEnabled(toolset) || !targetConfiguration.isToolApplicable(tool) {
+			denied = append(denied, target)
+		}
+	}
+	return denied
+}
+
+// withDeniedTargets documents the targets in which the tool is not available and removes them from the target enum
+func withDeniedTargets(tool api.ServerTool, targetParameterName string, deniedTargets []string) api.ServerTool {
+	if len(deniedTargets) == 0 {
+		return tool
+	}
+	tool.Tool.Description += fmt.Sprintf("\n\nNot available in the following %ss due to their configuration: %s",
+		targetParameterName, strings.Join(deniedTargets, ", "))
+	if property, ok := tool.Tool.InputSchema.Properties[targetParameterName]; ok && property.Enum != nil {
+		property.Enum = slices.DeleteFunc(property.Enum, func(target any) bool {
+			return slices.Contains(deniedTargets, target.(string))
+		})
+	}
+	return tool
+}
+
+// targetContext checks that the tool is available in the tool call target and returns the context to run the tool
+// in the target, enforcing the denied resources of the target
+func (s *Server) targetContext(ctx context.Context, tool api.ServerTool, target string) (context.Context, error) {
+	if !tool.IsClusterAware() {
+		return ctx, nil
+	}
+	return s.targetOverridesContext(ctx, tool, target)
+}
+
+// targetOverridesContext is targetContext for any tool, including the ones that operate on several targets at once
+// (e.g. resources_diff) and select their targets with their own parameters
+func (s *Server) targetOverridesContext(ctx context.Context, tool api.ServerTool, target string) (context.Context, error) {
+	if !s.configuration.HasTargetOverrides() {
+		return ctx, nil
+	}
+	if target == "" {
+		target = s.p.GetDefaultTarget()
+	}
+	targetConfiguration := s.configuration.forTarget(target)
+	s.mu.RLock()
+	toolset, ok := s.toolsets[tool.Tool.Name]
+	s.mu.RUnlock()
+	if (ok && !targetConfiguration.isToolsetEnabled(toolset)) || !targetConfiguration.isToolApplicable(tool) {
+		return nil, fmt.Errorf("tool %s is not available in %s %s due to its configuration", tool.Tool.Name, s.p.GetTargetParameterName(), target)
+	}
+	return api.WithDeniedResources(ctx, targetConfiguration.DeniedResources), nil
+}
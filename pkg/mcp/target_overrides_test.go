@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type TargetOverridesSuite struct {
+	BaseMcpSuite
+	mockServers map[string]*test.MockServer
+}
+
+func (s *TargetOverridesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServers = map[string]*test.MockServer{"fake": fanOutTestServer("prod"), "staging": fanOutTestServer("staging")}
+	kubeconfig := s.mockServers["fake"].Kubeconfig()
+	staging := s.mockServers["staging"].Kubeconfig()
+	kubeconfig.Clusters["staging"] = staging.Clusters["fake"]
+	kubeconfig.AuthInfos["staging"] = staging.AuthInfos["fake"]
+	kubeconfig.Contexts["staging"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		list_output = "name"
+		read_only = true
+		toolsets = ["core", "config", "helm"]
+		[targets.fake-context]
+		denied_resources = [{group = "", version = "v1", kind = "Pod"}]
+		[targets.staging]
+		read_only = false
+		toolsets = ["core"]
+	`)))
+	s.Cfg.KubeConfig = test.KubeconfigFile(s.T(), kubeconfig)
+}
+
+func (s *TargetOverridesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	for _, mockServer := range s.mockServers {
+		mockServer.Close()
+	}
+}
+
+func (s *TargetOverridesSuite) TestToolsList() {
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	toolsByName := make(map[string]*mcp.Tool, len(tools.Tools))
+	for _, tool := range tools.Tools {
+		toolsByName[tool.Name] = tool
+	}
+	s.Run("includes the tools available in some of the targets", func() {
+		s.Contains(toolsByName, "pods_delete")
+		s.Contains(toolsByName, "helm_list")
+	})
+	s.Run("excludes the tools not available in any of the targets", func() {
+		s.NotContains(toolsByName, "helm_install")
+	})
+	s.Run("describes the targets in which the tool is not available", func() {
+		s.Contains(toolsByName["pods_delete"].Description, "Not available in the following contexts due to their configuration: fake-context")
+		s.Contains(toolsByName["helm_list"].Description, "Not available in the following contexts due to their configuration: staging")
+		s.NotContains(toolsByName["pods_list"].Description, "Not available")
+	})
+	s.Run("removes the targets in which the tool is not available from the context enum", func() {
+		properties := toolsByName["pods_delete"].InputSchema.(map[string]any)["properties"].(map[string]any)
+		s.Equal([]any{"staging"}, properties["context"].(map[string]any)["enum"])
+	})
+	s.Run("keeps the global configuration for the tools that don't run in a target", func() {
+		s.Contains(toolsByName, "configuration_view")
+		s.NotContains(toolsByName["configuration_view"].Description, "Not available")
+	})
+}
+
+func (s *TargetOverridesSuite) TestToolCall() {
+	s.InitMcpClient()
+	s.Run("pods_delete in a read-only context returns error", func() {
+		toolResult, err := s.CallTool("pods_delete", map[string]interface{}{"name": "pod-prod", "context": "fake-context"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Equal("tool pods_delete is not available in context fake-context due to its configuration", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_delete in the default context returns error if it's read-only", func() {
+		toolResult, err := s.CallTool("pods_delete", map[string]interface{}{"name": "pod-prod"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "not available in context fake-context")
+	})
+	s.Run("helm_list in a context without the helm toolset returns error", func() {
+		toolResult, err := s.CallTool("helm_list", map[string]interface{}{"context": "staging"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Equal("tool helm_list is not available in context staging due to its configuration", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_list in a context denying pods returns error", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"context": "fake-context"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+	})
+	s.Run("pods_list in a context allowing pods returns the pods", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"context": "staging"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("pod/pod-staging\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_list(targets) enforces the denied resources of each context", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"fake-context", "staging"}})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "pod/pod-staging\n# Failed contexts (1/2):\n# - fake-context: ")
+	})
+}
+
+func TestTargetOverrides(t *testing.T) {
+	suite.Run(t, new(TargetOverridesSuite))
+}
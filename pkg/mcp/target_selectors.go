@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// WithTargetSelectors documents the target selectors in the target and targets parameters when the targets have
+// configured metadata. The target enum is removed since the parameter accepts selectors besides the target names.
+func WithTargetSelectors(targetParameterName string, metadata map[string]config.TargetConfig) ToolMutator {
+	return func(tool api.ServerTool) api.ServerTool {
+		if !hasTargetMetadata(metadata) || tool.Tool.InputSchema == nil {
+			return tool
+		}
+		if property, ok := tool.Tool.InputSchema.Properties[targetParameterName]; ok {
+			property.Enum = nil
+			property.Description += fmt.Sprintf(
+				". Accepts a %s name, or a selector of the %s metadata listed by %s_list (e.g. env=prod, region=eu-west-1) matching a single %s",
+				targetParameterName, targetParameterName, targetParameterName, targetParameterName)
+		}
+		if property, ok := tool.Tool.InputSchema.Properties[TargetsParameterName]; ok {
+			property.Description += fmt.Sprintf(
+				". Each item can also be a selector of the %s metadata listed by %s_list (e.g. env=prod) selecting all the matching %ss",
+				targetParameterName, targetParameterName, targetParameterName)
+		}
+		return tool
+	}
+}
+
+// hasTargetMetadata returns true if any of the targets has metadata to select it with
+func hasTargetMetadata(metadata map[string]config.TargetConfig) bool {
+	for _, target := range metadata {
+		if target.HasMetadata() {
+			return true
+		}
+	}
+	return false
+}
+
+// isTargetSelector returns true if the target parameter value is a selector of the target metadata (e.g. env=prod)
+func isTargetSelector(value string) bool {
+	return strings.ContainsAny(value, "=!") || strings.Contains(value, " in ") || strings.Contains(value, " notin ")
+}
+
+// selectTargets returns the targets whose metadata matches the selector, sorted by name
+func selectTargets(targets []string, metadata map[string]config.TargetConfig, selector string) ([]string, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %s: %w", selector, err)
+	}
+	selected := make([]string, 0)
+	for _, target := range targets {
+		if parsed.Matches(metadata[target].SelectorLabels()) {
+			selected = append(selected, target)
+		}
+	}
+	slices.Sort(selected)
+	return selected, nil
+}
+
+// expandTarget returns the targets selected by the target parameter value, either the target itself or the targets
+// matching the selector
+func (s *Server) expandTarget(ctx context.Context, value string) ([]string, error) {
+	if !isTargetSelector(value) {
+		return []string{value}, nil
+	}
+	targets, err := s.p.GetTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(targets, value) {
+		return []string{value}, nil
+	}
+	selected, err := selectTargets(targets, s.configuration.Targets, value)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no %s matches the selector %s", s.p.GetTargetParameterName(), value)
+	}
+	return selected, nil
+}
+
+// resolveTarget returns the target selected by the target parameter value, selectors must match a single target
+func (s *Server) resolveTarget(ctx context.Context, value string) (string, error) {
+	selected, err := s.expandTarget(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	if len(selected) > 1 {
+		return "", fmt.Errorf("the selector %s matches several %ss (%s), refine it or use the %s parameter",
+			value, s.p.GetTargetParameterName(), strings.Join(selected, ", "), TargetsParameterName)
+	}
+	return selected[0], nil
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type TargetSelectorsSuite struct {
+	BaseMcpSuite
+	mockServers map[string]*test.MockServer
+}
+
+func (s *TargetSelectorsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServers = map[string]*test.MockServer{"fake": fanOutTestServer("prod"), "staging": fanOutTestServer("staging")}
+	kubeconfig := s.mockServers["fake"].Kubeconfig()
+	staging := s.mockServers["staging"].Kubeconfig()
+	kubeconfig.Clusters["staging"] = staging.Clusters["fake"]
+	kubeconfig.AuthInfos["staging"] = staging.AuthInfos["fake"]
+	kubeconfig.Contexts["staging"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	kubeconfig.Contexts["staging-eu"] = &clientcmdapi.Context{Cluster: "staging", AuthInfo: "staging"}
+	s.Cfg = test.Must(config.ReadToml([]byte(`
+		list_output = "yaml"
+		[targets.fake-context]
+		environment = "prod"
+		region = "us-east-1"
+		labels = { team = "payments" }
+		[targets.staging]
+		environment = "staging"
+		region = "us-east-1"
+		[targets.staging-eu]
+		environment = "staging"
+		region = "eu-west-1"
+	`)))
+	s.Cfg.KubeConfig = test.KubeconfigFile(s.T(), kubeconfig)
+}
+
+func (s *TargetSelectorsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	for _, mockServer := range s.mockServers {
+		mockServer.Close()
+	}
+}
+
+func (s *TargetSelectorsSuite) TestTargetParameter() {
+	s.InitMcpClient()
+	s.Run("pods_list(context=env=prod) runs in the matching context", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"context": "env=prod"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: pod-prod")
+	})
+	s.Run("pods_list(context=environment=staging,region=eu-west-1) runs in the matching context", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"context": "environment=staging,region=eu-west-1"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: pod-staging")
+	})
+	s.Run("pods_list(context=team=payments) matches the configured labels", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"context": "team=payments"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: pod-prod")
+	})
+	s.Run("pods_list(context=env=staging) fails if the selector matches several contexts", func() {
+		_, err := s.CallTool("pods_list", map[string]interface{}{"context": "env=staging"})
+		s.ErrorContains(err, "the selector env=staging matches several contexts (staging, staging-eu)")
+	})
+	s.Run("pods_list(context=env=dev) fails if the selector matches no context", func() {
+		_, err := s.CallTool("pods_list", map[string]interface{}{"context": "env=dev"})
+		s.ErrorContains(err, "no context matches the selector env=dev")
+	})
+}
+
+func (s *TargetSelectorsSuite) TestTargetsParameter() {
+	s.InitMcpClient()
+	s.Run("pods_list(targets=[env=staging]) runs in all the matching contexts", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"env=staging"}, "output": "name"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("pod/pod-staging\npod/pod-staging\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_list(targets=[region=us-east-1, staging]) deduplicates the selected contexts", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"region=us-east-1", "staging"}, "output": "csv"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("NAMESPACE,TARGET,APIVERSION,KIND,NAME\n"+
+			"default,fake-context,v1,Pod,pod-prod\n"+
+			"default,staging,v1,Pod,pod-staging\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_list(targets=[env in (]) fails with invalid selector", func() {
+		toolResult, err := s.CallTool("pods_list", map[string]interface{}{"targets": []string{"env in ("}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to run pods_list, invalid selector env in (")
+	})
+}
+
+func (s *TargetSelectorsSuite) TestToolSchema() {
+	s.InitMcpClient()
+	tools, err := s.ListTools()
+	s.Require().NoError(err)
+	for _, tool := range tools.Tools {
+		if tool.Name != "pods_list" {
+			continue
+		}
+		properties := tool.InputSchema.(map[string]any)["properties"].(map[string]any)
+		s.Run("context parameter has no enum", func() {
+			s.NotContains(properties["context"], "enum")
+		})
+		s.Run("context parameter documents the selectors", func() {
+			s.Contains(properties["context"].(map[string]any)["description"], "selector of the context metadata")
+		})
+		s.Run("targets parameter documents the selectors", func() {
+			s.Contains(properties["targets"].(map[string]any)["description"], "selecting all the matching contexts")
+		})
+	}
+}
+
+func TestTargetSelectors(t *testing.T) {
+	suite.Run(t, new(TargetSelectorsSuite))
+}
@@ -46,3 +46,10 @@ func ShouldIncludeTargetListTool(targetName string, targets []string) ToolFilter
 		return true
 	}
 }
+
+func ShouldIncludeCrossTargetTool(targets []string) ToolFilter {
+	return func(tool api.ServerTool) bool {
+		// there is nothing to operate across with a single available target
+		return !tool.IsCrossTarget() || len(targets) > 1
+	}
+}
@@ -79,6 +79,26 @@ func (s *ToolFilterSuite) TestShouldIncludeTargetListTool() {
 	})
 }
 
+func (s *ToolFilterSuite) TestShouldIncludeCrossTargetTool() {
+	s.Run("non-cross-target tools: returns true", func() {
+		filter := ShouldIncludeCrossTargetTool([]string{"1"})
+		tool := api.ServerTool{Tool: api.Tool{Name: "test"}}
+		s.True(filter(tool))
+	})
+	s.Run("cross-target tools", func() {
+		s.Run("with targets == 1: returns false", func() {
+			filter := ShouldIncludeCrossTargetTool([]string{"1"})
+			tool := api.ServerTool{Tool: api.Tool{Name: "test"}, CrossTarget: ptr.To(true)}
+			s.False(filter(tool))
+		})
+		s.Run("with targets > 1: returns true", func() {
+			filter := ShouldIncludeCrossTargetTool([]string{"1", "2"})
+			tool := api.ServerTool{Tool: api.Tool{Name: "test"}, CrossTarget: ptr.To(true)}
+			s.True(filter(tool))
+		})
+	})
+}
+
 func TestToolFilter(t *testing.T) {
 	suite.Run(t, new(ToolFilterSuite))
 }
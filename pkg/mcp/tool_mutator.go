@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/openshift"
 	"github.com/google/jsonschema-go/jsonschema"
 )
 
@@ -80,7 +83,7 @@ func createTargetProperty(defaultCluster, targetName string, targets []string) *
 // WithTargetListTool mutates the generic "targets_list" tool to have the correct name,
 // description, and handler based on the provider's target parameter name.
 // For example, with ACM provider (targetParameterName="cluster"), it becomes "cluster_list".
-func WithTargetListTool(defaultTarget, targetParameterName string, targets []string) ToolMutator {
+func WithTargetListTool(defaultTarget, targetParameterName string, targets []string, metadata map[string]config.TargetConfig) ToolMutator {
 	return func(tool api.ServerTool) api.ServerTool {
 		if tool.Tool.Name != TargetsListToolName {
 			return tool
@@ -88,18 +91,25 @@ func WithTargetListTool(defaultTarget, targetParameterName string, targets []str
 
 		// Rename tool based on target parameter name
 		tool.Tool.Name = fmt.Sprintf("%s_list", targetParameterName)
-		tool.Tool.Description = fmt.Sprintf("List all available %ss that can be targeted by tools", targetParameterName)
+		tool.Tool.Description = fmt.Sprintf("List all available %ss that can be targeted by tools, "+
+			"with their metadata (environment, region, OpenShift, server URL and labels)", targetParameterName)
 		tool.Tool.Annotations.Title = fmt.Sprintf("%s List", capitalizeFirst(targetParameterName))
 
 		// Set the handler with captured targets
-		tool.Handler = createTargetListHandler(targets, targetParameterName, defaultTarget)
+		tool.Handler = createTargetListHandler(targets, targetParameterName, defaultTarget, metadata)
 
 		return tool
 	}
 }
 
-func createTargetListHandler(targets []string, targetParameterName, defaultTarget string) api.ToolHandlerFunc {
-	return func(_ api.ToolHandlerParams) (*api.ToolCallResult, error) {
+// targetList is the structured content of the targets list tool
+type targetList struct {
+	Default string               `json:"default"`
+	Targets []api.TargetMetadata `json:"targets"`
+}
+
+func createTargetListHandler(targets []string, targetParameterName, defaultTarget string, metadata map[string]config.TargetConfig) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		if len(targets) == 0 {
 			return api.NewToolCallResult(fmt.Sprintf("No %ss available", targetParameterName), nil), nil
 		}
@@ -109,22 +119,89 @@ func createTargetListHandler(targets []string, targetParameterName, defaultTarge
 		copy(sortedTargets, targets)
 		sort.Strings(sortedTargets)
 
+		list := targetList{Default: defaultTarget, Targets: targetsMetadata(params, sortedTargets, defaultTarget, metadata)}
+
 		result := fmt.Sprintf("Available %ss (%d total, default: %s):\n\n", targetParameterName, len(sortedTargets), defaultTarget)
-		result += fmt.Sprintf("Format: [*] %s_NAME\n", strings.ToUpper(targetParameterName))
+		result += fmt.Sprintf("Format: [*] %s_NAME [(METADATA)]\n", strings.ToUpper(targetParameterName))
 		result += fmt.Sprintf(" (* indicates the default %s used in tools if %s is not set)\n\n", targetParameterName, targetParameterName)
 		result += fmt.Sprintf("%ss:\n---------\n", capitalizeFirst(targetParameterName))
-		for _, target := range sortedTargets {
+		for _, target := range list.Targets {
 			marker := " "
-			if target == defaultTarget {
+			if target.Default {
 				marker = "*"
 			}
-			result += fmt.Sprintf("%s %s\n", marker, target)
+			result += fmt.Sprintf("%s %s%s\n", marker, target.Name, formatTargetMetadata(target))
 		}
 		result += "---------\n\n"
 		result += fmt.Sprintf("To use a specific %s with any tool, set the '%s' parameter in the tool call arguments", targetParameterName, targetParameterName)
+		if hasTargetMetadata(metadata) {
+			result += ", either to its name or to a selector of its metadata (e.g. env=prod)"
+		}
+
+		return &api.ToolCallResult{Content: result, StructuredContent: list}, nil
+	}
+}
+
+// targetsMetadata returns the configured metadata of the targets completed with their server URL and OpenShift flag
+func targetsMetadata(params api.ToolHandlerParams, targets []string, defaultTarget string, metadata map[string]config.TargetConfig) []api.TargetMetadata {
+	ret := make([]api.TargetMetadata, len(targets))
+	wg := sync.WaitGroup{}
+	for i, target := range targets {
+		ret[i] = api.TargetMetadata{
+			Name:        target,
+			Default:     target == defaultTarget,
+			Environment: metadata[target].Environment,
+			Region:      metadata[target].Region,
+			Labels:      metadata[target].Labels,
+		}
+		if params.Targets == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			k, err := params.Targets.GetKubernetesClient(params, target)
+			if err != nil {
+				ret[i].Error = err.Error()
+				return
+			}
+			ret[i].ServerURL = k.RESTConfig().Host
+			ret[i].OpenShift = openshift.IsOpenshift(k.DiscoveryClient())
+		}()
+	}
+	wg.Wait()
+	return ret
+}
 
-		return api.NewToolCallResult(result, nil), nil
+func formatTargetMetadata(target api.TargetMetadata) string {
+	var fields []string
+	if target.Environment != "" {
+		fields = append(fields, "environment="+target.Environment)
+	}
+	if target.Region != "" {
+		fields = append(fields, "region="+target.Region)
+	}
+	labelKeys := make([]string, 0, len(target.Labels))
+	for k := range target.Labels {
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		fields = append(fields, k+"="+target.Labels[k])
+	}
+	if target.OpenShift {
+		fields = append(fields, "openshift")
+	}
+	if target.ServerURL != "" {
+		fields = append(fields, "server="+target.ServerURL)
+	}
+	if target.Error != "" {
+		fields = append(fields, "error="+target.Error)
+	}
+	if len(fields) == 0 {
+		return ""
 	}
+	return " (" + strings.Join(fields, ", ") + ")"
 }
 
 func capitalizeFirst(s string) string {
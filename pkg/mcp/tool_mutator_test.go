@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -352,7 +353,7 @@ type TargetListToolMutatorSuite struct {
 
 func (s *TargetListToolMutatorSuite) TestMutatesTargetsListTool() {
 	tool := createTestTool(TargetsListToolName)
-	tm := WithTargetListTool("default-cluster", "cluster", []string{"cluster-1", "cluster-2", "cluster-3"})
+	tm := WithTargetListTool("default-cluster", "cluster", []string{"cluster-1", "cluster-2", "cluster-3"}, nil)
 	result := tm(tool)
 
 	s.Run("renames tool based on target parameter", func() {
@@ -372,7 +373,7 @@ func (s *TargetListToolMutatorSuite) TestMutatesTargetsListTool() {
 
 func (s *TargetListToolMutatorSuite) TestDoesNotMutateOtherTools() {
 	tool := createTestTool("some-other-tool")
-	tm := WithTargetListTool("default", "cluster", []string{"cluster-1", "cluster-2"})
+	tm := WithTargetListTool("default", "cluster", []string{"cluster-1", "cluster-2"}, nil)
 	result := tm(tool)
 
 	s.Equal("some-other-tool", result.Tool.Name, "tool name should remain unchanged")
@@ -380,7 +381,7 @@ func (s *TargetListToolMutatorSuite) TestDoesNotMutateOtherTools() {
 
 func (s *TargetListToolMutatorSuite) TestHandlerWithEmptyTargets() {
 	tool := createTestTool(TargetsListToolName)
-	tm := WithTargetListTool("default", "cluster", []string{})
+	tm := WithTargetListTool("default", "cluster", []string{}, nil)
 	result := tm(tool)
 
 	s.Require().NotNil(result.Handler)
@@ -389,6 +390,30 @@ func (s *TargetListToolMutatorSuite) TestHandlerWithEmptyTargets() {
 	s.Contains(callResult.Content, "No clusters available")
 }
 
+func (s *TargetListToolMutatorSuite) TestHandlerWithMetadata() {
+	tool := createTestTool(TargetsListToolName)
+	tm := WithTargetListTool("cluster-1", "cluster", []string{"cluster-2", "cluster-1"}, map[string]config.TargetConfig{
+		"cluster-1": {Environment: "prod", Region: "eu-west-1", Labels: map[string]string{"team": "payments"}},
+	})
+	result := tm(tool)
+
+	s.Require().NotNil(result.Handler)
+	callResult, err := result.Handler(api.ToolHandlerParams{})
+	s.Require().NoError(err)
+	s.Run("lists the targets with their metadata", func() {
+		s.Contains(callResult.Content, "* cluster-1 (environment=prod, region=eu-west-1, team=payments)\n  cluster-2\n")
+	})
+	s.Run("mentions the selectors", func() {
+		s.Contains(callResult.Content, "or to a selector of its metadata (e.g. env=prod)")
+	})
+	s.Run("returns the targets as structured content", func() {
+		s.Equal(targetList{Default: "cluster-1", Targets: []api.TargetMetadata{
+			{Name: "cluster-1", Default: true, Environment: "prod", Region: "eu-west-1", Labels: map[string]string{"team": "payments"}},
+			{Name: "cluster-2"},
+		}}, callResult.StructuredContent)
+	})
+}
+
 func TestTargetListToolMutator(t *testing.T) {
 	suite.Run(t, new(TargetListToolMutatorSuite))
 }
@@ -13,12 +13,14 @@ import (
 	configuration "github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/alertmanager"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kcp"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kiali"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/prometheus"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -161,6 +163,8 @@ func (s *ToolsetsSuite) TestGranularToolsetsTools() {
 		&helm.Toolset{},
 		&kiali.Toolset{},
 		&kubevirt.Toolset{},
+		&prometheus.Toolset{},
+		&alertmanager.Toolset{},
 	}
 	for _, testCase := range testCases {
 		s.Run("Toolset "+testCase.GetName(), func() {
@@ -189,20 +193,20 @@ func (s *ToolsetsSuite) TestInputSchemaEdgeCases() {
 			s.NotNil(tools, "Expected tools from ListTools")
 			s.NoError(err, "Expected no error from ListTools")
 		})
-		var namespacesList *mcp.Tool
+		var portForwardsList *mcp.Tool
 		for _, t := range tools.Tools {
-			if t.Name == "namespaces_list" {
-				namespacesList = t
+			if t.Name == "port_forwards_list" {
+				portForwardsList = t
 				break
 			}
 		}
-		s.Require().NotNil(namespacesList, "Expected namespaces_list from ListTools")
-		schema, ok := namespacesList.InputSchema.(map[string]any)
+		s.Require().NotNil(portForwardsList, "Expected port_forwards_list from ListTools")
+		schema, ok := portForwardsList.InputSchema.(map[string]any)
 		s.Require().True(ok, "Expected InputSchema to be map[string]any")
-		s.NotNil(schema["properties"], "Expected namespaces_list.InputSchema.properties not to be nil")
+		s.NotNil(schema["properties"], "Expected port_forwards_list.InputSchema.properties not to be nil")
 		properties, ok := schema["properties"].(map[string]any)
 		s.Require().True(ok, "Expected properties to be map[string]any")
-		s.Empty(properties, "Expected namespaces_list.InputSchema.properties to be empty")
+		s.Empty(properties, "Expected port_forwards_list.InputSchema.properties to be empty")
 	})
 	// https://github.com/containers/kubernetes-mcp-server/issues/717
 	// Verifies ALL tools have Properties initialized (not just cluster-aware ones)
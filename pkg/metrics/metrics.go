@@ -18,6 +18,8 @@ type Config struct {
 	// Telemetry is the optional telemetry configuration.
 	// If nil, env vars will be used for OTLP configuration.
 	Telemetry *config.TelemetryConfig
+	// DerivedClientCacheStats optionally provides the statistics of the derived clients cache to observe.
+	DerivedClientCacheStats func() CacheStats
 }
 
 // Metrics coordinates multiple metric collectors.
@@ -37,10 +39,11 @@ func New(cfg Config) (*Metrics, error) {
 	// Stats collector - always enabled for /stats endpoint
 	// Also exports to OTLP if OTEL_EXPORTER_OTLP_ENDPOINT is set or Telemetry config is provided
 	stats, err := NewOtelStatsCollectorWithConfig(CollectorConfig{
-		MeterName:      cfg.TracerName,
-		ServiceName:    cfg.ServiceName,
-		ServiceVersion: cfg.ServiceVersion,
-		Telemetry:      cfg.Telemetry,
+		MeterName:               cfg.TracerName,
+		ServiceName:             cfg.ServiceName,
+		ServiceVersion:          cfg.ServiceVersion,
+		Telemetry:               cfg.Telemetry,
+		DerivedClientCacheStats: cfg.DerivedClientCacheStats,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stats collector: %w", err)
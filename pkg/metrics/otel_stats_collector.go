@@ -38,6 +38,12 @@ type Statistics struct {
 	HTTPRequestsByStatus map[string]int64 `json:"http_requests_by_status"`
 	HTTPRequestsByMethod map[string]int64 `json:"http_requests_by_method"`
 
+	// Derived clients cache metrics
+	DerivedClientCacheHits      int64   `json:"derived_client_cache_hits"`
+	DerivedClientCacheMisses    int64   `json:"derived_client_cache_misses"`
+	DerivedClientCacheEvictions int64   `json:"derived_client_cache_evictions"`
+	DerivedClientCacheHitRate   float64 `json:"derived_client_cache_hit_rate"`
+
 	// Uptime
 	UptimeSeconds int64 `json:"uptime_seconds"`
 	StartTime     int64 `json:"start_time_unix"`
@@ -75,6 +81,15 @@ type CollectorConfig struct {
 	// Telemetry is the optional telemetry configuration.
 	// If nil, env vars will be used for OTLP configuration.
 	Telemetry *config.TelemetryConfig
+	// DerivedClientCacheStats optionally provides the statistics of the derived clients cache to observe.
+	DerivedClientCacheStats func() CacheStats
+}
+
+// CacheStats are the cumulative statistics of a cache.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
 }
 
 // createMetricsExporter creates an OTLP metrics exporter.
@@ -240,6 +255,12 @@ func NewOtelStatsCollectorWithConfig(cfg CollectorConfig) (*OtelStatsCollector,
 		return nil, fmt.Errorf("failed to create server info gauge: %w", err)
 	}
 
+	if cfg.DerivedClientCacheStats != nil {
+		if err = registerCacheStats(meter, "k8s_mcp.derived_client_cache", "derived clients cache", cfg.DerivedClientCacheStats); err != nil {
+			return nil, err
+		}
+	}
+
 	collector := &OtelStatsCollector{
 		toolCallCounter:       toolCallCounter,
 		toolCallErrorCounter:  toolCallErrorCounter,
@@ -263,6 +284,39 @@ func NewOtelStatsCollectorWithConfig(cfg CollectorConfig) (*OtelStatsCollector,
 	return collector, nil
 }
 
+// registerCacheStats registers the hits, misses and evictions observable counters of a cache.
+func registerCacheStats(meter metric.Meter, prefix, description string, cacheStats func() CacheStats) error {
+	hits, err := meter.Int64ObservableCounter(prefix+".hits",
+		metric.WithDescription("Total number of "+description+" hits"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %s hits counter: %w", description, err)
+	}
+	misses, err := meter.Int64ObservableCounter(prefix+".misses",
+		metric.WithDescription("Total number of "+description+" misses"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %s misses counter: %w", description, err)
+	}
+	evictions, err := meter.Int64ObservableCounter(prefix+".evictions",
+		metric.WithDescription("Total number of "+description+" evictions"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %s evictions counter: %w", description, err)
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := cacheStats()
+		o.ObserveInt64(hits, stats.Hits)
+		o.ObserveInt64(misses, stats.Misses)
+		o.ObserveInt64(evictions, stats.Evictions)
+		return nil
+	}, hits, misses, evictions)
+	if err != nil {
+		return fmt.Errorf("failed to register %s callback: %w", description, err)
+	}
+	return nil
+}
+
 // Shutdown gracefully shuts down the meter provider, flushing any pending metrics.
 func (c *OtelStatsCollector) Shutdown(ctx context.Context) error {
 	return c.provider.Shutdown(ctx)
@@ -348,6 +402,9 @@ func (c *OtelStatsCollector) GetStats() *Statistics {
 			c.processMetric(m, stats)
 		}
 	}
+	if lookups := stats.DerivedClientCacheHits + stats.DerivedClientCacheMisses; lookups > 0 {
+		stats.DerivedClientCacheHitRate = float64(stats.DerivedClientCacheHits) / float64(lookups)
+	}
 
 	return stats
 }
@@ -405,7 +462,27 @@ func (c *OtelStatsCollector) processMetric(m metricdata.Metrics, stats *Statisti
 				}
 			}
 		}
+
+	case "k8s_mcp.derived_client_cache.hits":
+		stats.DerivedClientCacheHits = c.sumValue(m)
+
+	case "k8s_mcp.derived_client_cache.misses":
+		stats.DerivedClientCacheMisses = c.sumValue(m)
+
+	case "k8s_mcp.derived_client_cache.evictions":
+		stats.DerivedClientCacheEvictions = c.sumValue(m)
+	}
+}
+
+// sumValue returns the total value of an int64 sum metric.
+func (c *OtelStatsCollector) sumValue(m metricdata.Metrics) int64 {
+	var total int64
+	if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+		for _, dp := range sum.DataPoints {
+			total += dp.Value
+		}
 	}
+	return total
 }
 
 // getAttributeValue extracts a string value from attributes by key.
@@ -252,6 +252,35 @@ func (s *OtelStatsCollectorSuite) TestPrometheusHandler() {
 	})
 }
 
+func (s *OtelStatsCollectorSuite) TestDerivedClientCacheStats() {
+	collector, err := NewOtelStatsCollectorWithConfig(CollectorConfig{
+		MeterName:      "test-meter-cache",
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+		DerivedClientCacheStats: func() CacheStats {
+			return CacheStats{Hits: 3, Misses: 1, Evictions: 2}
+		},
+	})
+	s.Require().NoError(err)
+	s.Run("reports the derived clients cache statistics", func() {
+		stats := collector.GetStats()
+		s.Equal(int64(3), stats.DerivedClientCacheHits)
+		s.Equal(int64(1), stats.DerivedClientCacheMisses)
+		s.Equal(int64(2), stats.DerivedClientCacheEvictions)
+		s.Equal(0.75, stats.DerivedClientCacheHitRate)
+	})
+	s.Run("serves the derived clients cache metrics in Prometheus format", func() {
+		rec := httptest.NewRecorder()
+		collector.PrometheusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		s.Contains(rec.Body.String(), "k8s_mcp_derived_client_cache_hits_total")
+		s.Contains(rec.Body.String(), "k8s_mcp_derived_client_cache_misses_total")
+		s.Contains(rec.Body.String(), "k8s_mcp_derived_client_cache_evictions_total")
+	})
+	s.Run("reports no hit rate without lookups", func() {
+		s.Zero(s.collector.GetStats().DerivedClientCacheHitRate)
+	})
+}
+
 // TestError is a simple error type for testing
 type TestError struct {
 	msg string
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultCompactPruneFields are the fields removed from the objects in compact mode,
+// tuned to drop the bookkeeping data that is rarely useful to an LLM and expensive in tokens.
+var DefaultCompactPruneFields = []string{
+	"metadata.managedFields",
+	`metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration`,
+	"metadata.resourceVersion",
+	"metadata.uid",
+	"metadata.generation",
+	"metadata.selfLink",
+	"metadata.ownerReferences.*.uid",
+	"spec.containers.*.terminationMessagePath",
+	"spec.containers.*.terminationMessagePolicy",
+	"spec.initContainers.*.terminationMessagePath",
+	"spec.initContainers.*.terminationMessagePolicy",
+	"spec.template.spec.containers.*.terminationMessagePath",
+	"spec.template.spec.containers.*.terminationMessagePolicy",
+	"status.conditions.*.lastProbeTime",
+	"status.conditions.*.lastHeartbeatTime",
+	"status.containerStatuses.*.containerID",
+	"status.containerStatuses.*.imageID",
+	"status.initContainerStatuses.*.containerID",
+	"status.initContainerStatuses.*.imageID",
+	"status.images",
+}
+
+// ParseFieldPath parses a dot-separated field path (e.g. spec.containers.*.image) into its segments.
+// The * segment matches every item of a list or every value of a map, dots in keys are escaped with a backslash
+// (e.g. metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration).
+func ParseFieldPath(path string) ([]string, error) {
+	var segments []string
+	var segment strings.Builder
+	escaped := false
+	for _, r := range path {
+		switch {
+		case escaped:
+			segment.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteRune(r)
+		}
+	}
+	segments = append(segments, segment.String())
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("invalid field path %q: empty segment", path)
+		}
+	}
+	return segments, nil
+}
+
+// Compact removes the fields matching the prune paths from the object (or the items of a list) in place.
+// Maps left empty after removing a field are removed too. Invalid paths are ignored.
+func Compact(obj runtime.Unstructured, pruneFields []string) {
+	var paths [][]string
+	for _, field := range pruneFields {
+		if segments, err := ParseFieldPath(field); err == nil {
+			paths = append(paths, segments)
+		}
+	}
+	compactObject := func(content map[string]interface{}) {
+		for _, path := range paths {
+			prune(content, path)
+		}
+	}
+	switch t := obj.(type) {
+	case *unstructured.UnstructuredList:
+		for i := range t.Items {
+			compactObject(t.Items[i].Object)
+		}
+	case *unstructured.Unstructured:
+		compactObject(t.Object)
+	}
+}
+
+// prune removes the field at path from value, returns true if value is an empty map after the removal
+func prune(value interface{}, path []string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if path[0] == "*" {
+			for _, child := range v {
+				if len(path) > 1 {
+					prune(child, path[1:])
+				}
+			}
+			return false
+		}
+		child, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) == 1 || prune(child, path[1:]) {
+			delete(v, path[0])
+			return len(v) == 0
+		}
+	case []interface{}:
+		if path[0] == "*" && len(path) > 1 {
+			for _, item := range v {
+				prune(item, path[1:])
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	segments, err := ParseFieldPath(`metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration`)
+	if err != nil {
+		t.Fatalf("Error parsing field path: %v", err)
+	}
+	expected := []string{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"}
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("Expected segments %v, got %v", expected, segments)
+	}
+	for _, invalid := range []string{"", "metadata.", ".metadata", "metadata..uid"} {
+		if _, err := ParseFieldPath(invalid); err == nil {
+			t.Errorf("Expected error for invalid field path %q", invalid)
+		}
+	}
+}
+
+func TestCompactUnstructuredList(t *testing.T) {
+	var podList unstructured.UnstructuredList
+	_ = json.Unmarshal([]byte(`
+			{ "apiVersion": "v1", "kind": "PodList", "items": [{
+			  "apiVersion": "v1", "kind": "Pod",
+			  "metadata": {
+			    "name": "pod-1", "namespace": "default", "uid": "1234", "resourceVersion": "42",
+			    "annotations": { "kubectl.kubernetes.io/last-applied-configuration": "{}" },
+			    "managedFields": [{ "manager": "kubectl" }]
+			  },
+			  "spec": { "containers": [{ "name": "container-1", "image": "nginx", "terminationMessagePath": "/dev/termination-log" }] },
+			  "status": { "phase": "Running", "containerStatuses": [{ "name": "container-1", "imageID": "sha256:abc", "ready": true }] } }
+			]}`), &podList)
+	Compact(&podList, DefaultCompactPruneFields)
+	pod := podList.Items[0]
+	t.Run("removes metadata noise", func(t *testing.T) {
+		if pod.GetUID() != "" || pod.GetResourceVersion() != "" || pod.GetManagedFields() != nil {
+			t.Errorf("Expected uid, resourceVersion and managedFields to be removed, got %v", pod.Object["metadata"])
+		}
+	})
+	t.Run("removes maps left empty", func(t *testing.T) {
+		if _, ok := pod.Object["metadata"].(map[string]interface{})["annotations"]; ok {
+			t.Errorf("Expected empty annotations to be removed, got %v", pod.Object["metadata"])
+		}
+	})
+	t.Run("removes fields of every list item", func(t *testing.T) {
+		container := pod.Object["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+		status := pod.Object["status"].(map[string]interface{})["containerStatuses"].([]interface{})[0].(map[string]interface{})
+		if _, ok := container["terminationMessagePath"]; ok {
+			t.Errorf("Expected terminationMessagePath to be removed, got %v", container)
+		}
+		if _, ok := status["imageID"]; ok {
+			t.Errorf("Expected imageID to be removed, got %v", status)
+		}
+	})
+	t.Run("keeps the relevant fields", func(t *testing.T) {
+		if pod.GetName() != "pod-1" || pod.GetNamespace() != "default" {
+			t.Errorf("Expected name and namespace to be kept, got %v", pod.Object["metadata"])
+		}
+		if phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase"); phase != "Running" {
+			t.Errorf("Expected status.phase to be kept, got %v", pod.Object["status"])
+		}
+	})
+}
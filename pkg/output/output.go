@@ -2,11 +2,16 @@ package output
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/get"
 	yml "sigs.k8s.io/yaml"
 )
 
@@ -14,6 +19,16 @@ var Yaml = &yaml{}
 
 var Table = &table{}
 
+var Wide = &wide{}
+
+var Json = &jsonOutput{}
+
+var Csv = &csvOutput{}
+
+var MarkdownTable = &markdownTable{}
+
+var Name = &name{}
+
 type Output interface {
 	// GetName returns the name of the output format, will be used by the CLI to identify the output format.
 	GetName() string
@@ -26,6 +41,11 @@ type Output interface {
 var Outputs = []Output{
 	Yaml,
 	Table,
+	Wide,
+	Json,
+	Csv,
+	MarkdownTable,
+	Name,
 }
 
 var Names []string
@@ -39,6 +59,22 @@ func FromString(name string) Output {
 	return nil
 }
 
+// FromSpec returns the Output for a kubectl-like output specification,
+// either the name of an output or a template (jsonpath=<template> or custom-columns=<spec>).
+func FromSpec(spec string) (Output, error) {
+	if output := FromString(spec); output != nil {
+		return output, nil
+	}
+	format, template, _ := strings.Cut(spec, "=")
+	switch format {
+	case "jsonpath":
+		return NewJsonPath(template)
+	case "custom-columns":
+		return NewCustomColumns(template)
+	}
+	return nil, fmt.Errorf("invalid output: %s, valid outputs are: %s, jsonpath=<template>, custom-columns=<spec>", spec, strings.Join(Names, ", "))
+}
+
 type yaml struct{}
 
 func (p *yaml) GetName() string {
@@ -61,27 +97,9 @@ func (p *table) AsTable() bool {
 }
 func (p *table) PrintObj(obj runtime.Unstructured) (string, error) {
 	var objectToPrint runtime.Object = obj
-	withNamespace := false
-	if obj.GetObjectKind().GroupVersionKind() == metav1.SchemeGroupVersion.WithKind("Table") {
-		t := &metav1.Table{}
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), t); err == nil {
-			objectToPrint = t
-			// Process the Raw object to retrieve the complete metadata (see kubectl/pkg/printers/table_printer.go)
-			for i := range t.Rows {
-				row := &t.Rows[i]
-				if row.Object.Raw == nil || row.Object.Object != nil {
-					continue
-				}
-				row.Object.Object, err = runtime.Decode(unstructured.UnstructuredJSONScheme, row.Object.Raw)
-				// Print namespace if at least one row has it (object is namespaced)
-				if err == nil && !withNamespace {
-					switch rowObject := row.Object.Object.(type) {
-					case *unstructured.Unstructured:
-						withNamespace = rowObject.GetNamespace() != ""
-					}
-				}
-			}
-		}
+	t, withNamespace := toTable(obj)
+	if t != nil {
+		objectToPrint = t
 	}
 	buf := new(bytes.Buffer)
 	// TablePrinter is mutable and not thread-safe, must create a new instance each time.
@@ -95,6 +113,238 @@ func (p *table) PrintObj(obj runtime.Unstructured) (string, error) {
 	return buf.String(), err
 }
 
+// wide is the table variant requesting the wide columns, as kubectl -o wide (the table output prints them too)
+type wide struct{ table }
+
+func (p *wide) GetName() string {
+	return "wide"
+}
+
+type jsonOutput struct{}
+
+func (p *jsonOutput) GetName() string {
+	return "json"
+}
+func (p *jsonOutput) AsTable() bool {
+	return false
+}
+func (p *jsonOutput) PrintObj(obj runtime.Unstructured) (string, error) {
+	var v any = obj
+	switch t := obj.(type) {
+	case *unstructured.UnstructuredList:
+		for i := range t.Items {
+			t.Items[i].SetManagedFields(nil)
+		}
+		v = t.Items
+	case *unstructured.Unstructured:
+		t.SetManagedFields(nil)
+	}
+	ret, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+type csvOutput struct{}
+
+func (p *csvOutput) GetName() string {
+	return "csv"
+}
+func (p *csvOutput) AsTable() bool {
+	return true
+}
+func (p *csvOutput) PrintObj(obj runtime.Unstructured) (string, error) {
+	headers, rows := tabular(obj)
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	_ = w.Write(headers)
+	_ = w.WriteAll(rows)
+	return buf.String(), w.Error()
+}
+
+type markdownTable struct{}
+
+func (p *markdownTable) GetName() string {
+	return "markdown-table"
+}
+func (p *markdownTable) AsTable() bool {
+	return true
+}
+func (p *markdownTable) PrintObj(obj runtime.Unstructured) (string, error) {
+	headers, rows := tabular(obj)
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+	buf := new(bytes.Buffer)
+	writeRow := func(cells []string) {
+		buf.WriteString("|")
+		for _, cell := range cells {
+			buf.WriteString(" " + escape.Replace(cell) + " |")
+		}
+		buf.WriteString("\n")
+	}
+	writeRow(headers)
+	buf.WriteString("|" + strings.Repeat("---|", len(headers)) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return buf.String(), nil
+}
+
+type name struct{}
+
+func (p *name) GetName() string {
+	return "name"
+}
+func (p *name) AsTable() bool {
+	return false
+}
+func (p *name) PrintObj(obj runtime.Unstructured) (string, error) {
+	buf := new(bytes.Buffer)
+	err := (&printers.NamePrinter{}).PrintObj(obj, buf)
+	return buf.String(), err
+}
+
+type jsonPath struct {
+	template string
+}
+
+// NewJsonPath returns an Output printing the fields matching the jsonpath template (e.g. {.items[*].metadata.name})
+func NewJsonPath(template string) (Output, error) {
+	if _, err := printers.NewJSONPathPrinter(template); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath template %s: %w", template, err)
+	}
+	return &jsonPath{template: template}, nil
+}
+
+func (p *jsonPath) GetName() string {
+	return "jsonpath"
+}
+func (p *jsonPath) AsTable() bool {
+	return false
+}
+func (p *jsonPath) PrintObj(obj runtime.Unstructured) (string, error) {
+	// JSONPathPrinter is mutable and not thread-safe, must create a new instance each time.
+	printer, err := printers.NewJSONPathPrinter(p.template)
+	if err != nil {
+		return "", err
+	}
+	printer.AllowMissingKeys(true)
+	buf := new(bytes.Buffer)
+	err = printer.PrintObj(obj, buf)
+	return buf.String(), err
+}
+
+type customColumns struct {
+	spec string
+}
+
+// NewCustomColumns returns an Output printing a table with the columns of the spec (e.g. NAME:.metadata.name,NODE:.spec.nodeName)
+func NewCustomColumns(spec string) (Output, error) {
+	if _, err := get.NewCustomColumnsPrinterFromSpec(spec, unstructured.UnstructuredJSONScheme, false); err != nil {
+		return nil, fmt.Errorf("invalid custom-columns spec %s: %w", spec, err)
+	}
+	return &customColumns{spec: spec}, nil
+}
+
+func (p *customColumns) GetName() string {
+	return "custom-columns"
+}
+func (p *customColumns) AsTable() bool {
+	return false
+}
+func (p *customColumns) PrintObj(obj runtime.Unstructured) (string, error) {
+	printer, err := get.NewCustomColumnsPrinterFromSpec(p.spec, unstructured.UnstructuredJSONScheme, false)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	err = printer.PrintObj(obj, buf)
+	return buf.String(), err
+}
+
+// toTable converts a server-side Table to a metav1.Table (nil if obj is not a Table),
+// and returns whether the rows are namespaced.
+func toTable(obj runtime.Unstructured) (*metav1.Table, bool) {
+	if obj.GetObjectKind().GroupVersionKind() != metav1.SchemeGroupVersion.WithKind("Table") {
+		return nil, false
+	}
+	t := &metav1.Table{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), t); err != nil {
+		return nil, false
+	}
+	withNamespace := false
+	// Process the Raw object to retrieve the complete metadata (see kubectl/pkg/printers/table_printer.go)
+	for i := range t.Rows {
+		row := &t.Rows[i]
+		if row.Object.Raw == nil || row.Object.Object != nil {
+			continue
+		}
+		var err error
+		row.Object.Object, err = runtime.Decode(unstructured.UnstructuredJSONScheme, row.Object.Raw)
+		// Print namespace if at least one row has it (object is namespaced)
+		if err == nil && !withNamespace {
+			switch rowObject := row.Object.Object.(type) {
+			case *unstructured.Unstructured:
+				withNamespace = rowObject.GetNamespace() != ""
+			}
+		}
+	}
+	return t, withNamespace
+}
+
+// tabular returns the headers and rows of the default (priority 0) columns of a server-side Table,
+// or the namespace, kind, and name of the objects if obj is not a Table
+func tabular(obj runtime.Unstructured) ([]string, [][]string) {
+	t, withNamespace := toTable(obj)
+	if t == nil {
+		headers := []string{"NAMESPACE", "KIND", "NAME"}
+		var rows [][]string
+		appendRow := func(u *unstructured.Unstructured) {
+			rows = append(rows, []string{u.GetNamespace(), u.GetKind(), u.GetName()})
+		}
+		switch o := obj.(type) {
+		case *unstructured.UnstructuredList:
+			for i := range o.Items {
+				appendRow(&o.Items[i])
+			}
+		case *unstructured.Unstructured:
+			appendRow(o)
+		}
+		return headers, rows
+	}
+	var headers []string
+	if withNamespace {
+		headers = append(headers, "NAMESPACE")
+	}
+	var columns []int
+	for i, column := range t.ColumnDefinitions {
+		if column.Priority == 0 {
+			columns = append(columns, i)
+			headers = append(headers, strings.ToUpper(column.Name))
+		}
+	}
+	rows := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		var cells []string
+		if withNamespace {
+			namespace := ""
+			if u, ok := row.Object.Object.(*unstructured.Unstructured); ok {
+				namespace = u.GetNamespace()
+			}
+			cells = append(cells, namespace)
+		}
+		for _, i := range columns {
+			cell := ""
+			if i < len(row.Cells) && row.Cells[i] != nil {
+				cell = fmt.Sprint(row.Cells[i])
+			}
+			cells = append(cells, cell)
+		}
+		rows = append(rows, cells)
+	}
+	return headers, rows
+}
+
 func MarshalYaml(v any) (string, error) {
 	switch t := v.(type) {
 	//case unstructured.UnstructuredList:
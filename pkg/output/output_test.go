@@ -31,3 +31,53 @@ func TestPlainTextUnstructuredList(t *testing.T) {
 		}
 	})
 }
+
+func TestFromSpec(t *testing.T) {
+	for _, spec := range []string{"yaml", "table", "wide", "json", "csv", "markdown-table", "name"} {
+		t.Run(spec, func(t *testing.T) {
+			out, err := FromSpec(spec)
+			if err != nil || out.GetName() != spec {
+				t.Errorf("Expected output %s, got %v (%v)", spec, out, err)
+			}
+		})
+	}
+	t.Run("jsonpath=<template>", func(t *testing.T) {
+		out, err := FromSpec("jsonpath={.metadata.name}")
+		if err != nil || out.GetName() != "jsonpath" {
+			t.Errorf("Expected jsonpath output, got %v (%v)", out, err)
+		}
+	})
+	t.Run("custom-columns=<spec>", func(t *testing.T) {
+		out, err := FromSpec("custom-columns=NAME:.metadata.name")
+		if err != nil || out.GetName() != "custom-columns" {
+			t.Errorf("Expected custom-columns output, got %v (%v)", out, err)
+		}
+	})
+	t.Run("invalid custom-columns spec", func(t *testing.T) {
+		if _, err := FromSpec("custom-columns=NAME"); err == nil {
+			t.Errorf("Expected error for invalid custom-columns spec")
+		}
+	})
+	t.Run("unknown output", func(t *testing.T) {
+		if _, err := FromSpec("xml"); err == nil {
+			t.Errorf("Expected error for unknown output")
+		}
+	})
+}
+
+func TestCsvUnstructuredList(t *testing.T) {
+	var podList unstructured.UnstructuredList
+	_ = json.Unmarshal([]byte(`
+			{ "apiVersion": "v1", "kind": "PodList", "items": [
+			  { "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "pod-1", "namespace": "default" } },
+			  { "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "pod,2", "namespace": "default" } }
+			]}`), &podList)
+	out, err := Csv.PrintObj(&podList)
+	if err != nil {
+		t.Fatalf("Error printing pod list: %v", err)
+	}
+	expected := "NAMESPACE,KIND,NAME\ndefault,Pod,pod-1\ndefault,Pod,\"pod,2\"\n"
+	if out != expected {
+		t.Errorf("Expected csv output %q, got %q", expected, out)
+	}
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TargetAnnotation is set on the objects merged from several targets to identify the target (cluster/context) they belong to
+const TargetAnnotation = "kubernetes-mcp-server/target"
+
+// MergeTargets merges the lists (or server-side Tables) retrieved from several targets into a single one.
+// The rows of the merged Table start with a Target column, the items of the merged list have the TargetAnnotation.
+func MergeTargets(targets []string, objs []runtime.Unstructured) (runtime.Unstructured, error) {
+	if len(targets) != len(objs) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(targets), len(objs))
+	}
+	if len(objs) == 0 {
+		return &unstructured.UnstructuredList{}, nil
+	}
+	if objs[0].GetObjectKind().GroupVersionKind() == metav1.SchemeGroupVersion.WithKind("Table") {
+		return mergeTables(targets, objs)
+	}
+	merged := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	for i, obj := range objs {
+		if i == 0 {
+			merged.SetAPIVersion(obj.GetObjectKind().GroupVersionKind().GroupVersion().String())
+			merged.SetKind(obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		var items []unstructured.Unstructured
+		switch t := obj.(type) {
+		case *unstructured.UnstructuredList:
+			items = t.Items
+		case *unstructured.Unstructured:
+			items = []unstructured.Unstructured{*t}
+		default:
+			return nil, fmt.Errorf("unsupported result type %T for target %s", obj, targets[i])
+		}
+		for _, item := range items {
+			annotations := item.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[TargetAnnotation] = targets[i]
+			item.SetAnnotations(annotations)
+			merged.Items = append(merged.Items, item)
+		}
+	}
+	return merged, nil
+}
+
+// mergeTables merges the Tables of the targets, the targets may return different columns (e.g. different server
+// versions or printers) so the merged Table has the columns of all of them and the cells are matched by column name
+func mergeTables(targets []string, objs []runtime.Unstructured) (runtime.Unstructured, error) {
+	merged := &metav1.Table{ColumnDefinitions: []metav1.TableColumnDefinition{
+		{Name: "Target", Type: "string", Description: "The target (cluster/context) of the resource"},
+	}}
+	tables := make([]*metav1.Table, len(objs))
+	columns := map[string]int{}
+	for i, obj := range objs {
+		tables[i] = &metav1.Table{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), tables[i]); err != nil {
+			return nil, fmt.Errorf("invalid table for target %s: %w", targets[i], err)
+		}
+		if i == 0 {
+			merged.TypeMeta = tables[i].TypeMeta
+		}
+		for _, column := range tables[i].ColumnDefinitions {
+			if _, ok := columns[column.Name]; !ok {
+				columns[column.Name] = len(merged.ColumnDefinitions)
+				merged.ColumnDefinitions = append(merged.ColumnDefinitions, column)
+			}
+		}
+	}
+	for i, t := range tables {
+		for _, row := range t.Rows {
+			cells := make([]interface{}, len(merged.ColumnDefinitions))
+			cells[0] = targets[i]
+			for c, cell := range row.Cells {
+				if c < len(t.ColumnDefinitions) {
+					cells[columns[t.ColumnDefinitions[c].Name]] = cell
+				}
+			}
+			row.Cells = cells
+			merged.Rows = append(merged.Rows, row)
+		}
+	}
+	merged.SetGroupVersionKind(metav1.SchemeGroupVersion.WithKind("Table"))
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(merged)
+	return &unstructured.Unstructured{Object: content}, err
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestMergeTargetsTables(t *testing.T) {
+	table := func(name string) runtime.Unstructured {
+		u := &unstructured.Unstructured{}
+		_ = json.Unmarshal([]byte(`{
+			"apiVersion": "meta.k8s.io/v1", "kind": "Table",
+			"columnDefinitions": [{ "name": "Name", "type": "string" }],
+			"rows": [{ "cells": ["`+name+`"] }]
+		}`), &u.Object)
+		return u
+	}
+	merged, err := MergeTargets([]string{"prod", "staging"}, []runtime.Unstructured{table("a"), table("b")})
+	if err != nil {
+		t.Fatalf("Error merging tables: %v", err)
+	}
+	out, err := Csv.PrintObj(merged)
+	if err != nil {
+		t.Fatalf("Error printing merged table: %v", err)
+	}
+	expected := "TARGET,NAME\nprod,a\nstaging,b\n"
+	if out != expected {
+		t.Errorf("Expected merged table %q, got %q", expected, out)
+	}
+}
+
+func TestMergeTargetsTablesWithDifferentColumns(t *testing.T) {
+	table := func(columns, cells string) runtime.Unstructured {
+		u := &unstructured.Unstructured{}
+		_ = json.Unmarshal([]byte(`{
+			"apiVersion": "meta.k8s.io/v1", "kind": "Table",
+			"columnDefinitions": [`+columns+`],
+			"rows": [{ "cells": [`+cells+`] }]
+		}`), &u.Object)
+		return u
+	}
+	merged, err := MergeTargets([]string{"prod", "staging"}, []runtime.Unstructured{
+		table(`{ "name": "Name", "type": "string" }, { "name": "Status", "type": "string" }`, `"a", "Running"`),
+		table(`{ "name": "Name", "type": "string" }, { "name": "Age", "type": "string" }, { "name": "Status", "type": "string" }`, `"b", "5m", "Pending"`),
+	})
+	if err != nil {
+		t.Fatalf("Error merging tables: %v", err)
+	}
+	out, err := Csv.PrintObj(merged)
+	if err != nil {
+		t.Fatalf("Error printing merged table: %v", err)
+	}
+	expected := "TARGET,NAME,STATUS,AGE\nprod,a,Running,\nstaging,b,Pending,5m\n"
+	if out != expected {
+		t.Errorf("Expected merged table %q, got %q", expected, out)
+	}
+}
+
+func TestMergeTargetsLists(t *testing.T) {
+	list := func(name string) runtime.Unstructured {
+		l := &unstructured.UnstructuredList{}
+		_ = json.Unmarshal([]byte(`{ "apiVersion": "v1", "kind": "PodList", "items": [
+			{ "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "`+name+`" } }
+		]}`), l)
+		return l
+	}
+	merged, err := MergeTargets([]string{"prod", "staging"}, []runtime.Unstructured{list("a"), list("b")})
+	if err != nil {
+		t.Fatalf("Error merging lists: %v", err)
+	}
+	items := merged.(*unstructured.UnstructuredList).Items
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+	for i, target := range []string{"prod", "staging"} {
+		if items[i].GetAnnotations()[TargetAnnotation] != target {
+			t.Errorf("Expected item %d to have target annotation %s, got %v", i, target, items[i].GetAnnotations())
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// Config holds Prometheus toolset configuration
+type Config struct {
+	// Url of the Prometheus (or Thanos Querier) HTTP API
+	Url                  string `toml:"url"`
+	Insecure             bool   `toml:"insecure,omitempty"`
+	CertificateAuthority string `toml:"certificate_authority,omitempty"`
+	// BearerTokenFile overrides the Kubernetes credentials used to authenticate against Prometheus
+	BearerTokenFile string `toml:"bearer_token_file,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return errors.New("prometheus config is nil")
+	}
+	if c.Url == "" {
+		return errors.New("url is required")
+	}
+	if u, err := url.Parse(c.Url); err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("url must be a valid URL")
+	}
+	if caValue := strings.TrimSpace(c.CertificateAuthority); caValue != "" {
+		if _, err := os.Stat(caValue); err != nil {
+			return fmt.Errorf("certificate_authority must be a valid file path: %w", err)
+		}
+	}
+	if tokenFile := strings.TrimSpace(c.BearerTokenFile); tokenFile != "" {
+		if _, err := os.Stat(tokenFile); err != nil {
+			return fmt.Errorf("bearer_token_file must be a valid file path: %w", err)
+		}
+	}
+	return nil
+}
+
+func prometheusToolsetParser(ctx context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+
+	// Resolve file paths relative to the config directory
+	configDir := config.ConfigDirPathFromContext(ctx)
+	if configDir != "" && cfg.CertificateAuthority != "" && !filepath.IsAbs(cfg.CertificateAuthority) {
+		cfg.CertificateAuthority = filepath.Join(configDir, cfg.CertificateAuthority)
+	}
+	if configDir != "" && cfg.BearerTokenFile != "" && !filepath.IsAbs(cfg.BearerTokenFile) {
+		cfg.BearerTokenFile = filepath.Join(configDir, cfg.BearerTokenFile)
+	}
+
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("prometheus", prometheusToolsetParser)
+}
@@ -0,0 +1,186 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"weak"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// maxResponseBytes bounds the size of the Prometheus responses to protect the server and the LLM context
+const maxResponseBytes = 10 * 1024 * 1024
+
+type Prometheus struct {
+	config        *Config
+	prometheusURL string
+	httpClient    *http.Client
+}
+
+// clients caches the Prometheus client of each target, keyed by the REST config of its Kubernetes client which lives as
+// long as the target does, the entries are removed once the REST config is garbage collected (e.g. evicted derived clients)
+var (
+	clientsMu sync.Mutex
+	clients   = map[weak.Pointer[rest.Config]]*Prometheus{}
+)
+
+// QueryResult is the data of a successful Prometheus query response
+type QueryResult struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+	Warnings   []string        `json:"warnings,omitempty"`
+}
+
+// response is the envelope of the Prometheus HTTP API responses
+type response struct {
+	Status    string       `json:"status"`
+	Data      *QueryResult `json:"data,omitempty"`
+	ErrorType string       `json:"errorType,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Warnings  []string     `json:"warnings,omitempty"`
+}
+
+// NewPrometheus returns the Prometheus client of the target with the provided Kubernetes REST config, the client is
+// created once per target (and toolset configuration) and reused by the following calls.
+// Requests are authenticated with the credentials of the Kubernetes REST config (bearer token, token file, exec or auth
+// provider plugins), unless a bearer_token_file is configured for the toolset.
+func NewPrometheus(configProvider api.ExtendedConfigProvider, kubernetes *rest.Config) *Prometheus {
+	var pc *Config
+	if cfg, ok := configProvider.GetToolsetConfig("prometheus"); ok {
+		pc, _ = cfg.(*Config)
+	}
+	key := weak.Make(kubernetes)
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if prometheus, ok := clients[key]; ok && prometheus.config == pc {
+		return prometheus
+	}
+	prometheus := newPrometheus(pc, kubernetes)
+	if _, ok := clients[key]; !ok {
+		runtime.AddCleanup(kubernetes, func(key weak.Pointer[rest.Config]) {
+			clientsMu.Lock()
+			defer clientsMu.Unlock()
+			delete(clients, key)
+		}, key)
+	}
+	clients[key] = prometheus
+	return prometheus
+}
+
+func newPrometheus(pc *Config, kubernetes *rest.Config) *Prometheus {
+	prometheus := &Prometheus{config: pc}
+	if pc == nil {
+		return prometheus
+	}
+	prometheus.prometheusURL = pc.Url
+	// The Kubernetes credentials are reused, but not the TLS configuration and transport wrappers of the API server
+	restConfig := rest.CopyConfig(kubernetes)
+	restConfig.WrapTransport = nil
+	restConfig.TLSClientConfig = rest.TLSClientConfig{
+		Insecure: pc.Insecure,
+		CAFile:   strings.TrimSpace(pc.CertificateAuthority),
+		CertFile: kubernetes.CertFile,
+		KeyFile:  kubernetes.KeyFile,
+		CertData: kubernetes.CertData,
+		KeyData:  kubernetes.KeyData,
+	}
+	if pc.BearerTokenFile != "" {
+		restConfig.BearerToken = ""
+		restConfig.BearerTokenFile = pc.BearerTokenFile
+		restConfig.Username, restConfig.Password = "", ""
+		restConfig.ExecProvider = nil
+		restConfig.AuthProvider = nil
+	}
+	transport, err := rest.TransportFor(restConfig)
+	if err != nil {
+		klog.Errorf("failed to create the prometheus transport: %v", err)
+		return prometheus
+	}
+	prometheus.httpClient = &http.Client{Transport: transport}
+	return prometheus
+}
+
+// Query evaluates an instant query at the provided time (RFC3339 or Unix timestamp, empty for the current time)
+func (p *Prometheus) Query(ctx context.Context, query, time string) (*QueryResult, error) {
+	params := url.Values{"query": []string{query}}
+	if time != "" {
+		params.Set("time", time)
+	}
+	return p.executeQuery(ctx, "/api/v1/query", params)
+}
+
+// QueryRange evaluates a query over a range of time (RFC3339 or Unix timestamps) with the provided resolution step
+func (p *Prometheus) QueryRange(ctx context.Context, query, start, end, step string) (*QueryResult, error) {
+	params := url.Values{
+		"query": []string{query},
+		"start": []string{start},
+		"end":   []string{end},
+		"step":  []string{step},
+	}
+	return p.executeQuery(ctx, "/api/v1/query_range", params)
+}
+
+func (p *Prometheus) validateAndGetURL(endpoint string, params url.Values) (string, error) {
+	if p == nil || strings.TrimSpace(p.prometheusURL) == "" {
+		return "", errors.New("prometheus url not configured, set [toolset_configs.prometheus].url")
+	}
+	if p.httpClient == nil {
+		return "", errors.New("prometheus client not available, check the TLS and credentials configuration")
+	}
+	joined, err := url.JoinPath(strings.TrimSpace(p.prometheusURL), endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid prometheus base URL: %w", err)
+	}
+	u, err := url.Parse(joined)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse joined URL: %w", err)
+	}
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+func (p *Prometheus) executeQuery(ctx context.Context, endpoint string, params url.Values) (*QueryResult, error) {
+	apiCallURL, err := p.validateAndGetURL(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(5).Infof("prometheus API call: GET %s", apiCallURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiCallURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		return nil, fmt.Errorf("prometheus response exceeds %d bytes, narrow the query or increase the step", maxResponseBytes)
+	}
+	ret := &response{}
+	if err = json.Unmarshal(body, ret); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("prometheus API error: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		return nil, fmt.Errorf("failed to parse prometheus response: %w", err)
+	}
+	if ret.Status != "success" || ret.Data == nil {
+		return nil, fmt.Errorf("prometheus API error: %s: %s", ret.ErrorType, ret.Error)
+	}
+	ret.Data.Warnings = ret.Warnings
+	return ret.Data, nil
+}
@@ -0,0 +1,194 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type PrometheusSuite struct {
+	suite.Suite
+	MockServer *test.MockServer
+	Config     *config.StaticConfig
+}
+
+func (s *PrometheusSuite) SetupTest() {
+	s.MockServer = test.NewMockServer()
+	s.MockServer.Config().BearerToken = ""
+	s.Config = test.Must(config.ReadToml([]byte(`
+		[toolset_configs.prometheus]
+		url = "` + s.MockServer.Config().Host + `/prometheus"
+	`)))
+}
+
+func (s *PrometheusSuite) TearDownTest() {
+	s.MockServer.Close()
+}
+
+func (s *PrometheusSuite) TestConfig() {
+	s.Run("url is required", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			insecure = true
+		`))
+		s.ErrorContains(err, "url is required")
+	})
+	s.Run("url must be valid", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "://invalid-url"
+		`))
+		s.ErrorContains(err, "url must be a valid URL")
+	})
+	s.Run("https without certificate_authority is valid", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "https://thanos-querier.openshift-monitoring.svc:9091"
+		`))
+		s.NoError(err)
+	})
+	s.Run("bearer_token_file must exist", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "https://prometheus.example"
+			bearer_token_file = "/does/not/exist"
+		`))
+		s.ErrorContains(err, "bearer_token_file must be a valid file path")
+	})
+	s.Run("resolves relative paths", func() {
+		tempDir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(tempDir, "token"), []byte("file-token"), 0600))
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "https://prometheus.example"
+			bearer_token_file = "token"
+		`), config.WithDirPath(tempDir)))
+		prometheusCfg, ok := cfg.GetToolsetConfig("prometheus")
+		s.Require().True(ok, "Prometheus config should be present")
+		s.Equal(filepath.Join(tempDir, "token"), prometheusCfg.(*Config).BearerTokenFile)
+	})
+}
+
+// authorization returns the Authorization header of a query performed with the Prometheus client of the REST config
+func (s *PrometheusSuite) authorization(cfg *config.StaticConfig, restConfig *rest.Config) string {
+	var authorization string
+	s.MockServer.ResetHandlers()
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	_, err := NewPrometheus(cfg, restConfig).Query(context.Background(), "up", "")
+	s.Require().NoError(err)
+	return authorization
+}
+
+func (s *PrometheusSuite) TestNewPrometheus() {
+	s.Run("uses the bearer token of the REST config", func() {
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		restConfig.BearerToken = "rest-token"
+		s.Equal("Bearer rest-token", s.authorization(s.Config, restConfig))
+	})
+	s.Run("uses the bearer token file of the REST config (service account)", func() {
+		tokenFile := filepath.Join(s.T().TempDir(), "token")
+		s.Require().NoError(os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		restConfig.BearerTokenFile = tokenFile
+		s.Equal("Bearer sa-token", s.authorization(s.Config, restConfig))
+	})
+	s.Run("uses the exec credential plugin of the REST config", func() {
+		if goruntime.GOOS == "windows" {
+			s.T().Skip("exec credential plugin test requires a POSIX shell")
+		}
+		plugin := filepath.Join(s.T().TempDir(), "credentials")
+		s.Require().NoError(os.WriteFile(plugin, []byte(`#!/bin/sh
+echo '{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","status":{"token":"exec-token"}}'
+`), 0700))
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		restConfig.ExecProvider = &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1", Command: plugin, InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		}
+		s.Equal("Bearer exec-token", s.authorization(s.Config, restConfig))
+	})
+	s.Run("bearer_token_file overrides the REST config", func() {
+		tokenFile := filepath.Join(s.T().TempDir(), "token")
+		s.Require().NoError(os.WriteFile(tokenFile, []byte("config-token"), 0600))
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "` + s.MockServer.Config().Host + `/prometheus"
+			bearer_token_file = "` + filepath.ToSlash(tokenFile) + `"
+		`)))
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		restConfig.BearerToken = "rest-token"
+		s.Equal("Bearer config-token", s.authorization(cfg, restConfig))
+	})
+	s.Run("reuses the client of the same target", func() {
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		p := NewPrometheus(s.Config, restConfig)
+		s.Same(p, NewPrometheus(s.Config, restConfig))
+		s.NotSame(p, NewPrometheus(s.Config, rest.CopyConfig(restConfig)))
+	})
+	s.Run("recreates the client if the toolset configuration changes", func() {
+		restConfig := rest.CopyConfig(s.MockServer.Config())
+		p := NewPrometheus(s.Config, restConfig)
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.prometheus]
+			url = "https://prometheus.example"
+		`)))
+		updated := NewPrometheus(cfg, restConfig)
+		s.NotSame(p, updated)
+		s.Equal("https://prometheus.example", updated.prometheusURL)
+	})
+}
+
+func (s *PrometheusSuite) TestQuery() {
+	var authorization string
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		switch r.URL.Query().Get("query") {
+		case "up":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"kubelet"},"value":[1700000000,"1"]}]},"warnings":["partial response"]}`))
+		case "invalid(":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`upstream unavailable`))
+		}
+	}))
+	restConfig := rest.CopyConfig(s.MockServer.Config())
+	restConfig.BearerToken = "rest-token"
+	p := NewPrometheus(s.Config, restConfig)
+	s.Run("returns result", func() {
+		result, err := p.Query(context.Background(), "up", "")
+		s.Require().NoError(err)
+		s.Equal("vector", result.ResultType)
+		s.JSONEq(`[{"metric":{"job":"kubelet"},"value":[1700000000,"1"]}]`, string(result.Result))
+		s.Equal([]string{"partial response"}, result.Warnings)
+		s.Equal("Bearer rest-token", authorization)
+	})
+	s.Run("returns prometheus error", func() {
+		_, err := p.Query(context.Background(), "invalid(", "")
+		s.EqualError(err, "prometheus API error: bad_data: parse error")
+	})
+	s.Run("returns http error", func() {
+		_, err := p.Query(context.Background(), "other", "")
+		s.EqualError(err, "prometheus API error: status 502: upstream unavailable")
+	})
+	s.Run("not configured", func() {
+		_, err := NewPrometheus(config.Default(), s.MockServer.Config()).Query(context.Background(), "up", "")
+		s.ErrorContains(err, "prometheus url not configured")
+	})
+}
+
+func TestPrometheus(t *testing.T) {
+	suite.Run(t, new(PrometheusSuite))
+}
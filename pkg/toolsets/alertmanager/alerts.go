@@ -0,0 +1,95 @@
+package alertmanager
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/alertmanager"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+var matchersSchema = &jsonschema.Schema{
+	Type: "array",
+	Description: "Optional label matchers in the Alertmanager matcher syntax to filter the results " +
+		`(e.g. ["alertname=\"KubePodCrashLooping\"", "namespace=~\"prod-.*\"", "severity!=\"info\""])`,
+	Items: &jsonschema.Schema{Type: "string"},
+}
+
+func initAlerts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "alertmanager_alerts_list",
+			Description: "List the alerts in Alertmanager. By default only the active (firing) alerts that are not silenced or inhibited are returned, " +
+				"including their labels, annotations, start time, and receivers",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": matchersSchema,
+					"receiver": {
+						Type:        "string",
+						Description: "Optional regular expression matching the receivers of the alerts",
+					},
+					"silenced": {
+						Type:        "boolean",
+						Description: "Include silenced alerts",
+						Default:     api.ToRawMessage(false),
+					},
+					"inhibited": {
+						Type:        "boolean",
+						Description: "Include inhibited alerts",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Alerts List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: alertsList},
+	}
+}
+
+func alertsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	matchers, err := stringArray(params, "matchers")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts, %w", err)), nil
+	}
+	filter := alertmanager.AlertsFilter{
+		Active:    ptr.To(true),
+		Silenced:  ptr.To(api.OptionalBool(params, "silenced", false)),
+		Inhibited: ptr.To(api.OptionalBool(params, "inhibited", false)),
+		Matchers:  matchers,
+		Receiver:  api.OptionalString(params, "receiver", ""),
+	}
+	alerts, err := alertmanager.NewAlertmanager(params, params).Alerts(params, filter)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(alerts, nil), nil
+}
+
+// stringArray returns the string items of an optional array argument
+func stringArray(params api.ToolHandlerParams, name string) ([]string, error) {
+	value, ok := params.GetArguments()[name]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of strings", name)
+	}
+	ret := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an array of strings", name)
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
@@ -0,0 +1,185 @@
+package alertmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/alertmanager"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	defaultSilenceDuration  = "2h"
+	defaultSilenceCreatedBy = "kubernetes-mcp-server"
+)
+
+func initSilences() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "alertmanager_silences_list",
+			Description: "List the silences in Alertmanager (active, pending, and expired) including their matchers, time range, author, and comment",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": matchersSchema,
+					"state": {
+						Type:        "string",
+						Description: "Optional state of the silences to return",
+						Enum:        []any{"active", "pending", "expired"},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Silences List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silencesList},
+		{Tool: api.Tool{
+			Name: "alertmanager_silence_create",
+			Description: "Create a silence in Alertmanager that mutes the notifications of the alerts matching all the provided matchers. " +
+				"The silence starts immediately and lasts for the provided duration (or until ends_at). Returns the ID of the new silence",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": {
+						Type: "array",
+						Description: "Label matchers in the Alertmanager matcher syntax selecting the alerts to silence " +
+							`(e.g. ["alertname=\"KubePodCrashLooping\"", "namespace=\"default\""])`,
+						Items:    &jsonschema.Schema{Type: "string"},
+						MinItems: ptr.To(1),
+					},
+					"comment": {
+						Type:        "string",
+						Description: "Reason for the silence",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Duration of the silence (e.g. 30m, 2h, 24h), ignored if ends_at is provided",
+						Default:     api.ToRawMessage(defaultSilenceDuration),
+					},
+					"ends_at": {
+						Type:        "string",
+						Description: "Optional end of the silence as an RFC3339 timestamp",
+					},
+					"created_by": {
+						Type:        "string",
+						Description: "Author of the silence",
+						Default:     api.ToRawMessage(defaultSilenceCreatedBy),
+					},
+				},
+				Required: []string{"matchers", "comment"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Silence Create",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silenceCreate},
+		{Tool: api.Tool{
+			Name:        "alertmanager_silence_expire",
+			Description: "Expire an Alertmanager silence by ID, the notifications of the matching alerts are sent again",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the silence to expire",
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alertmanager: Silence Expire",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silenceExpire},
+	}
+}
+
+func silencesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	matchers, err := stringArray(params, "matchers")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences, %w", err)), nil
+	}
+	silences, err := alertmanager.NewAlertmanager(params, params).Silences(params, matchers)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences: %w", err)), nil
+	}
+	if state := api.OptionalString(params, "state", ""); state != "" {
+		filtered := make([]alertmanager.Silence, 0, len(silences))
+		for _, silence := range silences {
+			if silence.Status != nil && silence.Status.State == state {
+				filtered = append(filtered, silence)
+			}
+		}
+		silences = filtered
+	}
+	return api.NewToolCallResultStructured(silences, nil), nil
+}
+
+func silenceCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	rawMatchers, err := stringArray(params, "matchers")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence, %w", err)), nil
+	}
+	if len(rawMatchers) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence, matchers parameter required")), nil
+	}
+	matchers, err := alertmanager.ParseMatchers(rawMatchers)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence, %w", err)), nil
+	}
+	comment, err := api.RequiredString(params, "comment")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence, %w", err)), nil
+	}
+	startsAt := time.Now().UTC()
+	var endsAt time.Time
+	if v := api.OptionalString(params, "ends_at", ""); v != "" {
+		if endsAt, err = time.Parse(time.RFC3339, v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create silence, ends_at %s is not an RFC3339 timestamp", v)), nil
+		}
+	} else {
+		v = api.OptionalString(params, "duration", defaultSilenceDuration)
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create silence, invalid duration: %s", v)), nil
+		}
+		endsAt = startsAt.Add(duration)
+	}
+	silence := alertmanager.Silence{
+		Matchers:  matchers,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedBy: api.OptionalString(params, "created_by", defaultSilenceCreatedBy),
+		Comment:   comment,
+	}
+	id, err := alertmanager.NewAlertmanager(params, params).CreateSilence(params, silence)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+	}
+	silence.ID = id
+	return api.NewToolCallResultStructured(silence, nil), nil
+}
+
+func silenceExpire(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	id, err := api.RequiredString(params, "id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence, %w", err)), nil
+	}
+	if err = alertmanager.NewAlertmanager(params, params).ExpireSilence(params, id); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence: %w", err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Silence %s expired", id), nil), nil
+}
@@ -0,0 +1,36 @@
+package alertmanager
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "alertmanager"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for listing Alertmanager alerts and managing silences, check the [Alertmanager documentation](https://github.com/containers/kubernetes-mcp-server/blob/main/docs/ALERTMANAGER.md) for more details."
+}
+
+func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initAlerts(),
+		initSilences(),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	// Alertmanager toolset does not provide prompts
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initCapacity() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "capacity_report",
+			Description: "Report the resource capacity of the current cluster for capacity planning. " +
+				"Compares the Node allocatable CPU and memory with the sum of the Pod requests and limits, " +
+				"the ResourceQuota usage and LimitRange defaults of each Namespace, and the usage reported by the Metrics Server (when available). " +
+				"Returns structured over- and under-provisioning findings (e.g. requests far above usage, exhausted quotas, overcommitted Nodes)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to report the capacity for (reports the Nodes and all the Namespaces if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Capacity: Report",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: capacityReport},
+	}
+}
+
+func capacityReport(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	report, err := kubernetes.NewCore(params).CapacityReport(params, ns)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to report capacity: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(report, nil), nil
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const defaultCertificatesExpiringDays = 30
+
+func initCertificates() []api.ServerTool {
+	sources := make([]any, 0, len(kubernetes.CertificateSources))
+	for _, source := range kubernetes.CertificateSources {
+		sources = append(sources, source)
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "certificates_check",
+			Description: "Check the TLS certificates in the current cluster and report the ones that are expired or about to expire. " +
+				"Inspects kubernetes.io/tls Secrets, cert-manager Certificates, admission webhook caBundles, and the serving certificate of the API server. " +
+				"Returns the subject, issuer, SANs, and days to expiry of each certificate (soonest expiry first). " +
+				"The private keys and any other sensitive value of the Secrets are discarded as soon as the Secrets are retrieved and never returned",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to check the Secrets and cert-manager Certificates in (checks all namespaces if not provided)",
+					},
+					"sources": {
+						Type:        "array",
+						Description: "Optional list of sources to check (checks all sources if not provided)",
+						Items:       &jsonschema.Schema{Type: "string", Enum: sources},
+					},
+					"expiring_days": {
+						Type:        "integer",
+						Description: "Certificates expiring within this number of days are reported as ExpiringSoon",
+						Default:     api.ToRawMessage(defaultCertificatesExpiringDays),
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Certificates: Check",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificatesCheck},
+	}
+}
+
+func certificatesCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	var sources []string
+	if v, ok := params.GetArguments()["sources"].([]any); ok {
+		for _, source := range v {
+			if s, ok := source.(string); ok {
+				sources = append(sources, s)
+			}
+		}
+	}
+	expiringDays := int64(defaultCertificatesExpiringDays)
+	if v, ok := params.GetArguments()["expiring_days"]; ok {
+		var err error
+		if expiringDays, err = api.ParseInt64(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to parse expiring_days parameter: %w", err)), nil
+		}
+	}
+	report, err := kubernetes.NewCore(params).CertificatesCheck(params, ns, sources, int(max(0, expiringDays)))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check certificates: %w", err)), nil
+	}
+	// Distinguished names may include e-mail addresses
+	for i := range report.Certificates {
+		report.Certificates[i].Subject = MaskPII(report.Certificates[i].Subject)
+		report.Certificates[i].Issuer = MaskPII(report.Certificates[i].Issuer)
+	}
+	return api.NewToolCallResultStructured(report, nil), nil
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// Config holds the core toolset configuration
+type Config struct {
+	// HealthRules are declarative rules evaluated by the cluster health check in addition to the registered ones
+	HealthRules []*HealthRuleConfig `toml:"health_rules,omitempty"`
+	// CompactPruneFields are the field paths removed from the YAML output of the get and list tools unless verbose is requested
+	// (defaults to output.DefaultCompactPruneFields, an empty list disables the compaction)
+	CompactPruneFields []string `toml:"compact_prune_fields,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	names := make(map[string]bool, len(c.HealthRules))
+	for i, rule := range c.HealthRules {
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("invalid health_rules[%d]: %w", i, err)
+		}
+		if names[rule.RuleName] {
+			return fmt.Errorf("invalid health_rules[%d]: duplicate name %s", i, rule.RuleName)
+		}
+		names[rule.RuleName] = true
+	}
+	for i, field := range c.CompactPruneFields {
+		if _, err := output.ParseFieldPath(field); err != nil {
+			return fmt.Errorf("invalid compact_prune_fields[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// compactPruneFields returns the configured compact_prune_fields, or the defaults if not configured
+func compactPruneFields(configProvider api.ExtendedConfigProvider) []string {
+	if configProvider != nil {
+		if cfg, ok := configProvider.GetToolsetConfig("core"); ok {
+			if coreCfg, ok := cfg.(*Config); ok && coreCfg != nil && coreCfg.CompactPruneFields != nil {
+				return coreCfg.CompactPruneFields
+			}
+		}
+	}
+	return output.DefaultCompactPruneFields
+}
+
+func coreToolsetParser(_ context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("core", coreToolsetParser)
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func (s *ConfigSuite) TestHealthRules() {
+	cfg := test.Must(config.ReadToml([]byte(`
+		[[toolset_configs.core.health_rules]]
+		name = "deployment-without-pdb"
+		category = "Deployments"
+		api_version = "apps/v1"
+		kind = "Deployment"
+		expression = "!related.pdbs.exists(p, p.metadata.namespace == object.metadata.namespace)"
+		message = "No PodDisruptionBudget in the namespace"
+		[toolset_configs.core.health_rules.related]
+		pdbs = { api_version = "policy/v1", kind = "PodDisruptionBudget" }
+
+		[[toolset_configs.core.health_rules]]
+		name = "certificate-expiring"
+		severity = "critical"
+		api_version = "cert-manager.io/v1"
+		kind = "Certificate"
+		expression = "timestamp(object.status.notAfter) - now < duration('336h')"
+		message_expression = "'Expires at ' + object.status.notAfter"
+	`)))
+	coreCfg, ok := cfg.GetToolsetConfig("core")
+	s.Require().True(ok, "core config should be present")
+	rules := coreCfg.(*Config).HealthRules
+	s.Require().Len(rules, 2)
+	s.Run("parses rule with related kinds", func() {
+		s.Equal("deployment-without-pdb", rules[0].Name())
+		s.Equal("Deployments", rules[0].Category())
+		s.Equal(HealthSeverityWarning, rules[0].Severity)
+		s.Equal("PodDisruptionBudget", rules[0].relatedGVKs["pdbs"].Kind)
+		s.NotNil(rules[0].program)
+	})
+	s.Run("parses rule with message expression", func() {
+		s.Equal("certificate-expiring", rules[1].Category(), "category defaults to the rule name")
+		s.Equal(HealthSeverityCritical, rules[1].Severity)
+		s.Equal("cert-manager.io", rules[1].gvk.Group)
+		s.NotNil(rules[1].messageProgram)
+	})
+}
+
+func (s *ConfigSuite) TestHealthRulesInvalid() {
+	for name, tc := range map[string]struct {
+		rule     string
+		expected string
+	}{
+		"missing name": {
+			rule:     `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "true"`,
+			expected: "invalid health_rules[0]: name is required",
+		},
+		"invalid severity": {
+			rule:     `name = "r"` + "\n" + `severity = "info"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "true"`,
+			expected: "r: severity must be critical or warning",
+		},
+		"missing kind": {
+			rule:     `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `expression = "true"`,
+			expected: "r: api_version and kind are required",
+		},
+		"missing expression": {
+			rule:     `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"`,
+			expected: "r: expression is required",
+		},
+		"invalid expression": {
+			rule:     `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "object.spec.("`,
+			expected: "r: expression: ERROR",
+		},
+		"non boolean expression": {
+			rule:     `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "'text'"`,
+			expected: "r: expression: must evaluate to bool, got string",
+		},
+		"non string message expression": {
+			rule:     `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "true"` + "\n" + `message_expression = "1"`,
+			expected: "r: message_expression: must evaluate to string, got int",
+		},
+	} {
+		s.Run(name, func() {
+			_, err := config.ReadToml([]byte("[[toolset_configs.core.health_rules]]\n" + tc.rule))
+			s.Require().Error(err)
+			s.Contains(err.Error(), tc.expected)
+		})
+	}
+	s.Run("duplicate name", func() {
+		rule := "[[toolset_configs.core.health_rules]]\n" + `name = "r"` + "\n" + `api_version = "v1"` + "\n" + `kind = "Pod"` + "\n" + `expression = "true"` + "\n"
+		_, err := config.ReadToml([]byte(rule + rule))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid health_rules[1]: duplicate name r")
+	})
+}
+
+func (s *ConfigSuite) TestCompactPruneFields() {
+	s.Run("defaults when not configured", func() {
+		cfg := test.Must(config.ReadToml([]byte(``)))
+		s.Equal(output.DefaultCompactPruneFields, compactPruneFields(cfg))
+	})
+	s.Run("parses configured fields", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.core]
+			compact_prune_fields = [ "metadata.managedFields", 'metadata.annotations.example\.com/noise' ]
+		`)))
+		s.Equal([]string{"metadata.managedFields", `metadata.annotations.example\.com/noise`}, compactPruneFields(cfg))
+	})
+	s.Run("empty list disables compaction", func() {
+		cfg := test.Must(config.ReadToml([]byte(`
+			[toolset_configs.core]
+			compact_prune_fields = []
+		`)))
+		s.Empty(compactPruneFields(cfg))
+	})
+	s.Run("invalid field path", func() {
+		_, err := config.ReadToml([]byte(`
+			[toolset_configs.core]
+			compact_prune_fields = [ "metadata..uid" ]
+		`))
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid compact_prune_fields[0]")
+	})
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initDebug() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "pods_debug",
+			Description: "Debug a Kubernetes Pod by adding an ephemeral container with debugging tools (useful for distroless images where pods_exec can't be used). Waits for the debug container to be running and optionally executes a command in it. Further commands can be executed with pods_exec using the returned container name",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to debug",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to debug",
+					},
+					"image": {
+						Type:        "string",
+						Description: "Container image of the ephemeral debug container (Optional, default: " + kubernetes.DefaultDebugImage + ")",
+						Default:     api.ToRawMessage(kubernetes.DefaultDebugImage),
+					},
+					"target": {
+						Type:        "string",
+						Description: "Name of the Pod container whose process namespace is shared with the debug container, allows inspecting its processes and filesystem through /proc/<pid>/root (Optional)",
+					},
+					"command": {
+						Type:        "array",
+						Description: "Command to execute in the debug container once it's running (Optional). Example: [\"ps\", \"aux\"]",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Debug",
+				DestructiveHint: ptr.To(true), // Ephemeral containers can't be removed from the Pod once added
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsDebug},
+		{Tool: api.Tool{
+			Name:        "nodes_debug",
+			Description: "Debug a Kubernetes node by creating a privileged Pod on it that shares the host PID, network, and IPC namespaces, with the node root filesystem mounted at /host. Waits for the Pod to be running and optionally executes a command in it. Further commands can be executed with pods_exec, the Pod must be deleted with pods_delete when no longer needed",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the node to debug",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace where the debug Pod will be created (Optional, current namespace if not provided)",
+					},
+					"image": {
+						Type:        "string",
+						Description: "Container image of the debug Pod (Optional, default: " + kubernetes.DefaultDebugImage + ")",
+						Default:     api.ToRawMessage(kubernetes.DefaultDebugImage),
+					},
+					"command": {
+						Type:        "array",
+						Description: "Command to execute in the debug Pod once it's running (Optional). Example: [\"chroot\", \"/host\", \"journalctl\", \"-u\", \"kubelet\", \"-n\", \"50\"]",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Node: Debug",
+				DestructiveHint: ptr.To(true), // Privileged access to the host
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesDebug},
+	}
+}
+
+func podsDebug(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug pod, %w", err)), nil
+	}
+	image := api.OptionalString(params, "image", kubernetes.DefaultDebugImage)
+	target := api.OptionalString(params, "target", "")
+	command, err := debugCommand(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug pod, %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).PodsDebug(params, ns, name, image, target, command)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug pod %s in namespace %s: %w", name, ns, err)), nil
+	}
+	return api.NewToolCallResult(debugResult(fmt.Sprintf(
+		"Ephemeral container %s (%s) is running in pod %s in namespace %s",
+		ret.Container, image, ret.Pod, ret.Namespace), ret, command), nil), nil
+}
+
+func nodesDebug(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug node, %w", err)), nil
+	}
+	ns := api.OptionalString(params, "namespace", "")
+	image := api.OptionalString(params, "image", kubernetes.DefaultDebugImage)
+	command, err := debugCommand(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug node, %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NodesDebug(params, ns, name, image, command)
+	if err != nil {
+		if ret != nil {
+			err = fmt.Errorf("%w (debug pod %s in namespace %s must be deleted with pods_delete)", err, ret.Pod, ret.Namespace)
+		}
+		return api.NewToolCallResult("", fmt.Errorf("failed to debug node %s: %w", name, err)), nil
+	}
+	return api.NewToolCallResult(debugResult(fmt.Sprintf(
+		"Debug pod %s (%s) is running on node %s in namespace %s, delete it with pods_delete when no longer needed",
+		ret.Pod, image, name, ret.Namespace), ret, command), nil), nil
+}
+
+func debugCommand(params api.ToolHandlerParams) ([]string, error) {
+	commandArg, ok := params.GetArguments()["command"]
+	if !ok || commandArg == nil {
+		return nil, nil
+	}
+	commandItems, ok := commandArg.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid command argument")
+	}
+	command := make([]string, 0, len(commandItems))
+	for _, cmd := range commandItems {
+		if s, ok := cmd.(string); ok {
+			command = append(command, s)
+		}
+	}
+	return command, nil
+}
+
+func debugResult(header string, ret *kubernetes.DebugResult, command []string) string {
+	var sb strings.Builder
+	sb.WriteString("# " + header + "\n")
+	sb.WriteString(fmt.Sprintf("# Use pods_exec with namespace=%s, name=%s, and container=%s to execute commands\n", ret.Namespace, ret.Pod, ret.Container))
+	if len(command) > 0 {
+		sb.WriteString(fmt.Sprintf("## %s\n", strings.Join(command, " ")))
+		if ret.Output == "" {
+			sb.WriteString("The executed command has not produced any output\n")
+		} else {
+			sb.WriteString(MaskPII(ret.Output))
+		}
+	}
+	return sb.String()
+}
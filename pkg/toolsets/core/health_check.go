@@ -1,14 +1,19 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
@@ -18,6 +23,30 @@ import (
 // is flagged as having issues, even if it's currently running without errors.
 const podHighRestartThreshold = 5
 
+// Severities of the health check findings, also used as the overall health status along with healthStatusHealthy
+const (
+	HealthSeverityCritical = "critical"
+	HealthSeverityWarning  = "warning"
+	healthStatusHealthy    = "healthy"
+)
+
+// Health check categories, in the order they're reported
+const (
+	healthCategoryNodes            = "Nodes"
+	healthCategoryClusterOperators = "ClusterOperators"
+	healthCategoryPods             = "Pods"
+	healthCategoryDeployments      = "Deployments"
+	healthCategoryStatefulSets     = "StatefulSets"
+	healthCategoryDaemonSets       = "DaemonSets"
+	healthCategoryPVCs             = "PersistentVolumeClaims"
+)
+
+// healthCategoriesFormatted are the categories with a dedicated section in the cluster-health-check prompt
+var healthCategoriesFormatted = []string{
+	healthCategoryNodes, healthCategoryClusterOperators, healthCategoryPods, healthCategoryDeployments,
+	healthCategoryStatefulSets, healthCategoryDaemonSets, healthCategoryPVCs,
+}
+
 // initHealthChecks initializes the cluster health check prompts
 func initHealthChecks() []api.ServerPrompt {
 	return []api.ServerPrompt{
@@ -44,43 +73,57 @@ func initHealthChecks() []api.ServerPrompt {
 	}
 }
 
+// initClusterHealth initializes the cluster_health tool, which returns the same diagnostics as the
+// cluster-health-check prompt as structured content
+func initClusterHealth() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "cluster_health",
+			Description: "Check the health of the Kubernetes/OpenShift cluster (Nodes, Cluster Operators, Pods, Deployments, StatefulSets, DaemonSets, PersistentVolumeClaims, and recent warning events). " +
+				"Returns structured JSON with an overall status (healthy, warning, critical) and score (0-100), and the findings of each category with their severity and score, " +
+				"so that the health can be compared between runs",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace to limit the health check scope (default: all namespaces)",
+					},
+					"check_events": {
+						Type:        "boolean",
+						Description: "Include recent warning/error events (default: true)",
+						Default:     api.ToRawMessage(true),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Cluster: Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterHealth},
+	}
+}
+
+// healthCheckParams contains the context and client used to gather the cluster diagnostics, shared by the
+// cluster-health-check prompt and the cluster_health tool
+type healthCheckParams struct {
+	context.Context
+	api.KubernetesClient
+}
+
 // clusterHealthCheckHandler implements the cluster health check prompt
 func clusterHealthCheckHandler(params api.PromptHandlerParams) (*api.PromptCallResult, error) {
 	args := params.GetArguments()
-	namespace := args["namespace"]
 	checkEvents := args["check_events"] != "false" // default true
 
-	klog.Info("Starting cluster health check...")
-
-	// Check if namespace exists if specified
-	namespaceWarning := ""
-	requestedNamespace := namespace
-	if namespace != "" {
-		_, err := params.CoreV1().Namespaces().Get(params.Context, namespace, metav1.GetOptions{})
-		if err != nil {
-			// Namespace doesn't exist - show warning and proceed with cluster-wide check
-			namespaceWarning = fmt.Sprintf("Namespace '%s' not found or not accessible. Showing cluster-wide information instead.", namespace)
-			namespace = "" // Fall back to cluster-wide check
-			klog.Warningf("Namespace '%s' not found, performing cluster-wide health check", requestedNamespace)
-		} else {
-			klog.Infof("Performing health check for namespace: %s", namespace)
-		}
-	} else {
-		klog.Info("Performing cluster-wide health check")
-	}
-
-	diagnostics, err := gatherClusterDiagnostics(params, namespace, checkEvents)
+	diagnostics, err := clusterHealthCheck(healthCheckParams{params.Context, params.KubernetesClient}, healthRules(params.ExtendedConfigProvider), args["namespace"], checkEvents)
 	if err != nil {
 		return nil, fmt.Errorf("failed to gather cluster diagnostics: %w", err)
 	}
 
-	// Set namespace warning and requested namespace for display
-	diagnostics.NamespaceWarning = namespaceWarning
-	if requestedNamespace != "" && namespaceWarning != "" {
-		diagnostics.TargetNamespace = requestedNamespace
-		diagnostics.NamespaceScoped = false // Changed to cluster-wide due to error
-	}
-
 	// Format diagnostic data for LLM analysis
 	promptText := formatHealthCheckPrompt(diagnostics)
 
@@ -106,95 +149,191 @@ func clusterHealthCheckHandler(params api.PromptHandlerParams) (*api.PromptCallR
 	), nil
 }
 
-// clusterDiagnostics contains all diagnostic data gathered from the cluster
-type clusterDiagnostics struct {
-	Nodes            string
-	Pods             string
-	Deployments      string
-	StatefulSets     string
-	DaemonSets       string
-	PVCs             string
-	ClusterOperators string
-	Events           string
-	CollectionTime   time.Time
-	TotalNamespaces  int
-	NamespaceScoped  bool
-	TargetNamespace  string
-	NamespaceWarning string
+// clusterHealth implements the cluster_health tool
+func clusterHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := api.OptionalString(params, "namespace", "")
+	checkEvents := api.OptionalBool(params, "check_events", true)
+	diagnostics, err := clusterHealthCheck(healthCheckParams{params.Context, params.KubernetesClient}, healthRules(params.ExtendedConfigProvider), namespace, checkEvents)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to gather cluster diagnostics: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(diagnostics, nil), nil
 }
 
-// gatherClusterDiagnostics collects comprehensive diagnostic data from the cluster
-func gatherClusterDiagnostics(params api.PromptHandlerParams, namespace string, checkEvents bool) (*clusterDiagnostics, error) {
-	diag := &clusterDiagnostics{
-		CollectionTime:  time.Now(),
-		NamespaceScoped: namespace != "",
-		TargetNamespace: namespace,
-	}
+// clusterHealthCheck validates the requested namespace (falling back to a cluster-wide check if it's not
+// accessible) and gathers the cluster diagnostics
+func clusterHealthCheck(params healthCheckParams, rules []HealthRule, namespace string, checkEvents bool) (*clusterDiagnostics, error) {
+	klog.Info("Starting cluster health check...")
 
-	// Gather node diagnostics using ResourcesList
-	klog.Info("Collecting node diagnostics...")
-	nodeDiag, err := gatherNodeDiagnostics(params)
-	if err == nil {
-		diag.Nodes = nodeDiag
-		klog.Info("Node diagnostics collected")
+	// Check if namespace exists if specified
+	namespaceWarning := ""
+	requestedNamespace := namespace
+	if namespace != "" {
+		_, err := params.CoreV1().Namespaces().Get(params.Context, namespace, metav1.GetOptions{})
+		if err != nil {
+			// Namespace doesn't exist - show warning and proceed with cluster-wide check
+			namespaceWarning = fmt.Sprintf("Namespace '%s' not found or not accessible. Showing cluster-wide information instead.", namespace)
+			namespace = "" // Fall back to cluster-wide check
+			klog.Warningf("Namespace '%s' not found, performing cluster-wide health check", requestedNamespace)
+		} else {
+			klog.Infof("Performing health check for namespace: %s", namespace)
+		}
 	} else {
-		klog.Warningf("Failed to collect node diagnostics: %v", err)
+		klog.Info("Performing cluster-wide health check")
 	}
 
-	// Gather pod diagnostics
-	klog.Info("Collecting pod diagnostics...")
-	podDiag, err := gatherPodDiagnostics(params, namespace)
-	if err == nil {
-		diag.Pods = podDiag
-		klog.Info("Pod diagnostics collected")
-	} else {
-		klog.Warningf("Failed to collect pod diagnostics: %v", err)
+	diagnostics, err := gatherClusterDiagnostics(params, rules, namespace, checkEvents)
+	if err != nil {
+		return nil, err
 	}
 
-	// Gather workload diagnostics
-	klog.Info("Collecting deployment diagnostics...")
-	deployDiag, err := gatherWorkloadDiagnostics(params, "Deployment", namespace)
-	if err == nil {
-		diag.Deployments = deployDiag
-		klog.Info("Deployment diagnostics collected")
-	} else {
-		klog.Warningf("Failed to collect deployment diagnostics: %v", err)
+	// Set namespace warning and requested namespace for display
+	diagnostics.NamespaceWarning = namespaceWarning
+	if requestedNamespace != "" && namespaceWarning != "" {
+		diagnostics.TargetNamespace = requestedNamespace
+		diagnostics.NamespaceScoped = false // Changed to cluster-wide due to error
 	}
+	return diagnostics, nil
+}
 
-	klog.Info("Collecting statefulset diagnostics...")
-	stsDiag, err := gatherWorkloadDiagnostics(params, "StatefulSet", namespace)
-	if err == nil {
-		diag.StatefulSets = stsDiag
-		klog.Info("StatefulSet diagnostics collected")
-	} else {
-		klog.Warningf("Failed to collect statefulset diagnostics: %v", err)
+// clusterDiagnostics contains all diagnostic data gathered from the cluster
+type clusterDiagnostics struct {
+	// Status is the overall health status: healthy, warning (at least one warning finding), or critical (at least one critical finding)
+	Status string `json:"status"`
+	// Score is the average of the category scores (0-100)
+	Score int `json:"score"`
+	// Severities is the number of findings per severity
+	Severities       map[string]int    `json:"severities"`
+	Categories       []*healthCategory `json:"categories"`
+	Events           *healthEvents     `json:"events,omitempty"`
+	CollectionTime   time.Time         `json:"collectionTime"`
+	TotalNamespaces  int               `json:"totalNamespaces"`
+	NamespaceScoped  bool              `json:"namespaceScoped"`
+	TargetNamespace  string            `json:"targetNamespace,omitempty"`
+	NamespaceWarning string            `json:"namespaceWarning,omitempty"`
+	// RuleErrors are the health rules that failed to evaluate, their categories are missing or incomplete
+	RuleErrors []healthRuleError `json:"ruleErrors,omitempty"`
+}
+
+type healthRuleError struct {
+	Rule  string `json:"rule"`
+	Error string `json:"error"`
+}
+
+// healthCategory contains the findings for a category of resources (Nodes, Pods, Deployments, ...)
+type healthCategory struct {
+	Name string `json:"name"`
+	// Total is the number of resources checked
+	Total int `json:"total"`
+	// Score is the percentage of healthy resources, critical findings count fully and warnings count half
+	Score    int             `json:"score"`
+	Findings []HealthFinding `json:"findings"`
+}
+
+// HealthFinding is an issue detected by a HealthRule for a resource
+type HealthFinding struct {
+	Severity  string   `json:"severity"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Status    string   `json:"status,omitempty"`
+	Issues    []string `json:"issues"`
+}
+
+// healthEvents contains the recent warning and error events, they're reported but don't affect the scores
+type healthEvents struct {
+	Warnings int           `json:"warnings"`
+	Errors   int           `json:"errors"`
+	Items    []healthEvent `json:"items"`
+}
+
+type healthEvent struct {
+	Type      string `json:"type"`
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Count     int32  `json:"count"`
+	Message   string `json:"message"`
+}
+
+// category returns the category with the provided name, or nil if it wasn't gathered
+func (d *clusterDiagnostics) category(name string) *healthCategory {
+	for _, category := range d.Categories {
+		if category.Name == name {
+			return category
+		}
 	}
+	return nil
+}
 
-	klog.Info("Collecting daemonset diagnostics...")
-	dsDiag, err := gatherWorkloadDiagnostics(params, "DaemonSet", namespace)
-	if err == nil {
-		diag.DaemonSets = dsDiag
-		klog.Info("DaemonSet diagnostics collected")
-	} else {
-		klog.Warningf("Failed to collect daemonset diagnostics: %v", err)
+// addFindings adds the result of a rule to its category, rules reporting in the same category are expected
+// to check the same resources so the total is the largest number of resources checked by any of them
+func (d *clusterDiagnostics) addFindings(name string, total int, findings []HealthFinding) {
+	category := d.category(name)
+	if category == nil {
+		category = &healthCategory{Name: name, Findings: []HealthFinding{}}
+		d.Categories = append(d.Categories, category)
 	}
+	category.Total = max(category.Total, total)
+	category.Findings = append(category.Findings, findings...)
+}
 
-	// Gather PVC diagnostics
-	klog.Info("Collecting PVC diagnostics...")
-	pvcDiag, err := gatherPVCDiagnostics(params, namespace)
-	if err == nil {
-		diag.PVCs = pvcDiag
-		klog.Info("PVC diagnostics collected")
-	} else {
-		klog.Warningf("Failed to collect PVC diagnostics: %v", err)
+// score computes the category scores, the overall score and status, and the number of findings per severity
+func (d *clusterDiagnostics) score() {
+	d.Status = healthStatusHealthy
+	d.Severities = map[string]int{HealthSeverityCritical: 0, HealthSeverityWarning: 0}
+	total := 0
+	for _, category := range d.Categories {
+		penalty := 0.0
+		for _, finding := range category.Findings {
+			d.Severities[finding.Severity]++
+			switch finding.Severity {
+			case HealthSeverityCritical:
+				penalty++
+				d.Status = HealthSeverityCritical
+			case HealthSeverityWarning:
+				penalty += 0.5
+				if d.Status != HealthSeverityCritical {
+					d.Status = HealthSeverityWarning
+				}
+			}
+		}
+		category.Score = 100
+		if category.Total > 0 {
+			category.Score = max(0, int(100*(float64(category.Total)-penalty)/float64(category.Total)))
+		}
+		total += category.Score
 	}
+	d.Score = 100
+	if len(d.Categories) > 0 {
+		d.Score = total / len(d.Categories)
+	}
+}
 
-	// Gather cluster operator diagnostics (OpenShift only)
-	klog.Info("Checking for cluster operators (OpenShift)...")
-	operatorDiag, err := gatherClusterOperatorDiagnostics(params)
-	if err == nil {
-		diag.ClusterOperators = operatorDiag
-		klog.Info("Cluster operator diagnostics collected")
+// gatherClusterDiagnostics collects comprehensive diagnostic data from the cluster
+func gatherClusterDiagnostics(params healthCheckParams, rules []HealthRule, namespace string, checkEvents bool) (*clusterDiagnostics, error) {
+	diag := &clusterDiagnostics{
+		Categories:      []*healthCategory{},
+		CollectionTime:  time.Now(),
+		NamespaceScoped: namespace != "",
+		TargetNamespace: namespace,
+	}
+
+	// Evaluate the health rules, each of them reports its findings in a category (Nodes, Pods, ...)
+	for _, rule := range rules {
+		klog.Infof("Evaluating health rule %s...", rule.Name())
+		total, findings, err := rule.Check(params.Context, params.KubernetesClient, namespace)
+		if errors.Is(err, ErrHealthRuleNotApplicable) {
+			klog.V(1).Infof("Health rule %s is not applicable: %v", rule.Name(), err)
+			continue
+		} else if err != nil {
+			klog.Warningf("Failed to evaluate health rule %s: %v", rule.Name(), err)
+			diag.RuleErrors = append(diag.RuleErrors, healthRuleError{Rule: rule.Name(), Error: err.Error()})
+			continue
+		}
+		diag.addFindings(rule.Category(), total, findings)
+		klog.Infof("Health rule %s evaluated", rule.Name())
 	}
 
 	// Gather recent events if requested
@@ -217,28 +356,22 @@ func gatherClusterDiagnostics(params api.PromptHandlerParams, namespace string,
 		klog.Infof("Found %d namespaces", diag.TotalNamespaces)
 	}
 
+	diag.score()
 	klog.Info("Cluster health check data collection completed")
 	return diag, nil
 }
 
 // gatherNodeDiagnostics collects node status using CoreV1 clientset
-func gatherNodeDiagnostics(params api.PromptHandlerParams) (string, error) {
+func gatherNodeDiagnostics(params healthCheckParams) (*healthCategory, error) {
 	nodeList, err := params.CoreV1().Nodes().List(params.Context, metav1.ListOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(nodeList.Items) == 0 {
-		return "No nodes found", nil
-	}
-
-	var sb strings.Builder
-	totalNodes := len(nodeList.Items)
-	healthyNodes := 0
-	var nodesWithIssues []string
-
+	category := &healthCategory{Total: len(nodeList.Items), Findings: []HealthFinding{}}
 	for _, node := range nodeList.Items {
 		nodeStatus := "Unknown"
+		severity := HealthSeverityWarning
 		var issues []string
 
 		// Parse node conditions
@@ -246,9 +379,9 @@ func gatherNodeDiagnostics(params api.PromptHandlerParams) (string, error) {
 			if cond.Type == v1.NodeReady {
 				if cond.Status == v1.ConditionTrue {
 					nodeStatus = "Ready"
-					healthyNodes++
 				} else {
 					nodeStatus = "NotReady"
+					severity = HealthSeverityCritical
 					issues = append(issues, fmt.Sprintf("Not ready: %s", cond.Message))
 				}
 			} else if cond.Status == v1.ConditionTrue {
@@ -259,36 +392,25 @@ func gatherNodeDiagnostics(params api.PromptHandlerParams) (string, error) {
 
 		// Only report nodes with issues
 		if len(issues) > 0 {
-			nodesWithIssues = append(nodesWithIssues, fmt.Sprintf("- **%s** (Status: %s)\n%s", node.Name, nodeStatus, "  - "+strings.Join(issues, "\n  - ")))
+			category.Findings = append(category.Findings, HealthFinding{
+				Severity: severity, Kind: "Node", Name: node.Name, Status: nodeStatus, Issues: issues,
+			})
 		}
 	}
-
-	sb.WriteString(fmt.Sprintf("**Total:** %d | **Healthy:** %d\n\n", totalNodes, healthyNodes))
-	if len(nodesWithIssues) > 0 {
-		sb.WriteString(strings.Join(nodesWithIssues, "\n\n"))
-	} else {
-		sb.WriteString("*All nodes are healthy*")
-	}
-
-	return sb.String(), nil
+	return category, nil
 }
 
 // gatherPodDiagnostics collects pod status using CoreV1 clientset
-func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
+func gatherPodDiagnostics(params healthCheckParams, namespace string) (*healthCategory, error) {
 	podList, err := params.CoreV1().Pods(namespace).List(params.Context, metav1.ListOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(podList.Items) == 0 {
-		return "No pods found", nil
-	}
-
-	totalPods := len(podList.Items)
-	var problemPods []string
-
+	category := &healthCategory{Total: len(podList.Items), Findings: []HealthFinding{}}
 	for _, pod := range podList.Items {
 		var issues []string
+		severity := HealthSeverityWarning
 		restarts := int32(0)
 		readyCount := 0
 		totalContainers := len(pod.Status.ContainerStatuses)
@@ -304,6 +426,7 @@ func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (str
 			if cs.State.Waiting != nil {
 				reason := cs.State.Waiting.Reason
 				if reason == "CrashLoopBackOff" || reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+					severity = HealthSeverityCritical
 					issues = append(issues, fmt.Sprintf("Container waiting: %s - %s", reason, cs.State.Waiting.Message))
 				}
 			}
@@ -312,6 +435,7 @@ func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (str
 			if cs.State.Terminated != nil {
 				reason := cs.State.Terminated.Reason
 				if reason == "Error" || reason == "OOMKilled" {
+					severity = HealthSeverityCritical
 					issues = append(issues, fmt.Sprintf("Container terminated: %s", reason))
 				}
 			}
@@ -319,153 +443,121 @@ func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (str
 
 		// Check pod phase
 		if pod.Status.Phase != v1.PodRunning && pod.Status.Phase != v1.PodSucceeded {
+			if pod.Status.Phase == v1.PodFailed {
+				severity = HealthSeverityCritical
+			}
 			issues = append(issues, fmt.Sprintf("Pod in %s phase", pod.Status.Phase))
 		}
 
 		// Report pods with issues or high restart count
-		if len(issues) > 0 || restarts > podHighRestartThreshold {
-			problemPods = append(problemPods, fmt.Sprintf("- **%s/%s** (Phase: %s, Ready: %d/%d, Restarts: %d)\n  - %s",
-				pod.Namespace, pod.Name, pod.Status.Phase, readyCount, totalContainers, restarts, strings.Join(issues, "\n  - ")))
+		if restarts > podHighRestartThreshold {
+			issues = append(issues, fmt.Sprintf("High restart count: %d", restarts))
+		}
+		if len(issues) > 0 {
+			category.Findings = append(category.Findings, HealthFinding{
+				Severity: severity, Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Issues: issues,
+				Status: fmt.Sprintf("Phase: %s, Ready: %d/%d, Restarts: %d", pod.Status.Phase, readyCount, totalContainers, restarts),
+			})
 		}
 	}
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("**Total:** %d | **With Issues:** %d\n\n", totalPods, len(problemPods)))
-	if len(problemPods) > 0 {
-		sb.WriteString(strings.Join(problemPods, "\n\n"))
-	} else {
-		sb.WriteString("*No pod issues detected*")
-	}
-
-	return sb.String(), nil
+	return category, nil
 }
 
 // gatherWorkloadDiagnostics collects workload controller status using AppsV1 clientset
-func gatherWorkloadDiagnostics(params api.PromptHandlerParams, kind string, namespace string) (string, error) {
-	var workloadsWithIssues []string
+func gatherWorkloadDiagnostics(params healthCheckParams, kind string, namespace string) (*healthCategory, error) {
+	category := &healthCategory{Findings: []HealthFinding{}}
+	// workloadFinding reports the workload if it has issues, critical if none of its replicas are ready
+	workloadFinding := func(meta metav1.ObjectMeta, ready, desired int32, issues ...string) {
+		if len(issues) == 0 {
+			return
+		}
+		severity := HealthSeverityWarning
+		if ready == 0 && desired > 0 {
+			severity = HealthSeverityCritical
+		}
+		category.Findings = append(category.Findings, HealthFinding{
+			Severity: severity, Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Status: fmt.Sprintf("Ready: %d/%d", ready, desired), Issues: issues,
+		})
+	}
 
 	switch kind {
 	case "Deployment":
 		deploymentList, err := params.AppsV1().Deployments(namespace).List(params.Context, metav1.ListOptions{})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		if len(deploymentList.Items) == 0 {
-			return "No Deployments found", nil
-		}
-
+		category.Total = len(deploymentList.Items)
 		for _, deployment := range deploymentList.Items {
 			var issues []string
-			ready := fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
-
 			if deployment.Status.UnavailableReplicas > 0 {
 				issues = append(issues, fmt.Sprintf("%d replicas unavailable", deployment.Status.UnavailableReplicas))
 			}
-
-			if len(issues) > 0 {
-				workloadsWithIssues = append(workloadsWithIssues, fmt.Sprintf("- **%s/%s** (Ready: %s)\n  - %s",
-					deployment.Namespace, deployment.Name, ready, strings.Join(issues, "\n  - ")))
-			}
+			workloadFinding(deployment.ObjectMeta, deployment.Status.ReadyReplicas, deployment.Status.Replicas, issues...)
 		}
 
 	case "StatefulSet":
 		statefulSetList, err := params.AppsV1().StatefulSets(namespace).List(params.Context, metav1.ListOptions{})
 		if err != nil {
-			return "", err
-		}
-		if len(statefulSetList.Items) == 0 {
-			return "No StatefulSets found", nil
+			return nil, err
 		}
-
+		category.Total = len(statefulSetList.Items)
 		for _, sts := range statefulSetList.Items {
 			var issues []string
 			specReplicas := int32(1)
 			if sts.Spec.Replicas != nil {
 				specReplicas = *sts.Spec.Replicas
 			}
-			ready := fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, specReplicas)
-
 			if sts.Status.ReadyReplicas < specReplicas {
 				issues = append(issues, fmt.Sprintf("Only %d/%d replicas ready", sts.Status.ReadyReplicas, specReplicas))
 			}
-
-			if len(issues) > 0 {
-				workloadsWithIssues = append(workloadsWithIssues, fmt.Sprintf("- **%s/%s** (Ready: %s)\n  - %s",
-					sts.Namespace, sts.Name, ready, strings.Join(issues, "\n  - ")))
-			}
+			workloadFinding(sts.ObjectMeta, sts.Status.ReadyReplicas, specReplicas, issues...)
 		}
 
 	case "DaemonSet":
 		daemonSetList, err := params.AppsV1().DaemonSets(namespace).List(params.Context, metav1.ListOptions{})
 		if err != nil {
-			return "", err
-		}
-		if len(daemonSetList.Items) == 0 {
-			return "No DaemonSets found", nil
+			return nil, err
 		}
-
+		category.Total = len(daemonSetList.Items)
 		for _, ds := range daemonSetList.Items {
 			var issues []string
-			ready := fmt.Sprintf("%d/%d", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
-
 			if ds.Status.NumberUnavailable > 0 {
 				issues = append(issues, fmt.Sprintf("%d pods unavailable", ds.Status.NumberUnavailable))
 			}
-
-			if len(issues) > 0 {
-				workloadsWithIssues = append(workloadsWithIssues, fmt.Sprintf("- **%s/%s** (Ready: %s)\n  - %s",
-					ds.Namespace, ds.Name, ready, strings.Join(issues, "\n  - ")))
-			}
+			workloadFinding(ds.ObjectMeta, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled, issues...)
 		}
 
 	default:
-		return "", fmt.Errorf("unsupported workload kind: %s", kind)
-	}
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("**%ss with Issues:** %d\n\n", kind, len(workloadsWithIssues)))
-	if len(workloadsWithIssues) > 0 {
-		sb.WriteString(strings.Join(workloadsWithIssues, "\n\n"))
-	} else {
-		sb.WriteString(fmt.Sprintf("*No %s issues detected*", kind))
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
 	}
-
-	return sb.String(), nil
+	return category, nil
 }
 
 // gatherPVCDiagnostics collects PVC status using CoreV1 clientset
-func gatherPVCDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
+func gatherPVCDiagnostics(params healthCheckParams, namespace string) (*healthCategory, error) {
 	pvcList, err := params.CoreV1().PersistentVolumeClaims(namespace).List(params.Context, metav1.ListOptions{})
 	if err != nil {
-		return "", err
-	}
-
-	if len(pvcList.Items) == 0 {
-		return "No PVCs found", nil
+		return nil, err
 	}
 
-	var pvcsWithIssues []string
-
+	category := &healthCategory{Total: len(pvcList.Items), Findings: []HealthFinding{}}
 	for _, pvc := range pvcList.Items {
 		if pvc.Status.Phase != v1.ClaimBound {
-			pvcsWithIssues = append(pvcsWithIssues, fmt.Sprintf("- **%s/%s** (Status: %s)\n  - PVC not bound",
-				pvc.Namespace, pvc.Name, pvc.Status.Phase))
+			severity := HealthSeverityWarning
+			if pvc.Status.Phase == v1.ClaimLost {
+				severity = HealthSeverityCritical
+			}
+			category.Findings = append(category.Findings, HealthFinding{
+				Severity: severity, Kind: "PersistentVolumeClaim", Namespace: pvc.Namespace, Name: pvc.Name,
+				Status: string(pvc.Status.Phase), Issues: []string{"PVC not bound"},
+			})
 		}
 	}
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("**PVCs with Issues:** %d\n\n", len(pvcsWithIssues)))
-	if len(pvcsWithIssues) > 0 {
-		sb.WriteString(strings.Join(pvcsWithIssues, "\n\n"))
-	} else {
-		sb.WriteString("*No PVC issues detected*")
-	}
-
-	return sb.String(), nil
+	return category, nil
 }
 
 // gatherClusterOperatorDiagnostics collects ClusterOperator status (OpenShift only)
-func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, error) {
+func gatherClusterOperatorDiagnostics(params healthCheckParams) (*healthCategory, error) {
 	gvk := &schema.GroupVersionKind{
 		Group:   "config.openshift.io",
 		Version: "v1",
@@ -475,16 +567,11 @@ func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, e
 	operatorList, err := kubernetes.NewCore(params).ResourcesList(params, gvk, "", api.ListOptions{})
 	if err != nil {
 		// Not an OpenShift cluster
-		return "", err
-	}
-
-	items, ok := operatorList.UnstructuredContent()["items"].([]interface{})
-	if !ok || len(items) == 0 {
-		return "No cluster operators found", nil
+		return nil, fmt.Errorf("%w: %v", ErrHealthRuleNotApplicable, err)
 	}
 
-	var operatorsWithIssues []string
-
+	items, _ := operatorList.UnstructuredContent()["items"].([]interface{})
+	category := &healthCategory{Total: len(items), Findings: []HealthFinding{}}
 	for _, item := range items {
 		opMap, ok := item.(map[string]interface{})
 		if !ok {
@@ -499,6 +586,7 @@ func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, e
 
 		available := "Unknown"
 		degraded := "Unknown"
+		severity := HealthSeverityWarning
 		var issues []string
 
 		for _, cond := range conditions {
@@ -511,6 +599,7 @@ func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, e
 			case "Available":
 				available = condStatus
 				if condStatus != "True" {
+					severity = HealthSeverityCritical
 					issues = append(issues, fmt.Sprintf("Not available: %s", message))
 				}
 			case "Degraded":
@@ -522,24 +611,17 @@ func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, e
 		}
 
 		if len(issues) > 0 {
-			operatorsWithIssues = append(operatorsWithIssues, fmt.Sprintf("- **%s** (Available: %s, Degraded: %s)\n  - %s",
-				name, available, degraded, strings.Join(issues, "\n  - ")))
+			category.Findings = append(category.Findings, HealthFinding{
+				Severity: severity, Kind: "ClusterOperator", Name: name,
+				Status: fmt.Sprintf("Available: %s, Degraded: %s", available, degraded), Issues: issues,
+			})
 		}
 	}
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("**Operators with Issues:** %d\n\n", len(operatorsWithIssues)))
-	if len(operatorsWithIssues) > 0 {
-		sb.WriteString(strings.Join(operatorsWithIssues, "\n\n"))
-	} else {
-		sb.WriteString("*All cluster operators are healthy*")
-	}
-
-	return sb.String(), nil
+	return category, nil
 }
 
 // gatherEventDiagnostics collects recent warning and error events
-func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
+func gatherEventDiagnostics(params healthCheckParams, namespace string) (*healthEvents, error) {
 	var namespaces []string
 
 	if namespace != "" {
@@ -560,9 +642,7 @@ func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (s
 	}
 
 	oneHourAgo := time.Now().Add(-1 * time.Hour)
-	totalWarnings := 0
-	totalErrors := 0
-	var recentEvents []string
+	events := &healthEvents{Items: []healthEvent{}}
 
 	for _, ns := range namespaces {
 		eventList, err := params.CoreV1().Events(ns).List(params.Context, metav1.ListOptions{})
@@ -586,9 +666,9 @@ func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (s
 			}
 
 			if event.Type == v1.EventTypeWarning {
-				totalWarnings++
+				events.Warnings++
 			} else {
-				totalErrors++
+				events.Errors++
 			}
 
 			// Limit message length
@@ -597,25 +677,19 @@ func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (s
 				message = message[:150] + "..."
 			}
 
-			recentEvents = append(recentEvents, fmt.Sprintf("- **%s/%s** in `%s` (%s, Count: %d)\n  - %s",
-				event.InvolvedObject.Kind, event.InvolvedObject.Name, ns, event.Reason, event.Count, message))
+			events.Items = append(events.Items, healthEvent{
+				Type: event.Type, Namespace: ns, Kind: event.InvolvedObject.Kind, Name: event.InvolvedObject.Name,
+				Reason: event.Reason, Count: event.Count, Message: message,
+			})
 		}
 	}
 
 	// Limit to 20 most recent events
-	if len(recentEvents) > 20 {
-		recentEvents = recentEvents[:20]
+	if len(events.Items) > 20 {
+		events.Items = events.Items[:20]
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("**Warnings:** %d | **Errors:** %d\n\n", totalWarnings, totalErrors))
-	if len(recentEvents) > 0 {
-		sb.WriteString(strings.Join(recentEvents, "\n\n"))
-	} else {
-		sb.WriteString("*No recent warning/error events*")
-	}
-
-	return sb.String(), nil
+	return events, nil
 }
 
 // formatHealthCheckPrompt formats diagnostic data into a prompt for LLM analysis
@@ -638,6 +712,8 @@ func formatHealthCheckPrompt(diag *clusterDiagnostics) string {
 	} else {
 		sb.WriteString(fmt.Sprintf("**Scope:** All namespaces (Total: %d)\n", diag.TotalNamespaces))
 	}
+	sb.WriteString(fmt.Sprintf("**Computed Status:** %s (Score: %d/100, Critical: %d, Warning: %d)\n",
+		diag.Status, diag.Score, diag.Severities[HealthSeverityCritical], diag.Severities[HealthSeverityWarning]))
 	sb.WriteString("\n")
 
 	sb.WriteString("## Your Task\n\n")
@@ -650,52 +726,90 @@ func formatHealthCheckPrompt(diag *clusterDiagnostics) string {
 
 	sb.WriteString("---\n\n")
 
-	if diag.Nodes != "" {
+	if nodes := diag.category(healthCategoryNodes); nodes != nil {
 		sb.WriteString("## 1. Nodes\n\n")
-		sb.WriteString(diag.Nodes)
+		sb.WriteString(formatHealthCategory(nodes,
+			fmt.Sprintf("**Total:** %d | **Healthy:** %d", nodes.Total, nodes.Total-len(nodes.Findings)),
+			"No nodes found", "*All nodes are healthy*"))
 		sb.WriteString("\n\n")
 	}
 
-	if diag.ClusterOperators != "" {
+	if operators := diag.category(healthCategoryClusterOperators); operators != nil {
 		sb.WriteString("## 2. Cluster Operators (OpenShift)\n\n")
-		sb.WriteString(diag.ClusterOperators)
+		sb.WriteString(formatHealthCategory(operators,
+			fmt.Sprintf("**Operators with Issues:** %d", len(operators.Findings)),
+			"No cluster operators found", "*All cluster operators are healthy*"))
 		sb.WriteString("\n\n")
 	}
 
-	if diag.Pods != "" {
+	if pods := diag.category(healthCategoryPods); pods != nil {
 		sb.WriteString("## 3. Pods\n\n")
-		sb.WriteString(diag.Pods)
+		sb.WriteString(formatHealthCategory(pods,
+			fmt.Sprintf("**Total:** %d | **With Issues:** %d", pods.Total, len(pods.Findings)),
+			"No pods found", "*No pod issues detected*"))
 		sb.WriteString("\n\n")
 	}
 
-	if diag.Deployments != "" || diag.StatefulSets != "" || diag.DaemonSets != "" {
+	workloads := []string{healthCategoryDeployments, healthCategoryStatefulSets, healthCategoryDaemonSets}
+	if diag.category(workloads[0]) != nil || diag.category(workloads[1]) != nil || diag.category(workloads[2]) != nil {
 		sb.WriteString("## 4. Workload Controllers\n\n")
-		if diag.Deployments != "" {
-			sb.WriteString("### Deployments\n\n")
-			sb.WriteString(diag.Deployments)
-			sb.WriteString("\n\n")
-		}
-		if diag.StatefulSets != "" {
-			sb.WriteString("### StatefulSets\n\n")
-			sb.WriteString(diag.StatefulSets)
-			sb.WriteString("\n\n")
-		}
-		if diag.DaemonSets != "" {
-			sb.WriteString("### DaemonSets\n\n")
-			sb.WriteString(diag.DaemonSets)
-			sb.WriteString("\n\n")
+		for _, name := range workloads {
+			if workload := diag.category(name); workload != nil {
+				sb.WriteString("### " + name + "\n\n")
+				sb.WriteString(formatHealthCategory(workload,
+					fmt.Sprintf("**%s with Issues:** %d", name, len(workload.Findings)),
+					fmt.Sprintf("No %s found", name), fmt.Sprintf("*No %s issues detected*", strings.TrimSuffix(name, "s"))))
+				sb.WriteString("\n\n")
+			}
 		}
 	}
 
-	if diag.PVCs != "" {
+	if pvcs := diag.category(healthCategoryPVCs); pvcs != nil {
 		sb.WriteString("## 5. Persistent Volume Claims\n\n")
-		sb.WriteString(diag.PVCs)
+		sb.WriteString(formatHealthCategory(pvcs,
+			fmt.Sprintf("**PVCs with Issues:** %d", len(pvcs.Findings)),
+			"No PVCs found", "*No PVC issues detected*"))
 		sb.WriteString("\n\n")
 	}
 
-	if diag.Events != "" {
-		sb.WriteString("## 6. Recent Events (Last Hour)\n\n")
-		sb.WriteString(diag.Events)
+	// The categories of the declared health rules that don't report in any of the above, each in its own section
+	section := 6
+	for _, category := range diag.Categories {
+		if slices.Contains(healthCategoriesFormatted, category.Name) {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("## %d. %s\n\n", section, category.Name))
+		sb.WriteString(formatHealthCategory(category,
+			fmt.Sprintf("**Total:** %d | **With Issues:** %d", category.Total, len(category.Findings)),
+			"No resources found", "*No issues detected*"))
+		sb.WriteString("\n\n")
+		section++
+	}
+
+	if diag.Events != nil {
+		sb.WriteString(fmt.Sprintf("## %d. Recent Events (Last Hour)\n\n", section))
+		section++
+		sb.WriteString(fmt.Sprintf("**Warnings:** %d | **Errors:** %d\n\n", diag.Events.Warnings, diag.Events.Errors))
+		if len(diag.Events.Items) > 0 {
+			var events []string
+			for _, event := range diag.Events.Items {
+				events = append(events, fmt.Sprintf("- **%s/%s** in `%s` (%s, Count: %d)\n  - %s",
+					event.Kind, event.Name, event.Namespace, event.Reason, event.Count, event.Message))
+			}
+			sb.WriteString(strings.Join(events, "\n\n"))
+		} else {
+			sb.WriteString("*No recent warning/error events*")
+		}
+		sb.WriteString("\n\n")
+	}
+
+	if len(diag.RuleErrors) > 0 {
+		sb.WriteString(fmt.Sprintf("## %d. Health Rule Errors\n\n", section))
+		var ruleErrors []string
+		for _, ruleError := range diag.RuleErrors {
+			ruleErrors = append(ruleErrors, fmt.Sprintf("- **%s**: %s", ruleError.Rule, ruleError.Error))
+		}
+		sb.WriteString(strings.Join(ruleErrors, "\n"))
 		sb.WriteString("\n\n")
 	}
 
@@ -704,3 +818,30 @@ func formatHealthCheckPrompt(diag *clusterDiagnostics) string {
 
 	return sb.String()
 }
+
+// formatHealthCategory formats the findings of a category as a markdown list preceded by the provided header
+func formatHealthCategory(category *healthCategory, header, empty, healthy string) string {
+	if category.Total == 0 {
+		return empty
+	}
+	var sb strings.Builder
+	sb.WriteString(header + "\n\n")
+	if len(category.Findings) == 0 {
+		sb.WriteString(healthy)
+		return sb.String()
+	}
+	findings := make([]string, 0, len(category.Findings))
+	for _, finding := range category.Findings {
+		name := finding.Name
+		if finding.Namespace != "" {
+			name = finding.Namespace + "/" + finding.Name
+		}
+		status := finding.Status
+		if finding.Kind == "Node" || finding.Kind == "PersistentVolumeClaim" {
+			status = "Status: " + status
+		}
+		findings = append(findings, fmt.Sprintf("- **%s** (%s, Severity: %s)\n  - %s", name, status, finding.Severity, strings.Join(finding.Issues, "\n  - ")))
+	}
+	sb.WriteString(strings.Join(findings, "\n\n"))
+	return sb.String()
+}
@@ -56,6 +56,37 @@ func (s *ClusterHealthCheckSuite) TestPromptIsRegistered() {
 	})
 }
 
+func (s *ClusterHealthCheckSuite) TestFormatHealthCheckPrompt() {
+	diag := &clusterDiagnostics{
+		Status:     HealthSeverityCritical,
+		Score:      75,
+		Severities: map[string]int{HealthSeverityCritical: 1},
+		Categories: []*healthCategory{
+			{Name: healthCategoryPods, Total: 2, Score: 100},
+			{Name: "certificate-expiring", Total: 2, Score: 50, Findings: []HealthFinding{
+				{Severity: HealthSeverityCritical, Kind: "Certificate", Namespace: "default", Name: "ingress-tls",
+					Issues: []string{"Certificate expires in less than 14 days"}},
+			}},
+		},
+		Events:     &healthEvents{},
+		RuleErrors: []healthRuleError{{Rule: "broken", Error: "no such key: spec"}},
+	}
+	prompt := formatHealthCheckPrompt(diag)
+	s.Run("renders the categories of the declared rules in their own section", func() {
+		s.Contains(prompt, "## 6. certificate-expiring\n\n**Total:** 2 | **With Issues:** 1\n\n")
+		s.Contains(prompt, "ingress-tls")
+		s.Contains(prompt, "Certificate expires in less than 14 days")
+	})
+	s.Run("numbers the following sections after the declared rule categories", func() {
+		s.Contains(prompt, "## 7. Recent Events (Last Hour)")
+		s.Contains(prompt, "## 8. Health Rule Errors")
+	})
+	s.Run("renders the registered categories in their dedicated section only", func() {
+		s.Contains(prompt, "## 3. Pods")
+		s.NotContains(prompt, "## 6. Pods")
+	})
+}
+
 func TestClusterHealthCheckSuite(t *testing.T) {
 	suite.Run(t, new(ClusterHealthCheckSuite))
 }
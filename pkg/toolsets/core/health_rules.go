@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// ErrHealthRuleNotApplicable is returned by a HealthRule when the resources it checks are not available in the
+// cluster (e.g. OpenShift only resources, or custom resources whose definition is not installed).
+// The rule is skipped and no category is reported for it.
+var ErrHealthRuleNotApplicable = errors.New("health rule not applicable")
+
+// HealthRule is a check evaluated by the cluster-health-check prompt and the cluster_health tool.
+// Rules are registered with RegisterHealthRule, or declared in the core toolset configuration
+// (see HealthRuleConfig).
+type HealthRule interface {
+	// Name uniquely identifies the rule
+	Name() string
+	// Category is the category the findings of the rule are reported in (e.g. Nodes, Pods, Deployments).
+	// Rules reporting in the same category are merged.
+	Category() string
+	// Check returns the number of resources checked and the findings for the ones with issues.
+	// The namespace is empty for cluster-wide checks.
+	Check(ctx context.Context, client api.KubernetesClient, namespace string) (int, []HealthFinding, error)
+}
+
+var healthRuleRegistry []HealthRule
+
+// RegisterHealthRule registers a HealthRule, rules are evaluated in the order they're registered
+func RegisterHealthRule(rule HealthRule) {
+	if slices.ContainsFunc(healthRuleRegistry, func(r HealthRule) bool { return r.Name() == rule.Name() }) {
+		panic("health rule already registered: " + rule.Name())
+	}
+	healthRuleRegistry = append(healthRuleRegistry, rule)
+}
+
+// healthRules returns the registered rules followed by the rules declared in the core toolset configuration.
+// A declared rule replaces the registered rule with the same name.
+func healthRules(configProvider api.ExtendedConfigProvider) []HealthRule {
+	var declared []*HealthRuleConfig
+	if configProvider != nil {
+		if cfg, ok := configProvider.GetToolsetConfig("core"); ok {
+			if coreCfg, ok := cfg.(*Config); ok && coreCfg != nil {
+				declared = coreCfg.HealthRules
+			}
+		}
+	}
+	rules := make([]HealthRule, 0, len(healthRuleRegistry)+len(declared))
+	for _, rule := range healthRuleRegistry {
+		if !slices.ContainsFunc(declared, func(d *HealthRuleConfig) bool { return d.Name() == rule.Name() }) {
+			rules = append(rules, rule)
+		}
+	}
+	for _, rule := range declared {
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// builtinHealthRule is a HealthRule backed by one of the gather*Diagnostics functions
+type builtinHealthRule struct {
+	name     string
+	category string
+	gather   func(params healthCheckParams, namespace string) (*healthCategory, error)
+}
+
+var _ HealthRule = (*builtinHealthRule)(nil)
+
+func (r *builtinHealthRule) Name() string {
+	return r.name
+}
+
+func (r *builtinHealthRule) Category() string {
+	return r.category
+}
+
+func (r *builtinHealthRule) Check(ctx context.Context, client api.KubernetesClient, namespace string) (int, []HealthFinding, error) {
+	category, err := r.gather(healthCheckParams{ctx, client}, namespace)
+	if err != nil {
+		return 0, nil, err
+	}
+	return category.Total, category.Findings, nil
+}
+
+func init() {
+	RegisterHealthRule(&builtinHealthRule{name: "nodes", category: healthCategoryNodes,
+		gather: func(params healthCheckParams, _ string) (*healthCategory, error) {
+			return gatherNodeDiagnostics(params)
+		}})
+	RegisterHealthRule(&builtinHealthRule{name: "cluster-operators", category: healthCategoryClusterOperators,
+		gather: func(params healthCheckParams, _ string) (*healthCategory, error) {
+			return gatherClusterOperatorDiagnostics(params)
+		}})
+	RegisterHealthRule(&builtinHealthRule{name: "pods", category: healthCategoryPods, gather: gatherPodDiagnostics})
+	RegisterHealthRule(&builtinHealthRule{name: "deployments", category: healthCategoryDeployments, gather: workloadDiagnostics("Deployment")})
+	RegisterHealthRule(&builtinHealthRule{name: "statefulsets", category: healthCategoryStatefulSets, gather: workloadDiagnostics("StatefulSet")})
+	RegisterHealthRule(&builtinHealthRule{name: "daemonsets", category: healthCategoryDaemonSets, gather: workloadDiagnostics("DaemonSet")})
+	RegisterHealthRule(&builtinHealthRule{name: "persistentvolumeclaims", category: healthCategoryPVCs, gather: gatherPVCDiagnostics})
+}
+
+func workloadDiagnostics(kind string) func(params healthCheckParams, namespace string) (*healthCategory, error) {
+	return func(params healthCheckParams, namespace string) (*healthCategory, error) {
+		return gatherWorkloadDiagnostics(params, kind, namespace)
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/google/cel-go/interpreter"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const (
+	// healthRuleCostLimit is the maximum CEL runtime cost of a single evaluation of a declared rule expression,
+	// it prevents expressions iterating over large related lists from stalling the health check
+	healthRuleCostLimit = 1_000_000
+	// healthRuleInterruptCheckFrequency is the number of comprehension iterations between checks of the evaluation
+	// context cancellation
+	healthRuleInterruptCheckFrequency = 100
+)
+
+// HealthRuleConfig is a declarative HealthRule that evaluates a CEL expression over each object of a kind.
+// The expression has access to the following variables:
+//   - object: the object being checked (as unstructured content)
+//   - related: the objects of the related kinds in the checked scope, by the name they're declared with
+//   - now: the time at which the rule is evaluated
+//
+// Example (Deployments without a PodDisruptionBudget):
+//
+//	[[toolset_configs.core.health_rules]]
+//	name = "deployment-without-pdb"
+//	category = "Deployments"
+//	api_version = "apps/v1"
+//	kind = "Deployment"
+//	related = { pdbs = { api_version = "policy/v1", kind = "PodDisruptionBudget" } }
+//	expression = "!related.pdbs.exists(p, p.metadata.namespace == object.metadata.namespace && ...)"
+//	message = "No PodDisruptionBudget selects the Deployment"
+type HealthRuleConfig struct {
+	RuleName string `toml:"name"`
+	// RuleCategory is the category the findings are reported in (default: the rule name)
+	RuleCategory string `toml:"category,omitempty"`
+	// Severity of the findings: critical or warning (default: warning)
+	Severity   string `toml:"severity,omitempty"`
+	APIVersion string `toml:"api_version"`
+	Kind       string `toml:"kind"`
+	// Expression is a CEL expression that evaluates to true when the object has an issue
+	Expression string `toml:"expression"`
+	// Message describes the issue
+	Message string `toml:"message,omitempty"`
+	// MessageExpression is a CEL expression that evaluates to the message describing the issue, it takes precedence over Message
+	MessageExpression string                             `toml:"message_expression,omitempty"`
+	Related           map[string]HealthRuleRelatedConfig `toml:"related,omitempty"`
+
+	gvk            schema.GroupVersionKind
+	program        cel.Program
+	messageProgram cel.Program
+	relatedGVKs    map[string]schema.GroupVersionKind
+}
+
+// HealthRuleRelatedConfig is a kind whose objects are made available to the expressions of a HealthRuleConfig
+type HealthRuleRelatedConfig struct {
+	APIVersion string `toml:"api_version"`
+	Kind       string `toml:"kind"`
+}
+
+var _ HealthRule = (*HealthRuleConfig)(nil)
+
+func (r *HealthRuleConfig) Name() string {
+	return r.RuleName
+}
+
+func (r *HealthRuleConfig) Category() string {
+	if r.RuleCategory == "" {
+		return r.RuleName
+	}
+	return r.RuleCategory
+}
+
+// compile validates the rule and compiles its CEL expressions
+func (r *HealthRuleConfig) compile() error {
+	if r.RuleName == "" {
+		return errors.New("name is required")
+	}
+	if r.Severity == "" {
+		r.Severity = HealthSeverityWarning
+	}
+	if r.Severity != HealthSeverityCritical && r.Severity != HealthSeverityWarning {
+		return fmt.Errorf("%s: severity must be %s or %s", r.RuleName, HealthSeverityCritical, HealthSeverityWarning)
+	}
+	var err error
+	if r.gvk, err = healthRuleGVK(r.APIVersion, r.Kind); err != nil {
+		return fmt.Errorf("%s: %w", r.RuleName, err)
+	}
+	r.relatedGVKs = make(map[string]schema.GroupVersionKind, len(r.Related))
+	for name, related := range r.Related {
+		if r.relatedGVKs[name], err = healthRuleGVK(related.APIVersion, related.Kind); err != nil {
+			return fmt.Errorf("%s: related %s: %w", r.RuleName, name, err)
+		}
+	}
+	if r.Expression == "" {
+		return fmt.Errorf("%s: expression is required", r.RuleName)
+	}
+	if r.program, err = healthRuleProgram(r.Expression, cel.BoolType); err != nil {
+		return fmt.Errorf("%s: expression: %w", r.RuleName, err)
+	}
+	if r.MessageExpression != "" {
+		if r.messageProgram, err = healthRuleProgram(r.MessageExpression, cel.StringType); err != nil {
+			return fmt.Errorf("%s: message_expression: %w", r.RuleName, err)
+		}
+	}
+	return nil
+}
+
+func (r *HealthRuleConfig) Check(ctx context.Context, client api.KubernetesClient, namespace string) (int, []HealthFinding, error) {
+	core := kubernetes.NewCore(client)
+	list := func(gvk schema.GroupVersionKind) ([]any, error) {
+		ret, err := core.ResourcesList(ctx, &gvk, namespace, api.ListOptions{})
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("%w: %v", ErrHealthRuleNotApplicable, err)
+		} else if err != nil {
+			return nil, err
+		}
+		items, _ := ret.UnstructuredContent()["items"].([]any)
+		return items, nil
+	}
+	objects, err := list(r.gvk)
+	if err != nil {
+		return 0, nil, err
+	}
+	related := make(map[string][]any, len(r.relatedGVKs))
+	for name, gvk := range r.relatedGVKs {
+		if related[name], err = list(gvk); err != nil {
+			return 0, nil, err
+		}
+	}
+	now := time.Now()
+	findings := []HealthFinding{}
+	for _, object := range objects {
+		content, ok := object.(map[string]any)
+		if !ok {
+			continue
+		}
+		obj := unstructured.Unstructured{Object: content}
+		vars := map[string]any{"object": content, "related": related, "now": now}
+		result, _, err := r.program.ContextEval(ctx, vars)
+		// Evaluations cancelled by the cost limit or the context would fail for the remaining objects too
+		if cancelled := (interpreter.EvalCancelledError{}); errors.As(err, &cancelled) {
+			return 0, nil, fmt.Errorf("failed to evaluate %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		} else if err != nil {
+			klog.V(1).Infof("Health rule %s failed to evaluate %s/%s: %v", r.RuleName, obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+		if issue, _ := result.Value().(bool); !issue {
+			continue
+		}
+		message := r.Message
+		if r.messageProgram != nil {
+			if result, _, err = r.messageProgram.ContextEval(ctx, vars); err == nil {
+				message, _ = result.Value().(string)
+			}
+		}
+		if message == "" {
+			message = "Matched health rule " + r.RuleName
+		}
+		findings = append(findings, HealthFinding{
+			Severity: r.Severity, Kind: r.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Issues: []string{message},
+		})
+	}
+	return len(objects), findings, nil
+}
+
+func healthRuleGVK(apiVersion, kind string) (schema.GroupVersionKind, error) {
+	if apiVersion == "" || kind == "" {
+		return schema.GroupVersionKind{}, errors.New("api_version and kind are required")
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("invalid api_version %s: %w", apiVersion, err)
+	}
+	return gv.WithKind(kind), nil
+}
+
+// healthRuleProgram compiles a CEL expression of a HealthRuleConfig, checking that it evaluates to the expected type
+func healthRuleProgram(expression string, outputType *cel.Type) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("related", cel.MapType(cel.StringType, cel.ListType(cel.DynType))),
+		cel.Variable("now", cel.TimestampType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !slices.Contains([]*cel.Type{outputType, cel.DynType}, ast.OutputType()) {
+		return nil, fmt.Errorf("must evaluate to %s, got %s", outputType, ast.OutputType())
+	}
+	return env.Program(ast,
+		cel.CostLimit(healthRuleCostLimit),
+		cel.InterruptCheckFrequency(healthRuleInterruptCheckFrequency),
+	)
+}
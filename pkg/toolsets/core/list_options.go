@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// listOutputSchema is the input property to override the configured list output for a single call
+func listOutputSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Description: fmt.Sprintf("Optional output format (one of: %s, jsonpath=<template>, custom-columns=<spec>), "+
+			"defaults to the server configured list output. Prefer name, csv, or custom-columns to reduce the size of the result", strings.Join(output.Names, ", ")),
+	}
+}
+
+// requestedListOutput returns the output requested with the output argument, or the configured list output if not provided
+func requestedListOutput(params api.ToolHandlerParams) (output.Output, error) {
+	spec := api.OptionalString(params, "output", "")
+	if spec == "" {
+		return params.ListOutput, nil
+	}
+	return output.FromSpec(spec)
+}
+
+// listLimitSchema is the input property to limit the number of items returned by a list tool
+func listLimitSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Optional maximum number of items to return. If more items are available, the result includes a continue token to retrieve the next page",
+		Minimum:     ptr.To(float64(1)),
+	}
+}
+
+// listContinueSchema is the input property to retrieve the next page of a list tool
+func listContinueSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Optional continue token returned by a previous call with limit to retrieve the next page (the other arguments must not change)",
+	}
+}
+
+// listPagination sets the limit and continue arguments in the list options
+func listPagination(params api.ToolHandlerParams, options *api.ListOptions) error {
+	if limit := params.GetArguments()["limit"]; limit != nil {
+		l, err := api.ParseInt64(limit)
+		if err != nil {
+			return fmt.Errorf("failed to parse limit parameter: %w", err)
+		}
+		if l < 1 {
+			return fmt.Errorf("limit must be greater than 0, got %d", l)
+		}
+		options.Limit = l
+	}
+	options.Continue = api.OptionalString(params, "continue", "")
+	return nil
+}
+
+// listResult prints the list with the list output, followed by the continue token if more items are available
+func listResult(listOutput output.Output, ret runtime.Unstructured) *api.ToolCallResult {
+	out, err := listOutput.PrintObj(ret)
+	if err != nil {
+		return api.NewToolCallResult(out, err)
+	}
+	token, _, _ := unstructured.NestedString(ret.UnstructuredContent(), "metadata", "continue")
+	if token == "" {
+		return api.NewToolCallResult(out, nil)
+	}
+	remaining := ""
+	if count, found, _ := unstructured.NestedInt64(ret.UnstructuredContent(), "metadata", "remainingItemCount"); found {
+		remaining = fmt.Sprintf(" (%d remaining items)", count)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s\n# More results available%s, call again with continue: %s\n", strings.TrimSuffix(out, "\n"), remaining, token), nil)
+}
+
+// verboseSchema is the input property to disable the compaction of the YAML output
+func verboseSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Optional, return the full objects including bookkeeping fields (managedFields, resourceVersion, uid, last-applied-configuration, etc.) that are removed by default to reduce the size of the result",
+		Default:     api.ToRawMessage(false),
+	}
+}
+
+// compact removes the configured noise fields from obj unless the verbose argument is true
+func compact(params api.ToolHandlerParams, obj runtime.Unstructured) {
+	if api.OptionalBool(params, "verbose", false) {
+		return
+	}
+	output.Compact(obj, compactPruneFields(params))
+}
@@ -19,6 +19,11 @@ func initNamespaces(o api.Openshift) []api.ServerTool {
 			Description: "List all the Kubernetes namespaces in the current cluster",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"output":   listOutputSchema(),
+					"limit":    listLimitSchema(),
+					"continue": listContinueSchema(),
+				},
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Namespaces: List",
@@ -26,7 +31,7 @@ func initNamespaces(o api.Openshift) []api.ServerTool {
 				DestructiveHint: ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: namespacesList,
+		}, Handler: namespacesList, MultiTarget: ptr.To(true),
 	})
 	if o.IsOpenShift(context.Background()) {
 		ret = append(ret, api.ServerTool{
@@ -49,11 +54,19 @@ func initNamespaces(o api.Openshift) []api.ServerTool {
 }
 
 func namespacesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ret, err := kubernetes.NewCore(params).NamespacesList(params, api.ListOptions{AsTable: params.ListOutput.AsTable()})
+	listOutput, err := requestedListOutput(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces, %w", err)), nil
+	}
+	options := api.ListOptions{AsTable: listOutput.AsTable()}
+	if err = listPagination(params, &options); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces, %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NamespacesList(params, options)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %w", err)), nil
 	}
-	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+	return listResult(listOutput, ret), nil
 }
 
 func projectsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initNetworkPolicies() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "networkpolicy_check",
+			Description: "Check whether the Kubernetes NetworkPolicies allow traffic from a source Pod to a destination Pod on a port. " +
+				"Evaluates the egress policies selecting the source Pod and the ingress policies selecting the destination Pod, " +
+				"and returns whether the traffic is allowed and which NetworkPolicies allow (or isolate) it",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"source_namespace": {
+						Type:        "string",
+						Description: "Namespace of the source Pod (Optional, current namespace if not provided)",
+					},
+					"source_pod": {
+						Type:        "string",
+						Description: "Name of the source Pod",
+					},
+					"destination_namespace": {
+						Type:        "string",
+						Description: "Namespace of the destination Pod (Optional, current namespace if not provided)",
+					},
+					"destination_pod": {
+						Type:        "string",
+						Description: "Name of the destination Pod",
+					},
+					"port": {
+						Type:        "string",
+						Description: "Destination port number or container port name (e.g. 8080, http)",
+					},
+					"protocol": {
+						Type:        "string",
+						Description: "Protocol of the traffic",
+						Enum:        []any{"TCP", "UDP", "SCTP"},
+						Default:     api.ToRawMessage("TCP"),
+					},
+				},
+				Required: []string{"source_pod", "destination_pod", "port"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "NetworkPolicy: Check",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: networkPolicyCheck},
+		{Tool: api.Tool{
+			Name: "networkpolicy_coverage",
+			Description: "Report the Pods that are not selected by any Kubernetes NetworkPolicy (all their ingress and egress traffic is allowed), " +
+				"with a per Namespace summary of the isolated Pods and default deny policies",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to report the coverage for (reports all namespaces if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "NetworkPolicy: Coverage",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: networkPolicyCoverage},
+	}
+}
+
+func networkPolicyCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	sourcePod, err := api.RequiredString(params, "source_pod")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check network policies, %w", err)), nil
+	}
+	destinationPod, err := api.RequiredString(params, "destination_pod")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check network policies, %w", err)), nil
+	}
+	port, err := api.RequiredString(params, "port")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check network policies, %w", err)), nil
+	}
+	sourceNamespace := params.NamespaceOrDefault(api.OptionalString(params, "source_namespace", ""))
+	destinationNamespace := params.NamespaceOrDefault(api.OptionalString(params, "destination_namespace", ""))
+	protocol := api.OptionalString(params, "protocol", "TCP")
+	result, err := kubernetes.NewCore(params).NetworkPolicyCheck(params, sourceNamespace, sourcePod, destinationNamespace, destinationPod, port, protocol)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check network policies: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+func networkPolicyCoverage(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	report, err := kubernetes.NewCore(params).NetworkPolicyCoverage(params, ns)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to report network policy coverage: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(report, nil), nil
+}
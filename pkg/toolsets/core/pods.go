@@ -32,6 +32,9 @@ func initPods() []api.ServerTool {
 						Description: "Optional Kubernetes field selector to filter pods by field values (e.g. 'status.phase=Running', 'spec.nodeName=node1'). Supported fields: metadata.name, metadata.namespace, spec.nodeName, spec.restartPolicy, spec.schedulerName, spec.serviceAccountName, status.phase (Pending/Running/Succeeded/Failed/Unknown), status.podIP, status.nominatedNodeName. Note: CrashLoopBackOff is a container state, not a pod phase, so it cannot be filtered directly. See https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/",
 						Pattern:     REGEX_FIELDSELECTOR,
 					},
+					"output":   listOutputSchema(),
+					"limit":    listLimitSchema(),
+					"continue": listContinueSchema(),
 				},
 			},
 			Annotations: api.ToolAnnotations{
@@ -40,7 +43,7 @@ func initPods() []api.ServerTool {
 				DestructiveHint: ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsListInAllNamespaces},
+		}, Handler: podsListInAllNamespaces, MultiTarget: ptr.To(true)},
 		{Tool: api.Tool{
 			Name:        "pods_list_in_namespace",
 			Description: "List all the Kubernetes pods in the specified namespace in the current cluster",
@@ -61,6 +64,9 @@ func initPods() []api.ServerTool {
 						Description: "Optional Kubernetes field selector to filter pods by field values (e.g. 'status.phase=Running', 'spec.nodeName=node1'). Supported fields: metadata.name, metadata.namespace, spec.nodeName, spec.restartPolicy, spec.schedulerName, spec.serviceAccountName, status.phase (Pending/Running/Succeeded/Failed/Unknown), status.podIP, status.nominatedNodeName. Note: CrashLoopBackOff is a container state, not a pod phase, so it cannot be filtered directly. See https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/",
 						Pattern:     REGEX_FIELDSELECTOR,
 					},
+					"output":   listOutputSchema(),
+					"limit":    listLimitSchema(),
+					"continue": listContinueSchema(),
 				},
 				Required: []string{"namespace"},
 			},
@@ -70,7 +76,7 @@ func initPods() []api.ServerTool {
 				DestructiveHint: ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsListInNamespace},
+		}, Handler: podsListInNamespace, MultiTarget: ptr.To(true)},
 		{Tool: api.Tool{
 			Name:        "pods_get",
 			Description: "Get a Kubernetes Pod in the current or provided namespace with the provided name",
@@ -85,6 +91,7 @@ func initPods() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the Pod",
 					},
+					"verbose": verboseSchema(),
 				},
 				Required: []string{"name"},
 			},
@@ -262,8 +269,15 @@ func initPods() []api.ServerTool {
 func podsListInAllNamespaces(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	labelSelector := params.GetArguments()["labelSelector"]
 	fieldSelector := params.GetArguments()["fieldSelector"]
+	listOutput, err := requestedListOutput(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces, %w", err)), nil
+	}
 	resourceListOptions := api.ListOptions{
-		AsTable: params.ListOutput.AsTable(),
+		AsTable: listOutput.AsTable(),
+	}
+	if err = listPagination(params, &resourceListOptions); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces, %w", err)), nil
 	}
 	if labelSelector != nil {
 		resourceListOptions.LabelSelector = labelSelector.(string)
@@ -275,7 +289,7 @@ func podsListInAllNamespaces(params api.ToolHandlerParams) (*api.ToolCallResult,
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %w", err)), nil
 	}
-	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+	return listResult(listOutput, ret), nil
 }
 
 func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -283,8 +297,15 @@ func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, err
 	if ns == nil {
 		return api.NewToolCallResult("", errors.New("failed to list pods in namespace, missing argument namespace")), nil
 	}
+	listOutput, err := requestedListOutput(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace, %w", err)), nil
+	}
 	resourceListOptions := api.ListOptions{
-		AsTable: params.ListOutput.AsTable(),
+		AsTable: listOutput.AsTable(),
+	}
+	if err = listPagination(params, &resourceListOptions); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace, %w", err)), nil
 	}
 	labelSelector := params.GetArguments()["labelSelector"]
 	if labelSelector != nil {
@@ -298,7 +319,7 @@ func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, err
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s: %w", ns, err)), nil
 	}
-	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+	return listResult(listOutput, ret), nil
 }
 
 func podsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -314,6 +335,7 @@ func podsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s in namespace %s: %w", name, ns, err)), nil
 	}
+	compact(params, ret)
 	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
 }
 
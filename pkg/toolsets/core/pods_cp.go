@@ -0,0 +1,189 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const (
+	podsCpEncodingText   = "text"
+	podsCpEncodingBase64 = "base64"
+)
+
+func initPodsCp() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "pods_cp_from",
+			Description: "Copy a file from a Kubernetes Pod container and return its content (config files, heap dumps, etc.). Requires the tar binary in the container image",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to copy the file from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to copy the file from",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container to copy the file from (Optional)",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Path of the file in the container. Example: /etc/nginx/nginx.conf",
+					},
+					"encoding": {
+						Type:        "string",
+						Description: "Encoding of the returned file content, use base64 for binary files (Optional, default: text)",
+						Enum:        []any{podsCpEncodingText, podsCpEncodingBase64},
+						Default:     api.ToRawMessage(podsCpEncodingText),
+					},
+					"max_bytes": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum size in bytes of the file to copy, larger files are rejected (Optional, default: %d, maximum: %d)", kubernetes.DefaultPodsCopyMaxBytes, kubernetes.PodsCopyMaxBytesLimit),
+						Default:     api.ToRawMessage(kubernetes.DefaultPodsCopyMaxBytes),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(kubernetes.PodsCopyMaxBytesLimit)),
+					},
+				},
+				Required: []string{"name", "path"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Copy From",
+				ReadOnlyHint:    ptr.To(false), // Runs tar through pods/exec, which isn't available in read-only mode
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsCpFrom},
+		{Tool: api.Tool{
+			Name:        "pods_cp_to",
+			Description: "Copy content to a file in a Kubernetes Pod container, overwriting the file if it already exists. Requires the tar binary in the container image",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to copy the file to",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to copy the file to",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container to copy the file to (Optional)",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Path of the destination file in the container, the parent directory must exist. Example: /tmp/script.sh",
+					},
+					"content": {
+						Type:        "string",
+						Description: "Content of the file",
+					},
+					"encoding": {
+						Type:        "string",
+						Description: "Encoding of the provided content, use base64 for binary files (Optional, default: text)",
+						Enum:        []any{podsCpEncodingText, podsCpEncodingBase64},
+						Default:     api.ToRawMessage(podsCpEncodingText),
+					},
+				},
+				Required: []string{"name", "path", "content"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Copy To",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsCpTo},
+	}
+}
+
+func podsCpFrom(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file from pod, %w", err)), nil
+	}
+	filePath, err := api.RequiredString(params, "path")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file from pod, %w", err)), nil
+	}
+	container := api.OptionalString(params, "container", "")
+	encoding := api.OptionalString(params, "encoding", podsCpEncodingText)
+	if encoding != podsCpEncodingText && encoding != podsCpEncodingBase64 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file from pod, invalid encoding %q", encoding)), nil
+	}
+	maxBytes := kubernetes.DefaultPodsCopyMaxBytes
+	if v, ok := params.GetArguments()["max_bytes"]; ok {
+		if maxBytes, err = api.ParseInt64(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to parse max_bytes parameter: %w", err)), nil
+		}
+	}
+	maxBytes = max(1, min(maxBytes, kubernetes.PodsCopyMaxBytesLimit))
+	content, err := kubernetes.NewCore(params).PodsCopyFrom(params, ns, name, container, filePath, maxBytes)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file %s from pod %s in namespace %s: %w", filePath, name, ns, err)), nil
+	}
+	// Text content is masked regardless of the requested encoding, only binary content is returned verbatim
+	text := utf8.Valid(content)
+	if text {
+		content = []byte(MaskPII(string(content)))
+	}
+	if encoding == podsCpEncodingBase64 {
+		return api.NewToolCallResult(base64.StdEncoding.EncodeToString(content), nil), nil
+	}
+	if !text {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file %s from pod %s in namespace %s: file contains binary data, use the base64 encoding", filePath, name, ns)), nil
+	}
+	if len(content) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("The file %s in pod %s in namespace %s is empty", filePath, name, ns), nil), nil
+	}
+	return api.NewToolCallResult(string(content), nil), nil
+}
+
+func podsCpTo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, %w", err)), nil
+	}
+	filePath, err := api.RequiredString(params, "path")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, %w", err)), nil
+	}
+	contentArg, err := api.RequiredString(params, "content")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, %w", err)), nil
+	}
+	container := api.OptionalString(params, "container", "")
+	var content []byte
+	switch encoding := api.OptionalString(params, "encoding", podsCpEncodingText); encoding {
+	case podsCpEncodingText:
+		content = []byte(contentArg)
+	case podsCpEncodingBase64:
+		if content, err = base64.StdEncoding.DecodeString(contentArg); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, invalid base64 content: %w", err)), nil
+		}
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, invalid encoding %q", encoding)), nil
+	}
+	if int64(len(content)) > kubernetes.PodsCopyMaxBytesLimit {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file to pod, content size (%d bytes) exceeds the maximum allowed size (%d bytes)", len(content), kubernetes.PodsCopyMaxBytesLimit)), nil
+	}
+	if err = kubernetes.NewCore(params).PodsCopyTo(params, ns, name, container, filePath, content); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy file %s to pod %s in namespace %s: %w", filePath, name, ns, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Copied %d bytes to %s in pod %s in namespace %s", len(content), filePath, name, ns), nil), nil
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initPodsDiagnose() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pods_diagnose",
+			Description: "Diagnose why a Kubernetes Pod in the current cluster is failing or not running. " +
+				"Classifies the failure (CrashLoopBackOff, ImagePullBackOff, OOMKilled, CreateContainerConfigError, Pending due to scheduling, taints, quota or unbound PersistentVolumeClaims, probe failures, unhealthy node) " +
+				"from the Pod status, its events, the previous container logs, and the node conditions. " +
+				"Returns a structured verdict with the evidence for each finding and the tools suggested to investigate further",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to diagnose",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to diagnose",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Diagnose",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsDiagnose},
+	}
+}
+
+func podsDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose pod, %w", err)), nil
+	}
+	diagnosis, err := kubernetes.NewCore(params).PodsDiagnose(params, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diagnose pod %s in namespace %s: %w", name, ns, err)), nil
+	}
+	// Evidence may include container logs
+	for i := range diagnosis.Findings {
+		for j := range diagnosis.Findings[i].Evidence {
+			diagnosis.Findings[i].Evidence[j] = MaskPII(diagnosis.Findings[i].Evidence[j])
+		}
+	}
+	return api.NewToolCallResultStructured(diagnosis, nil), nil
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// podsExecReadMaxWait is the maximum number of seconds pods_exec_read can wait for new output
+const podsExecReadMaxWait = 60
+
+func initPodsExecSessions() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "pods_exec_start",
+			Description: "Start an interactive command (shell, REPL such as psql or redis-cli, etc.) in a Kubernetes Pod with stdin attached. Returns a session ID to be used with pods_exec_write, pods_exec_read, and pods_exec_close. Sessions are closed automatically after a period of inactivity (" + kubernetes.DefaultExecSessionIdleTimeout.String() + " by default)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod where the command will be executed",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod where the command will be executed",
+					},
+					"command": {
+						Type:        "array",
+						Description: "Command to execute in the Pod container. The first item is the command to be run, and the rest are the arguments to that command (Optional, defaults to [\"/bin/sh\"]). Example: [\"psql\", \"-U\", \"postgres\"]",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container where the command will be executed (Optional)",
+					},
+					"tty": {
+						Type:        "boolean",
+						Description: "Allocate a TTY for the command, stderr is merged into stdout (Optional, default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Exec Start",
+				DestructiveHint: ptr.To(true), // Depending on the Pod's entrypoint, executing certain commands may kill the Pod
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsExecStart},
+		{Tool: api.Tool{
+			Name:        "pods_exec_write",
+			Description: "Write input to the stdin of an interactive command started with pods_exec_start. Include a trailing newline (\\n) to submit a line",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"session_id": {
+						Type:        "string",
+						Description: "ID of the exec session returned by pods_exec_start",
+					},
+					"input": {
+						Type:        "string",
+						Description: "Input to write to the command stdin",
+					},
+					"close_stdin": {
+						Type:        "boolean",
+						Description: "Close the command stdin (EOF) after writing the input (Optional, default: false)",
+					},
+				},
+				Required: []string{"session_id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Exec Write",
+				DestructiveHint: ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, ClusterAware: ptr.To(false), Handler: podsExecWrite},
+		{Tool: api.Tool{
+			Name:        "pods_exec_read",
+			Description: "Read the stdout and stderr produced by an interactive command started with pods_exec_start since the last read",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"session_id": {
+						Type:        "string",
+						Description: "ID of the exec session returned by pods_exec_start",
+					},
+					"wait": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Number of seconds to wait for new output if there's none buffered (Optional, default: 1, maximum: %d)", podsExecReadMaxWait),
+						Default:     api.ToRawMessage(1),
+						Minimum:     ptr.To(float64(0)),
+						Maximum:     ptr.To(float64(podsExecReadMaxWait)),
+					},
+				},
+				Required: []string{"session_id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Exec Read",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, ClusterAware: ptr.To(false), Handler: podsExecRead},
+		{Tool: api.Tool{
+			Name:        "pods_exec_close",
+			Description: "Terminate an interactive command started with pods_exec_start and release its session",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"session_id": {
+						Type:        "string",
+						Description: "ID of the exec session returned by pods_exec_start",
+					},
+				},
+				Required: []string{"session_id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Exec Close",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, ClusterAware: ptr.To(false), Handler: podsExecClose},
+	}
+}
+
+func podsExecStart(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to start exec session in pod, %w", err)), nil
+	}
+	container := api.OptionalString(params, "container", "")
+	command := []string{"/bin/sh"}
+	if commandArg, ok := params.GetArguments()["command"]; ok {
+		commandItems, ok := commandArg.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to start exec session in pod, invalid command argument")), nil
+		}
+		command = make([]string, 0, len(commandItems))
+		for _, cmd := range commandItems {
+			if s, ok := cmd.(string); ok {
+				command = append(command, s)
+			}
+		}
+	}
+	tty := api.OptionalBool(params, "tty", false)
+	session, err := kubernetes.NewCore(params).PodsExecStart(params, ns, name, container, command, tty)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to start exec session in pod %s in namespace %s: %w", name, ns, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf(
+		"Exec session %s started in pod %s in namespace %s running: %s\n"+
+			"Use pods_exec_write to send input, pods_exec_read to read the output, and pods_exec_close to terminate it",
+		session.ID, session.Name, session.Namespace, strings.Join(session.Command, " ")), nil), nil
+}
+
+func podsExecWrite(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	sessionID, err := api.RequiredString(params, "session_id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to write to exec session, %w", err)), nil
+	}
+	input := api.OptionalString(params, "input", "")
+	closeStdin := api.OptionalBool(params, "close_stdin", false)
+	if err = kubernetes.ExecSessions.Write(params, sessionID, input, closeStdin); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to write to exec session %s: %w", sessionID, err)), nil
+	}
+	ret := fmt.Sprintf("Wrote %d bytes to exec session %s", len(input), sessionID)
+	if closeStdin {
+		ret += " and closed its stdin"
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func podsExecRead(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	sessionID, err := api.RequiredString(params, "session_id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to read from exec session, %w", err)), nil
+	}
+	wait := int64(1)
+	if v, ok := params.GetArguments()["wait"]; ok {
+		if wait, err = api.ParseInt64(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to parse wait parameter: %w", err)), nil
+		}
+	}
+	wait = max(0, min(wait, podsExecReadMaxWait))
+	output, err := kubernetes.ExecSessions.Read(params, sessionID, time.Duration(wait)*time.Second)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to read from exec session %s: %w", sessionID, err)), nil
+	}
+	var sb strings.Builder
+	if output.Running {
+		sb.WriteString(fmt.Sprintf("# Exec session %s is running\n", sessionID))
+	} else if output.Err != nil {
+		sb.WriteString(fmt.Sprintf("# Exec session %s has finished with error: %s\n", sessionID, output.Err))
+	} else {
+		sb.WriteString(fmt.Sprintf("# Exec session %s has finished\n", sessionID))
+	}
+	if output.Discarded > 0 {
+		sb.WriteString(fmt.Sprintf("# %d bytes of unread output were discarded\n", output.Discarded))
+	}
+	if output.Stdout == "" && output.Stderr == "" {
+		sb.WriteString("# No new output\n")
+	}
+	if output.Stdout != "" {
+		sb.WriteString("## stdout\n")
+		sb.WriteString(MaskPII(output.Stdout))
+		sb.WriteString("\n")
+	}
+	if output.Stderr != "" {
+		sb.WriteString("## stderr\n")
+		sb.WriteString(MaskPII(output.Stderr))
+		sb.WriteString("\n")
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func podsExecClose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	sessionID, err := api.RequiredString(params, "session_id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to close exec session, %w", err)), nil
+	}
+	if err = kubernetes.ExecSessions.Close(params, sessionID); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to close exec session %s: %w", sessionID, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Exec session %s closed", sessionID), nil), nil
+}
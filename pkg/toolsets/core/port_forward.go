@@ -0,0 +1,322 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// httpProbeMaxTimeout is the maximum number of seconds http_probe waits for a response
+const httpProbeMaxTimeout = 60
+
+// httpProbeRedacted replaces the values of the sensitive response headers returned by http_probe
+const httpProbeRedacted = "<redacted>"
+
+// httpProbeSensitiveHeaders are the response headers (or header name fragments) whose values are redacted
+var httpProbeSensitiveHeaders = []string{"authorization", "cookie", "token", "secret", "api-key", "apikey", "session"}
+
+// httpProbeURLHeaders are the response headers with URLs, their query and credentials may hold tokens
+var httpProbeURLHeaders = []string{"Location", "Content-Location", "Refresh", "Link"}
+
+// httpProbeHeaderValue returns the value of the response header, redacting the credentials and PII it may hold
+func httpProbeHeaderValue(name, value string) string {
+	lowerName := strings.ToLower(name)
+	for _, sensitive := range httpProbeSensitiveHeaders {
+		if strings.Contains(lowerName, sensitive) {
+			return httpProbeRedacted
+		}
+	}
+	if slices.Contains(httpProbeURLHeaders, name) {
+		value = httpProbeURLQuery.ReplaceAllString(value, "?"+httpProbeRedacted)
+		value = httpProbeURLUserInfo.ReplaceAllString(value, "://"+httpProbeRedacted+"@")
+	}
+	return MaskPII(value)
+}
+
+var (
+	httpProbeURLQuery    = regexp.MustCompile(`\?[^\s>;,]*`)
+	httpProbeURLUserInfo = regexp.MustCompile(`://[^/\s@]+@`)
+)
+
+func initPortForward() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "pods_port_forward",
+			Description: "Forward a local port on the MCP server host to a port of a Kubernetes Pod for ad-hoc checks. Returns the ID and the bound local address of the forward, which can be probed with http_probe. Port forwards are closed automatically after their TTL (" + kubernetes.DefaultPortForwardTTL.String() + " by default)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to forward the port to",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to forward the port to",
+					},
+					"port": {
+						Type:        "integer",
+						Description: "Port of the Pod to forward",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+				},
+				Required: []string{"name", "port"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Port Forward",
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsPortForward},
+		{Tool: api.Tool{
+			Name:        "services_port_forward",
+			Description: "Forward a local port on the MCP server host to a port of a Kubernetes Service (connects to one of the running Pods selected by the Service). Returns the ID and the bound local address of the forward, which can be probed with http_probe. Port forwards are closed automatically after their TTL (" + kubernetes.DefaultPortForwardTTL.String() + " by default)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Service to forward the port to",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Service to forward the port to",
+					},
+					"port": {
+						Type:        "integer",
+						Description: "Port of the Service to forward",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+				},
+				Required: []string{"name", "port"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Services: Port Forward",
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: servicesPortForward},
+		{Tool: api.Tool{
+			Name:        "port_forwards_list",
+			Description: "List the active port forwards opened with pods_port_forward or services_port_forward",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Port Forwards: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, ClusterAware: ptr.To(false), Handler: portForwardsList},
+		{Tool: api.Tool{
+			Name:        "port_forwards_close",
+			Description: "Close a port forward opened with pods_port_forward or services_port_forward",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the port forward to close",
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Port Forwards: Close",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, ClusterAware: ptr.To(false), Handler: portForwardsClose},
+		{Tool: api.Tool{
+			Name:        "http_probe",
+			Description: "Issue an HTTP GET request through a port forward opened with pods_port_forward or services_port_forward and return the response status, headers, and body",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the port forward to send the request through",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Path and query of the request (Optional, default: /). Example: /healthz",
+						Default:     api.ToRawMessage("/"),
+					},
+					"scheme": {
+						Type:        "string",
+						Description: "Scheme of the request, certificates are not verified for https (Optional, default: http)",
+						Enum:        []any{"http", "https"},
+						Default:     api.ToRawMessage("http"),
+					},
+					"timeout": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Number of seconds to wait for the response (Optional, default: 10, maximum: %d)", httpProbeMaxTimeout),
+						Default:     api.ToRawMessage(10),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(httpProbeMaxTimeout)),
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "HTTP: Probe",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, ClusterAware: ptr.To(false), Handler: httpProbe},
+	}
+}
+
+func podsPortForward(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward pod port, %w", err)), nil
+	}
+	port, err := portForwardPort(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward pod port, %w", err)), nil
+	}
+	forward, err := kubernetes.NewCore(params).PodsPortForward(params, ns, name, port)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward port %d of pod %s in namespace %s: %w", port, name, ns, err)), nil
+	}
+	return api.NewToolCallResult(portForwardStarted(forward), nil), nil
+}
+
+func servicesPortForward(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward service port, %w", err)), nil
+	}
+	port, err := portForwardPort(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward service port, %w", err)), nil
+	}
+	forward, err := kubernetes.NewCore(params).ServicesPortForward(params, ns, name, port)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward port %d of service %s in namespace %s: %w", port, name, ns, err)), nil
+	}
+	return api.NewToolCallResult(portForwardStarted(forward), nil), nil
+}
+
+func portForwardsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	forwards, err := kubernetes.PortForwards.List(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list port forwards: %w", err)), nil
+	}
+	if len(forwards) == 0 {
+		return api.NewToolCallResult("No active port forwards", nil), nil
+	}
+	var sb strings.Builder
+	sb.WriteString("ID\tLOCAL ADDRESS\tNAMESPACE\tTARGET\tREMOTE PORT\tEXPIRES\n")
+	for _, forward := range forwards {
+		expires := "never"
+		if !forward.ExpiresAt.IsZero() {
+			expires = forward.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		sb.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%d\t%s\n",
+			forward.ID, forward.LocalAddress, forward.Namespace, portForwardTarget(forward), forward.RemotePort, expires))
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func portForwardsClose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	id, err := api.RequiredString(params, "id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to close port forward, %w", err)), nil
+	}
+	if err = kubernetes.PortForwards.Close(params, id); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to close port forward %s: %w", id, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Port forward %s closed", id), nil), nil
+}
+
+func httpProbe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	id, err := api.RequiredString(params, "id")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to probe port forward, %w", err)), nil
+	}
+	path := api.OptionalString(params, "path", "/")
+	scheme := api.OptionalString(params, "scheme", "http")
+	if scheme != "http" && scheme != "https" {
+		return api.NewToolCallResult("", fmt.Errorf("failed to probe port forward, invalid scheme %q", scheme)), nil
+	}
+	timeout := int64(10)
+	if v, ok := params.GetArguments()["timeout"]; ok {
+		if timeout, err = api.ParseInt64(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to parse timeout parameter: %w", err)), nil
+		}
+	}
+	timeout = max(1, min(timeout, httpProbeMaxTimeout))
+	result, err := kubernetes.PortForwards.HTTPProbe(params, id, scheme, path, time.Duration(timeout)*time.Second)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to probe port forward %s: %w", id, err)), nil
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("GET %s\n", result.URL))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", result.Status))
+	sb.WriteString("## Headers\n")
+	headerNames := make([]string, 0, len(result.Header))
+	for name := range result.Header {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		values := make([]string, 0, len(result.Header.Values(name)))
+		for _, value := range result.Header.Values(name) {
+			values = append(values, httpProbeHeaderValue(name, value))
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", name, strings.Join(values, ", ")))
+	}
+	sb.WriteString("## Body\n")
+	sb.WriteString(MaskPII(result.Body))
+	if result.Truncated {
+		sb.WriteString("\n# Response body was truncated")
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func portForwardPort(params api.ToolHandlerParams) (int32, error) {
+	portArg, ok := params.GetArguments()["port"]
+	if !ok {
+		return 0, fmt.Errorf("port parameter required")
+	}
+	port, err := api.ParseInt64(portArg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port parameter: %w", err)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port parameter: %d is out of range", port)
+	}
+	return int32(port), nil
+}
+
+func portForwardStarted(forward *kubernetes.PortForward) string {
+	return fmt.Sprintf("Port forward %s listening on %s forwarding to %s port %d in namespace %s\n"+
+		"Use http_probe to send HTTP requests through it and port_forwards_close to close it",
+		forward.ID, forward.LocalAddress, portForwardTarget(forward), forward.RemotePort, forward.Namespace)
+}
+
+func portForwardTarget(forward *kubernetes.PortForward) string {
+	if forward.Service != "" {
+		return fmt.Sprintf("service/%s (pod/%s)", forward.Service, forward.Pod)
+	}
+	return "pod/" + forward.Pod
+}
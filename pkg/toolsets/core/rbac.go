@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// rbacAccessRequestProperties are the input properties describing the action to check
+func rbacAccessRequestProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"verb": {
+			Type:        "string",
+			Description: "Verb to check (e.g. get, list, watch, create, update, patch, delete, impersonate, or * for all verbs)",
+		},
+		"resource": {
+			Type:        "string",
+			Description: "Resource (plural, lowercase) to check (e.g. pods, deployments, secrets)",
+		},
+		"api_group": {
+			Type:        "string",
+			Description: "API group of the resource (e.g. apps, batch, rbac.authorization.k8s.io), empty for the core group",
+			Default:     api.ToRawMessage(""),
+		},
+		"subresource": {
+			Type:        "string",
+			Description: "Optional subresource to check (e.g. log, exec, scale, status)",
+		},
+		"name": {
+			Type:        "string",
+			Description: "Optional name of the resource to check",
+		},
+		"namespace": {
+			Type:        "string",
+			Description: "Optional Namespace to check the permission in (checks cluster-wide permissions if not provided)",
+		},
+	}
+}
+
+func initRBAC() []api.ServerTool {
+	canIProperties := rbacAccessRequestProperties()
+	canIProperties["service_account"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Optional ServiceAccount to check the permission for by impersonating it (requires permission to impersonate ServiceAccounts)",
+	}
+	canIProperties["service_account_namespace"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Namespace of the ServiceAccount (Optional, current namespace if not provided)",
+	}
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "rbac_can_i",
+			Description: "Check whether the current identity can perform an action (verb on a resource) in the current cluster using a SelfSubjectAccessReview. " +
+				"Optionally checks the permission for a ServiceAccount by impersonating it",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: canIProperties,
+				Required:   []string{"verb", "resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "RBAC: Can I",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rbacCanI},
+		{Tool: api.Tool{
+			Name: "rbac_who_can",
+			Description: "List the subjects (Users, Groups, and ServiceAccounts) that can perform an action (verb on a resource) in the current cluster " +
+				"by evaluating the Roles, ClusterRoles, RoleBindings, and ClusterRoleBindings. " +
+				"Returns the binding and role granting the permission to each subject",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: rbacAccessRequestProperties(),
+				Required:   []string{"verb", "resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "RBAC: Who Can",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rbacWhoCan},
+		{Tool: api.Tool{
+			Name: "rbac_subject_permissions",
+			Description: "Summarize the RBAC permissions of a User, Group, or ServiceAccount in the current cluster. " +
+				"Returns the rules granted by each RoleBinding and ClusterRoleBinding referencing the subject (directly or through a Group)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the subject",
+						Enum:        []any{"User", "Group", "ServiceAccount"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the subject",
+					},
+					"subject_namespace": {
+						Type:        "string",
+						Description: "Namespace of the ServiceAccount (Optional, current namespace if not provided, ignored for Users and Groups)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to evaluate the RoleBindings in (evaluates all namespaces if not provided), ClusterRoleBindings are always evaluated",
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "RBAC: Subject Permissions",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rbacSubjectPermissions},
+	}
+}
+
+func rbacAccessRequest(params api.ToolHandlerParams) (kubernetes.RBACAccessRequest, error) {
+	verb, err := api.RequiredString(params, "verb")
+	if err != nil {
+		return kubernetes.RBACAccessRequest{}, err
+	}
+	resource, err := api.RequiredString(params, "resource")
+	if err != nil {
+		return kubernetes.RBACAccessRequest{}, err
+	}
+	return kubernetes.RBACAccessRequest{
+		Verb:        verb,
+		Group:       api.OptionalString(params, "api_group", ""),
+		Resource:    resource,
+		Subresource: api.OptionalString(params, "subresource", ""),
+		Name:        api.OptionalString(params, "name", ""),
+		Namespace:   api.OptionalString(params, "namespace", ""),
+	}, nil
+}
+
+func rbacCanI(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	request, err := rbacAccessRequest(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check permission, %w", err)), nil
+	}
+	serviceAccount := api.OptionalString(params, "service_account", "")
+	serviceAccountNamespace := ""
+	if serviceAccount != "" {
+		serviceAccountNamespace = params.NamespaceOrDefault(api.OptionalString(params, "service_account_namespace", ""))
+	}
+	result, err := kubernetes.NewCore(params).RBACCanI(params, request, serviceAccountNamespace, serviceAccount)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check permission: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+func rbacWhoCan(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	request, err := rbacAccessRequest(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subjects, %w", err)), nil
+	}
+	result, err := kubernetes.NewCore(params).RBACWhoCan(params, request)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subjects: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+func rbacSubjectPermissions(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, err := api.RequiredString(params, "kind")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get subject permissions, %w", err)), nil
+	}
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get subject permissions, %w", err)), nil
+	}
+	subjectNamespace := ""
+	switch kind {
+	case "ServiceAccount":
+		subjectNamespace = params.NamespaceOrDefault(api.OptionalString(params, "subject_namespace", ""))
+	case "User", "Group":
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("failed to get subject permissions, unsupported kind: %s", kind)), nil
+	}
+	ns := api.OptionalString(params, "namespace", "")
+	result, err := kubernetes.NewCore(params).RBACSubjectPermissions(params, kind, name, subjectNamespace, ns)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get subject permissions: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
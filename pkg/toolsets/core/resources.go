@@ -49,6 +49,10 @@ func initResources(o api.Openshift) []api.ServerTool {
 						Description: "Optional Kubernetes field selector to filter resources by field values (e.g. 'status.phase=Running', 'metadata.name=myresource'). Supported fields vary by resource type. For Pods: metadata.name, metadata.namespace, spec.nodeName, spec.restartPolicy, spec.schedulerName, spec.serviceAccountName, status.phase (Pending/Running/Succeeded/Failed/Unknown), status.podIP, status.nominatedNodeName. See https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/",
 						Pattern:     REGEX_FIELDSELECTOR,
 					},
+					"output":   listOutputSchema(),
+					"verbose":  verboseSchema(),
+					"limit":    listLimitSchema(),
+					"continue": listContinueSchema(),
 				},
 				Required: []string{"apiVersion", "kind"},
 			},
@@ -58,7 +62,7 @@ func initResources(o api.Openshift) []api.ServerTool {
 				DestructiveHint: ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesList},
+		}, Handler: resourcesList, MultiTarget: ptr.To(true)},
 		{Tool: api.Tool{
 			Name:        "resources_get",
 			Description: "Get a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n" + commonApiVersion,
@@ -81,6 +85,7 @@ func initResources(o api.Openshift) []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the resource",
 					},
+					"verbose": verboseSchema(),
 				},
 				Required: []string{"apiVersion", "kind", "name"},
 			},
@@ -91,6 +96,115 @@ func initResources(o api.Openshift) []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: resourcesGet},
+		{Tool: api.Tool{
+			Name:        "resources_describe",
+			Description: "Describe a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name. Returns a concise report (similar to kubectl describe) with the resource owner chain, the objects it controls (e.g. ReplicaSets and Pods of a Deployment), the Pods and endpoints selected by a Service, the PersistentVolumeClaims mounted by its Pods, the HorizontalPodAutoscalers targeting it, and the recent events of all of them\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, StatefulSet)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to retrieve the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will get resource from configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Describe",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDescribe},
+		{Tool: api.Tool{
+			Name:        "resources_graph",
+			Description: "Get the ownership and dependency graph of the resources in a namespace of the current cluster, or only of the resources connected to a root resource if its apiVersion, kind, and name are provided. Edges include ownerReferences (owns), Service to Endpoints to Pod selection (endpoints, targets, selects), Ingress/Route to Service routing (routes), and Pod to ConfigMap/Secret/PersistentVolumeClaim/ServiceAccount references (mounts, references, uses). Useful to assess the impact (blast radius) of deleting or modifying a resource",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to build the graph for. If not provided, will use the configured namespace",
+					},
+					"apiVersion": {
+						Type:        "string",
+						Description: "Optional apiVersion of the root resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Optional kind of the root resource (examples of valid kind are: Deployment, Service, ConfigMap, Secret)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the root resource, if provided the graph only includes the resources connected to it",
+					},
+					"format": {
+						Type:        "string",
+						Description: "Output format of the graph, the structured nodes and edges are always returned (Optional, default: json)",
+						Enum:        []any{"json", "mermaid", "dot"},
+						Default:     api.ToRawMessage("json"),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Graph",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesGraph},
+		{Tool: api.Tool{
+			Name:        "resources_diff",
+			Description: "Compare a Kubernetes resource, or all the resources of a kind in a namespace if no name is provided, between two clusters (source and destination) by providing the apiVersion, kind, and optionally the namespace and name. Returns a semantic diff of the resources matched by name with the differing field paths and their values, server-populated fields (status, uid, resourceVersion, creationTimestamp, managedFields, clusterIP, etc.) are ignored. Useful to detect configuration drift between environments (e.g. staging and production)\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resources (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to compare the namespaced resources in (ignored in case of cluster scoped resources). If not provided, will use the configured namespace of the source cluster",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the resource to compare, if not provided all the resources of the kind in the namespace are compared",
+					},
+					"source": {
+						Type:        "string",
+						Description: "Optional cluster (or context) to compare from, either its name or a selector of its metadata (e.g. env=staging). If not provided, will use the default one",
+					},
+					"destination": {
+						Type:        "string",
+						Description: "Cluster (or context) to compare with, either its name or a selector of its metadata (e.g. env=prod)",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "destination"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Diff",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDiff, ClusterAware: ptr.To(false), CrossTarget: ptr.To(true)},
 		{Tool: api.Tool{
 			Name:        "resources_create_or_update",
 			Description: "Create or update a Kubernetes resource in the current cluster by providing a YAML or JSON representation of the resource\n" + commonApiVersion,
@@ -192,8 +306,15 @@ func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		namespace = ""
 	}
 	labelSelector := params.GetArguments()["labelSelector"]
+	listOutput, err := requestedListOutput(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
+	}
 	resourceListOptions := api.ListOptions{
-		AsTable: params.ListOutput.AsTable(),
+		AsTable: listOutput.AsTable(),
+	}
+	if err = listPagination(params, &resourceListOptions); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
 	}
 
 	if labelSelector != nil {
@@ -225,7 +346,10 @@ func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %w", err)), nil
 	}
-	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+	if listOutput.GetName() == output.Yaml.GetName() {
+		compact(params, ret)
+	}
+	return listResult(listOutput, ret), nil
 }
 
 func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -256,9 +380,119 @@ func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get resource: %w", err)), nil
 	}
+	compact(params, ret)
 	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
 }
 
+func resourcesDescribe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource, %s", err)), nil
+	}
+	ns := api.OptionalString(params, "namespace", "")
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource, %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesDescribe(params, gvk, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource: %w", err)), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal resource description: %w", err)), nil
+	}
+	return api.NewToolCallResult("# Description of "+gvk.Kind+" "+name+" (YAML) is below\n"+marshalled, nil), nil
+}
+
+func resourcesGraph(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ns := api.OptionalString(params, "namespace", "")
+	name := api.OptionalString(params, "name", "")
+	var root *schema.GroupVersionKind
+	if name != "" {
+		var err error
+		if root, err = parseGroupVersionKind(params.GetArguments()); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get resources graph, %s", err)), nil
+		}
+	}
+	graph, err := kubernetes.NewCore(params).ResourcesGraph(params, ns, root, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resources graph: %w", err)), nil
+	}
+	result := api.NewToolCallResultStructured(graph, nil)
+	switch format := api.OptionalString(params, "format", "json"); format {
+	case "mermaid":
+		result.Content = graph.Mermaid()
+	case "dot":
+		result.Content = graph.DOT()
+	case "json":
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resources graph, invalid format %s", format)), nil
+	}
+	return result, nil
+}
+
+func resourcesDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, %s", err)), nil
+	}
+	destination, err := api.RequiredString(params, "destination")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, %w", err)), nil
+	}
+	if params.Targets == nil {
+		return api.NewToolCallResult("", errors.New("failed to diff resources, no targets available")), nil
+	}
+	source := api.OptionalString(params, "source", params.Targets.GetDefaultTarget())
+	sourceClient, err := params.Targets.GetTargetClient(params, source)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, invalid source %s: %w", source, err)), nil
+	}
+	destinationClient, err := params.Targets.GetTargetClient(params, destination)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, invalid destination %s: %w", destination, err)), nil
+	}
+	ns := api.OptionalString(params, "namespace", "")
+	name := api.OptionalString(params, "name", "")
+	diff, err := kubernetes.NewCore(sourceClient).ResourcesDiff(sourceClient, destinationClient,
+		kubernetes.NewCore(destinationClient), gvk, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources: %w", err)), nil
+	}
+	diff.Source = sourceClient.Target
+	diff.Destination = destinationClient.Target
+	// Differing values may include environment variables, arguments or annotations with PII
+	for i := range diff.Resources {
+		for j := range diff.Resources[i].Differences {
+			diff.Resources[i].Differences[j].Source = maskPIIValue(diff.Resources[i].Differences[j].Source)
+			diff.Resources[i].Differences[j].Destination = maskPIIValue(diff.Resources[i].Differences[j].Destination)
+		}
+	}
+	return api.NewToolCallResultStructured(diff, nil), nil
+}
+
+// maskPIIValue returns the unstructured value with the PII masked in all its strings
+func maskPIIValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return MaskPII(v)
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = maskPIIValue(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskPIIValue(item)
+		}
+		return masked
+	}
+	return value
+}
+
 func resourcesCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	resource := params.GetArguments()["resource"]
 	if resource == nil || resource == "" {
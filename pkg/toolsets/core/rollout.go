@@ -0,0 +1,243 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initRollout() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "rollout_status",
+			Description: "Get the rollout status of a Deployment, StatefulSet, or DaemonSet in the current cluster: whether the rollout is complete, a message describing its progress, replica counts, and compact status conditions",
+			InputSchema: rolloutSchema("get the rollout status of", nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutStatus},
+		{Tool: api.Tool{
+			Name:        "rollout_history",
+			Description: "Get the rollout history of a Deployment (from its ReplicaSets), StatefulSet, or DaemonSet (from its ControllerRevisions) in the current cluster, including revision numbers, change causes, container images, and which revision is current",
+			InputSchema: rolloutSchema("get the rollout history of", nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: History",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutHistory},
+		{Tool: api.Tool{
+			Name:        "rollout_restart",
+			Description: "Restart the Pods of a Deployment, StatefulSet, or DaemonSet in the current cluster with a rolling update (same as kubectl rollout restart). Use rollout_status to follow the progress of the restart",
+			InputSchema: rolloutSchema("restart", nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Restart",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutRestart},
+		{Tool: api.Tool{
+			Name:        "rollout_undo",
+			Description: "Roll back a Deployment, StatefulSet, or DaemonSet in the current cluster to a previous revision (same as kubectl rollout undo). Use rollout_history to list the available revisions",
+			InputSchema: rolloutSchema("roll back", map[string]*jsonschema.Schema{
+				"toRevision": {
+					Type:        "integer",
+					Description: "Optional revision to roll back to. If not provided, rolls back to the previous revision",
+				},
+			}),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Undo",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutUndo},
+		{Tool: api.Tool{
+			Name:        "rollout_pause",
+			Description: "Pause the rollout of a Deployment in the current cluster, changes to its Pod template won't be rolled out until it's resumed with rollout_resume",
+			InputSchema: rolloutDeploymentSchema("pause"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Pause",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutPause},
+		{Tool: api.Tool{
+			Name:        "rollout_resume",
+			Description: "Resume the rollout of a paused Deployment in the current cluster, pending changes to its Pod template will be rolled out",
+			InputSchema: rolloutDeploymentSchema("resume"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Resume",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutResume},
+	}
+}
+
+func rolloutSchema(action string, extra map[string]*jsonschema.Schema) *jsonschema.Schema {
+	kinds := make([]any, 0, len(kubernetes.RolloutKinds))
+	for _, kind := range kubernetes.RolloutKinds {
+		kinds = append(kinds, kind)
+	}
+	properties := map[string]*jsonschema.Schema{
+		"kind": {
+			Type:        "string",
+			Description: "Kind of the workload to " + action,
+			Enum:        kinds,
+		},
+		"namespace": {
+			Type:        "string",
+			Description: "Optional Namespace of the workload. If not provided, will use the configured namespace",
+		},
+		"name": {
+			Type:        "string",
+			Description: "Name of the workload",
+		},
+	}
+	for k, v := range extra {
+		properties[k] = v
+	}
+	return &jsonschema.Schema{
+		Type:       "object",
+		Properties: properties,
+		Required:   []string{"kind", "name"},
+	}
+}
+
+func rolloutDeploymentSchema(action string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"namespace": {
+				Type:        "string",
+				Description: "Optional Namespace of the Deployment. If not provided, will use the configured namespace",
+			},
+			"name": {
+				Type:        "string",
+				Description: "Name of the Deployment to " + action,
+			},
+		},
+		Required: []string{"name"},
+	}
+}
+
+// rolloutParams parses the common kind, namespace, and name arguments of the rollout tools
+func rolloutParams(params api.ToolHandlerParams, defaultKind string) (kind, namespace, name string, err error) {
+	kind = api.OptionalString(params, "kind", defaultKind)
+	if kind == "" {
+		return "", "", "", fmt.Errorf("kind parameter required")
+	}
+	namespace = api.OptionalString(params, "namespace", "")
+	name, err = api.RequiredString(params, "name")
+	return
+}
+
+func rolloutStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, ns, name, err := rolloutParams(params, "")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout status, %w", err)), nil
+	}
+	status, err := kubernetes.NewCore(params).RolloutStatus(params, kind, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout status of %s %s: %w", kind, name, err)), nil
+	}
+	marshalled, err := output.MarshalYaml(status)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal rollout status: %w", err)), nil
+	}
+	return api.NewToolCallResult("# Rollout status (YAML) is below\n"+marshalled, nil), nil
+}
+
+func rolloutHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, ns, name, err := rolloutParams(params, "")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history, %w", err)), nil
+	}
+	revisions, err := kubernetes.NewCore(params).RolloutHistory(params, kind, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history of %s %s: %w", kind, name, err)), nil
+	}
+	if len(revisions) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No rollout history found for %s %s", kind, name), nil), nil
+	}
+	marshalled, err := output.MarshalYaml(revisions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal rollout history: %w", err)), nil
+	}
+	return api.NewToolCallResult("# Rollout history (YAML) is below\n"+marshalled, nil), nil
+}
+
+func rolloutRestart(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, ns, name, err := rolloutParams(params, "")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restart rollout, %w", err)), nil
+	}
+	restarted, err := kubernetes.NewCore(params).RolloutRestart(params, kind, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restart %s %s: %w", kind, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s %s in namespace %s restarted, use rollout_status to follow the progress", kind, name, restarted.GetNamespace()), nil), nil
+}
+
+func rolloutUndo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, ns, name, err := rolloutParams(params, "")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout, %w", err)), nil
+	}
+	var toRevision int64
+	if v, ok := params.GetArguments()["toRevision"]; ok && v != nil {
+		if toRevision, err = api.ParseInt64(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout, invalid toRevision: %w", err)), nil
+		}
+	}
+	revision, skipped, err := kubernetes.NewCore(params).RolloutUndo(params, kind, ns, name, toRevision)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout of %s %s: %w", kind, name, err)), nil
+	}
+	if skipped {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s is already at revision %d, skipped rollback", kind, name, revision.Revision), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s %s rolled back to revision %d, use rollout_status to follow the progress", kind, name, revision.Revision), nil), nil
+}
+
+func rolloutPause(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	return rolloutSetPaused(params, "pause")
+}
+
+func rolloutResume(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	return rolloutSetPaused(params, "resume")
+}
+
+func rolloutSetPaused(params api.ToolHandlerParams, action string) (*api.ToolCallResult, error) {
+	kind, ns, name, err := rolloutParams(params, "Deployment")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to %s rollout, %w", action, err)), nil
+	}
+	core := kubernetes.NewCore(params)
+	var deployment *unstructured.Unstructured
+	if action == "pause" {
+		deployment, err = core.RolloutPause(params, kind, ns, name)
+	} else {
+		deployment, err = core.RolloutResume(params, kind, ns, name)
+	}
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to %s rollout of %s %s: %w", action, kind, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%s %s in namespace %s %sd", kind, name, deployment.GetNamespace(), action), nil), nil
+}
@@ -31,7 +31,18 @@ func (t *Toolset) GetTools(o api.Openshift) []api.ServerTool {
 		initNamespaces(o),
 		initNodes(),
 		initPods(),
+		initPodsDiagnose(),
+		initPodsExecSessions(),
+		initPodsCp(),
+		initPortForward(),
+		initDebug(),
+		initRollout(),
 		initResources(o),
+		initClusterHealth(),
+		initCertificates(),
+		initCapacity(),
+		initNetworkPolicies(),
+		initRBAC(),
 	)
 }
 
@@ -0,0 +1,156 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+const defaultRestartsWindow = "1h"
+
+// promDurationRegexp matches a PromQL range duration (e.g. 5m, 1h30m, 7d)
+var promDurationRegexp = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+// podsUsageQueries are the PromQL queries for the pod resource usage, with the label matchers as argument
+var podsUsageQueries = map[string]string{
+	"cpu":    `sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{%s,container!="",container!="POD"}[5m]))`,
+	"memory": `sum by (namespace, pod) (container_memory_working_set_bytes{%s,container!="",container!="POD"})`,
+}
+
+func initPods() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "prometheus_pods_usage",
+			Description: "Get the CPU (cores) or memory (working set bytes) usage of the Pods in a Namespace over time from Prometheus. " +
+				"Unlike pods_top, which returns the current usage from the Metrics Server, this returns historical data",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pods",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Optional name (or regular expression) of the Pods to get the usage for (all Pods in the Namespace if not provided)",
+					},
+					"resource": {
+						Type:        "string",
+						Description: "Resource to get the usage for",
+						Enum:        []any{"cpu", "memory"},
+						Default:     api.ToRawMessage("cpu"),
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Length of the range ending now (e.g. 30m, 6h, 24h)",
+						Default:     api.ToRawMessage(defaultRangeDuration),
+					},
+					"step": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional query resolution step (e.g. 30s, 5m), defaults to a step returning %d points per series", maxRangePoints),
+					},
+				},
+				Required: []string{"namespace"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Pods Usage",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsUsage},
+		{Tool: api.Tool{
+			Name:        "prometheus_pods_restarts",
+			Description: "Get the number of container restarts of the Pods in a Namespace over a time window from Prometheus (requires kube-state-metrics)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pods",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Optional name (or regular expression) of the Pods to get the restarts for (all Pods in the Namespace if not provided)",
+					},
+					"window": {
+						Type:        "string",
+						Description: "Time window to count the restarts in, as a PromQL duration (e.g. 30m, 1h, 1d)",
+						Default:     api.ToRawMessage(defaultRestartsWindow),
+					},
+				},
+				Required: []string{"namespace"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Pods Restarts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsRestarts},
+	}
+}
+
+func podsUsage(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	matchers, err := podMatchers(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods usage, %w", err)), nil
+	}
+	resource := api.OptionalString(params, "resource", "cpu")
+	usageQuery, ok := podsUsageQueries[resource]
+	if !ok {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods usage, unsupported resource: %s", resource)), nil
+	}
+	start, end, step, err := rangeParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods usage, %w", err)), nil
+	}
+	result, err := prometheus.NewPrometheus(params, params.RESTConfig()).
+		QueryRange(params, fmt.Sprintf(usageQuery, matchers), formatTime(start), formatTime(end), step.String())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods usage: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+func podsRestarts(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	matchers, err := podMatchers(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods restarts, %w", err)), nil
+	}
+	window := api.OptionalString(params, "window", defaultRestartsWindow)
+	if !promDurationRegexp.MatchString(window) {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods restarts, invalid window: %s", window)), nil
+	}
+	restartsQuery := fmt.Sprintf(`sum by (namespace, pod, container) (increase(kube_pod_container_status_restarts_total{%s}[%s]))`, matchers, window)
+	result, err := prometheus.NewPrometheus(params, params.RESTConfig()).Query(params, restartsQuery, "")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods restarts: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+// podMatchers returns the PromQL label matchers for the namespace and pod arguments, values are quoted to prevent
+// injection into the query
+func podMatchers(params api.ToolHandlerParams) (string, error) {
+	namespace, err := api.RequiredString(params, "namespace")
+	if err != nil {
+		return "", err
+	}
+	matchers := "namespace=" + strconv.Quote(namespace)
+	if pod := api.OptionalString(params, "pod", ""); pod != "" {
+		if _, err = regexp.Compile(pod); err != nil {
+			return "", fmt.Errorf("invalid pod regular expression: %w", err)
+		}
+		matchers += ",pod=~" + strconv.Quote(pod)
+	}
+	return matchers, nil
+}
@@ -0,0 +1,173 @@
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/prometheus"
+)
+
+const (
+	defaultRangeDuration = "1h"
+	// maxRangePoints is the number of points per series used to compute the default step of range queries
+	maxRangePoints = 120
+	minRangeStep   = 15 * time.Second
+)
+
+func initQuery() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "prometheus_query",
+			Description: "Evaluate a PromQL instant query against the configured Prometheus (or Thanos Querier) and return the result vector or scalar",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL expression to evaluate (e.g. sum by (namespace) (kube_pod_info))",
+					},
+					"time": {
+						Type:        "string",
+						Description: "Optional evaluation time as an RFC3339 or Unix timestamp (defaults to the current time)",
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: query},
+		{Tool: api.Tool{
+			Name: "prometheus_query_range",
+			Description: "Evaluate a PromQL query over a range of time against the configured Prometheus (or Thanos Querier) and return the result matrix. " +
+				"Use it to analyze how metrics evolved over time (historical data)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL expression to evaluate (e.g. rate(container_cpu_usage_seconds_total[5m]))",
+					},
+					"start": {
+						Type:        "string",
+						Description: "Optional start of the range as an RFC3339 or Unix timestamp (defaults to end minus duration)",
+					},
+					"end": {
+						Type:        "string",
+						Description: "Optional end of the range as an RFC3339 or Unix timestamp (defaults to the current time)",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Length of the range ending at end, used when start is not provided (e.g. 30m, 6h, 24h)",
+						Default:     api.ToRawMessage(defaultRangeDuration),
+					},
+					"step": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional query resolution step (e.g. 30s, 5m), defaults to a step returning %d points per series", maxRangePoints),
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Prometheus: Query Range",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: queryRange},
+	}
+}
+
+func query(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	q, err := api.RequiredString(params, "query")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus, %w", err)), nil
+	}
+	at := api.OptionalString(params, "time", "")
+	if at != "" {
+		t, err := parseTime(at)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to parse time parameter: %w", err)), nil
+		}
+		at = formatTime(t)
+	}
+	result, err := prometheus.NewPrometheus(params, params.RESTConfig()).Query(params, q, at)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+func queryRange(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	q, err := api.RequiredString(params, "query")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus, %w", err)), nil
+	}
+	start, end, step, err := rangeParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus, %w", err)), nil
+	}
+	result, err := prometheus.NewPrometheus(params, params.RESTConfig()).QueryRange(params, q, formatTime(start), formatTime(end), step.String())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	return api.NewToolCallResultStructured(result, nil), nil
+}
+
+// rangeParams resolves the start, end, and step of a range query from the start, end, duration, and step arguments
+func rangeParams(params api.ToolHandlerParams) (start, end time.Time, step time.Duration, err error) {
+	end = time.Now()
+	if v := api.OptionalString(params, "end", ""); v != "" {
+		if end, err = parseTime(v); err != nil {
+			return start, end, step, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	if v := api.OptionalString(params, "start", ""); v != "" {
+		if start, err = parseTime(v); err != nil {
+			return start, end, step, fmt.Errorf("invalid start: %w", err)
+		}
+	} else {
+		duration, err := time.ParseDuration(api.OptionalString(params, "duration", defaultRangeDuration))
+		if err != nil || duration <= 0 {
+			return start, end, step, fmt.Errorf("invalid duration: %s", api.OptionalString(params, "duration", defaultRangeDuration))
+		}
+		start = end.Add(-duration)
+	}
+	if !start.Before(end) {
+		return start, end, step, fmt.Errorf("start %s must be before end %s", formatTime(start), formatTime(end))
+	}
+	if v := api.OptionalString(params, "step", ""); v != "" {
+		if step, err = time.ParseDuration(v); err != nil || step <= 0 {
+			return start, end, step, fmt.Errorf("invalid step: %s", v)
+		}
+	} else {
+		step = max(minRangeStep, end.Sub(start)/maxRangePoints).Round(time.Second)
+	}
+	return start, end, step, nil
+}
+
+// parseTime parses an RFC3339 or Unix (optionally fractional) timestamp
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s is not an RFC3339 or Unix timestamp", value)
+	}
+	return time.UnixMilli(int64(seconds * 1000)), nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,36 @@
+package prometheus
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "prometheus"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for querying historical metrics from Prometheus or Thanos, check the [Prometheus documentation](https://github.com/containers/kubernetes-mcp-server/blob/main/docs/PROMETHEUS.md) for more details."
+}
+
+func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initQuery(),
+		initPods(),
+	)
+}
+
+func (t *Toolset) GetPrompts() []api.ServerPrompt {
+	// Prometheus toolset does not provide prompts
+	return nil
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}